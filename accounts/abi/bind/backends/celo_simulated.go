@@ -0,0 +1,304 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/consensustest"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	istanbulBackend "github.com/celo-org/celo-blockchain/consensus/istanbul/backend"
+	"github.com/celo-org/celo-blockchain/contracts/blockchain_parameters"
+	gpm "github.com/celo-org/celo-blockchain/contracts/gasprice_minimum"
+	"github.com/celo-org/celo-blockchain/contracts/random"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/eth/filters"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/mycelo/genesis"
+)
+
+// sealTimeout is how long Commit waits for the validator to agree on a block.
+const sealTimeout = 10 * time.Second
+
+var errNotCeloBackend = errors.New("simulated backend was not created with the celo core contracts")
+
+// celoValidator is the istanbul validator producing the blocks of a simulated
+// backend created by NewCeloSimulatedBackend.
+type celoValidator struct {
+	engine  *istanbulBackend.Backend
+	clock   *simulatedClock
+	address common.Address
+}
+
+// simulatedClock is the clock of the validator engine of a simulated backend,
+// which can be moved ahead of the system time to produce blocks without waiting
+// for their timestamps.
+type simulatedClock struct {
+	offset int64 // Accessed atomically
+}
+
+// Now returns the system time moved ahead by the clock offset.
+func (c *simulatedClock) Now() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&c.offset)))
+}
+
+// Advance moves the clock forward by d.
+func (c *simulatedClock) Advance(d time.Duration) {
+	atomic.AddInt64(&c.offset, int64(d))
+}
+
+// NewCeloSimulatedBackend creates a new binding backend on a chain with the celo
+// core contracts, generating its genesis from cfg and the contracts built in
+// contractsBuildPath the same way mycelo does.
+//
+// Blocks are produced by a single istanbul validator running in process, so
+// transactions pay fees through the gas price minimum and fee currency contracts
+// and epoch rewards are distributed at the end of each epoch. The accounts must
+// therefore configure exactly one validator.
+func NewCeloSimulatedBackend(accounts *env.AccountsConfig, cfg *genesis.Config, contractsBuildPath string) (*SimulatedBackend, error) {
+	if accounts.NumValidators != 1 {
+		return nil, fmt.Errorf("celo simulated backend needs exactly one validator, have %d", accounts.NumValidators)
+	}
+	gen, err := genesis.GenerateGenesis(accounts, cfg, contractsBuildPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate genesis: %w", err)
+	}
+	account := accounts.ValidatorAccounts()[0]
+	return newCeloSimulatedBackend(gen, account.PrivateKey)
+}
+
+// newCeloSimulatedBackend creates a backend on the genesis, with the key of its
+// only validator.
+func newCeloSimulatedBackend(gen *core.Genesis, key *ecdsa.PrivateKey) (*SimulatedBackend, error) {
+	database := rawdb.NewMemoryDatabase()
+	if _, err := gen.Commit(database); err != nil {
+		return nil, err
+	}
+
+	config := *istanbul.DefaultConfig
+	config.ReplicaStateDBPath = ""
+	config.ValidatorEnodeDBPath = ""
+	config.VersionCertificateDBPath = ""
+	config.RoundStateDBPath = ""
+	config.Validator = true
+	clock := new(simulatedClock)
+	config.Now = clock.Now
+	istanbul.ApplyParamsChainConfigToConfig(gen.Config, &config)
+	engine := istanbulBackend.New(&config, database).(*istanbulBackend.Backend)
+
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	engine.Authorize(address, address, &key.PublicKey,
+		istanbulBackend.DecryptFn(key), istanbulBackend.SignFn(key), istanbulBackend.SignBLSFn(key), istanbulBackend.SignHashFn(key))
+
+	blockchain, err := core.NewBlockChain(database, nil, gen.Config, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	engine.SetChain(
+		blockchain,
+		blockchain.CurrentBlock,
+		func(hash common.Hash) (*state.StateDB, error) {
+			stateRoot := blockchain.GetHeaderByHash(hash).Root
+			return blockchain.StateAt(stateRoot)
+		},
+	)
+	engine.SetBroadcaster(&consensustest.MockBroadcaster{})
+	engine.SetP2PServer(consensustest.NewMockP2PServer(&key.PublicKey))
+	engine.SetCallBacks(blockchain.HasBadBlock,
+		func(block *types.Block, state *state.StateDB) (types.Receipts, []*types.Log, uint64, error) {
+			return blockchain.Processor().Process(block, state, *blockchain.GetVMConfig())
+		},
+		blockchain.Validator().ValidateState,
+		func(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB) {
+			if err := blockchain.InsertPreprocessedBlock(block, receipts, logs, state); err != nil {
+				panic(fmt.Sprintf("could not InsertPreprocessedBlock: %v", err))
+			}
+		})
+	if err := engine.StartValidating(); err != nil {
+		blockchain.Stop()
+		return nil, err
+	}
+
+	backend := &SimulatedBackend{
+		database:   database,
+		blockchain: blockchain,
+		config:     gen.Config,
+		events:     filters.NewEventSystem(&filterBackend{database, blockchain}, false),
+		validator:  &celoValidator{engine: engine, clock: clock, address: address},
+	}
+	backend.rollback(blockchain.CurrentBlock())
+	return backend, nil
+}
+
+// AdvanceEpoch commits the pending block, then empty blocks up to the last
+// block of the epoch, so that the epoch rewards are distributed and the next
+// committed block starts a new epoch.
+// It is only supported by backends created with NewCeloSimulatedBackend.
+func (b *SimulatedBackend) AdvanceEpoch() error {
+	if b.validator == nil {
+		return errNotCeloBackend
+	}
+	for {
+		b.Commit()
+		if istanbul.IsLastBlockOfEpoch(b.blockchain.CurrentBlock().NumberU64(), b.config.Istanbul.Epoch) {
+			return nil
+		}
+	}
+}
+
+// closeCelo stops the validator of the backend.
+func (b *SimulatedBackend) closeCelo() error {
+	b.validator.engine.StopValidating()
+	b.blockchain.Stop()
+	return b.validator.engine.Close()
+}
+
+// commitCelo has the validator agree on the pending block, waiting until the
+// block is inserted in the chain.
+func (b *SimulatedBackend) commitCelo() (*types.Block, error) {
+	engine := b.validator.engine
+
+	chainHeadCh := make(chan core.ChainHeadEvent, 10)
+	sub := b.blockchain.SubscribeChainHeadEvent(chainHeadCh)
+	defer sub.Unsubscribe()
+
+	if err := engine.Seal(b.blockchain, b.pendingBlock); err != nil {
+		return nil, err
+	}
+	var block *types.Block
+	select {
+	case ev := <-chainHeadCh:
+		block = ev.Block
+	case <-time.After(sealTimeout):
+		return nil, errors.New("timed out waiting for the validator to commit the block")
+	}
+	// Like the miner does on new work, let the core move on to the next sequence.
+	go engine.EventMux().Post(istanbul.FinalCommittedEvent{})
+	return block, nil
+}
+
+// buildCeloBlock builds a block on top of parent with the given transactions
+// the way the validator would, returning it with its state.
+func (b *SimulatedBackend) buildCeloBlock(parent *types.Block, txs types.Transactions) (*types.Block, *state.StateDB, error) {
+	engine, clock := b.validator.engine, b.validator.clock
+
+	// Move the engine clock to the time of the block, so that Prepare does not
+	// wait for it.
+	blockTime := time.Unix(int64(parent.Time()+b.config.Istanbul.BlockPeriod), 0)
+	if delay := blockTime.Sub(clock.Now()); delay > 0 {
+		clock.Advance(delay)
+	}
+
+	num := parent.Number()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     num.Add(num, common.Big1),
+		Coinbase:   b.validator.address,
+	}
+	if err := engine.Prepare(b.blockchain, header); err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare header: %w", err)
+	}
+	statedb, err := b.blockchain.StateAt(parent.Root())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vmRunner := b.blockchain.NewEVMRunner(header, statedb)
+	randomness, err := b.revealAndCommitRandomness(header, statedb, vmRunner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasPool := new(core.GasPool).AddGas(blockchain_parameters.GetBlockGasLimitOrDefault(vmRunner))
+	sysCtx := core.NewSysContractCallCtx(b.blockchain.NewEVMRunner(header, statedb.Copy()))
+	receipts := make([]*types.Receipt, 0, len(txs))
+	for i, tx := range txs {
+		statedb.Prepare(tx.Hash(), i)
+		receipt, err := core.ApplyTransaction(b.config, b.blockchain, &b.validator.address, gasPool, statedb, header, tx, &header.GasUsed, *b.blockchain.GetVMConfig(), vmRunner, sysCtx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not apply transaction %s: %w", tx.Hash().Hex(), err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	block, err := engine.FinalizeAndAssemble(b.blockchain, header, statedb, txs, receipts, randomness)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := engine.UpdateValSetDiff(b.blockchain, block.MutableHeader(), statedb); err != nil {
+		return nil, nil, err
+	}
+	return block, statedb, nil
+}
+
+// revealAndCommitRandomness plays the part of the validator in the random
+// beacon, revealing the randomness it committed to in its previous block.
+func (b *SimulatedBackend) revealAndCommitRandomness(header *types.Header, statedb *state.StateDB, vmRunner vm.EVMRunner) (*types.Randomness, error) {
+	if !random.IsRunning(vmRunner) {
+		return &types.EmptyRandomness, nil
+	}
+	engine := b.validator.engine
+
+	lastCommitment, err := random.GetLastCommitment(vmRunner, b.validator.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last commitment: %w", err)
+	}
+	lastRandomness := common.Hash{}
+	if (lastCommitment != common.Hash{}) {
+		lastRandomnessParentHash := rawdb.ReadRandomCommitmentCache(b.database, lastCommitment)
+		if (lastRandomnessParentHash == common.Hash{}) {
+			if err := b.blockchain.RecoverRandomnessCache(lastCommitment, header.ParentHash); err != nil {
+				return nil, fmt.Errorf("failed to recover the randomness cache: %w", err)
+			}
+			lastRandomnessParentHash = rawdb.ReadRandomCommitmentCache(b.database, lastCommitment)
+		}
+		if lastRandomness, _, err = engine.GenerateRandomness(lastRandomnessParentHash); err != nil {
+			return nil, fmt.Errorf("failed to generate last randomness: %w", err)
+		}
+	}
+	_, newCommitment, err := engine.GenerateRandomness(header.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate new randomness: %w", err)
+	}
+	if err := random.RevealAndCommit(vmRunner, lastRandomness, newCommitment, b.validator.address); err != nil {
+		return nil, fmt.Errorf("failed to reveal and commit randomness: %w", err)
+	}
+	// always true (EIP158)
+	statedb.IntermediateRoot(true)
+	return &types.Randomness{Revealed: lastRandomness, Committed: newCommitment}, nil
+}
+
+// gasPriceMinimumAt reads the gas price minimum of the currency from the
+// GasPriceMinimum contract at the given header.
+func (b *SimulatedBackend) gasPriceMinimumAt(header *types.Header, currencyAddress *common.Address) (*big.Int, error) {
+	statedb, err := b.blockchain.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	return gpm.GetGasPriceMinimum(b.blockchain.NewEVMRunner(header, statedb), currencyAddress)
+}
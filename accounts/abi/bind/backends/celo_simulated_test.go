@@ -0,0 +1,197 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	istanbulBackend "github.com/celo-org/celo-blockchain/consensus/istanbul/backend"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/mycelo/genesis"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+const celoContractsBuildPath = "../../../../compiled-system-contracts"
+
+func newCeloTestBackend(t *testing.T) (*SimulatedBackend, *env.AccountsConfig) {
+	if _, err := os.Stat(celoContractsBuildPath); os.IsNotExist(err) {
+		t.Skipf("could not find dir %s, try running 'make prepare-system-contracts' and then re-running the test", celoContractsBuildPath)
+	}
+	accounts := &env.AccountsConfig{
+		Mnemonic:             env.MustNewMnemonic(),
+		NumValidators:        1,
+		ValidatorsPerGroup:   1,
+		NumDeveloperAccounts: 1,
+	}
+	cfg := genesis.CreateCommonGenesisConfig(big.NewInt(1), accounts.AdminAccount().Address, params.IstanbulConfig{
+		Epoch:          10,
+		ProposerPolicy: uint64(istanbul.ShuffledRoundRobin),
+		LookbackWindow: 3,
+		BlockPeriod:    1,
+		RequestTimeout: 3000,
+	})
+	cfg.Hardforks.EspressoBlock = common.Big0
+	genesis.FundAccounts(cfg, accounts.DeveloperAccounts())
+
+	sim, err := NewCeloSimulatedBackend(accounts, cfg, celoContractsBuildPath)
+	if err != nil {
+		t.Fatalf("could not create celo simulated backend: %v", err)
+	}
+	return sim, accounts
+}
+
+func TestCeloSimulatedBackendStableTokenFees(t *testing.T) {
+	sim, accounts := newCeloTestBackend(t)
+	defer sim.Close()
+	ctx := context.Background()
+
+	sender := accounts.DeveloperAccounts()[0]
+	cusd := env.MustProxyAddressFor("StableToken")
+	gasPriceMinimum, err := sim.CurrentGasPriceMinimum(ctx, &cusd)
+	if err != nil {
+		t.Fatalf("could not get the gas price minimum: %v", err)
+	}
+	balance, err := sim.BalanceAt(ctx, sender.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value := big.NewInt(1000)
+	gasPrice := new(big.Int).Mul(gasPriceMinimum, common.Big2)
+	tx := types.NewTransaction(0, crypto.PubkeyToAddress(testKey.PublicKey), value, 100000, gasPrice, &cusd, nil, nil, nil)
+	signedTx, err := types.SignTx(tx, types.LatestSigner(sim.config), sender.PrivateKey)
+	if err != nil {
+		t.Fatalf("could not sign tx: %v", err)
+	}
+	if err := sim.SendTransaction(ctx, signedTx); err != nil {
+		t.Fatalf("could not send tx: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := sim.TransactionReceipt(ctx, signedTx.Hash())
+	if err != nil {
+		t.Fatalf("could not get receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction failed")
+	}
+	// The fees are paid in cUSD, so only the value is taken from the CELO balance
+	newBalance, err := sim.BalanceAt(ctx, sender.Address, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := new(big.Int).Sub(balance, value); newBalance.Cmp(want) != 0 {
+		t.Errorf("wrong CELO balance: have %v, want %v", newBalance, want)
+	}
+}
+
+func TestCeloSimulatedBackendAdjustTime(t *testing.T) {
+	sim, _ := newCeloTestBackend(t)
+	defer sim.Close()
+
+	prevTime := sim.pendingBlock.Time()
+	if err := sim.AdjustTime(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	if newTime := sim.blockchain.CurrentBlock().Time(); newTime-prevTime < uint64(time.Hour.Seconds()) {
+		t.Errorf("adjusted time less than an hour. prev: %v, new: %v", prevTime, newTime)
+	}
+}
+
+func TestCeloSimulatedBackendAdvanceEpoch(t *testing.T) {
+	sim, _ := newCeloTestBackend(t)
+	defer sim.Close()
+
+	for epoch := uint64(1); epoch <= 2; epoch++ {
+		if err := sim.AdvanceEpoch(); err != nil {
+			t.Fatal(err)
+		}
+		if number := sim.blockchain.CurrentBlock().NumberU64(); number != istanbul.GetEpochLastBlockNumber(epoch, 10) {
+			t.Errorf("epoch %d ended at block %d", epoch, number)
+		}
+	}
+}
+
+// Runs the validator on a chain without the core contracts, which only checks
+// block production.
+func TestCeloSimulatedBackendBlockProduction(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	blsPrivateKey, _ := blscrypto.ECDSAToBLS(key)
+	blsPublicKey, _ := blscrypto.PrivateToPublic(blsPrivateKey)
+	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
+
+	config := *params.IstanbulTestChainConfig
+	config.Istanbul = &params.IstanbulConfig{Epoch: 10, LookbackWindow: 3, BlockPeriod: 1}
+	gen := &core.Genesis{
+		Config: &config,
+		Alloc:  core.GenesisAlloc{testAddr: {Balance: big.NewInt(10000000000000000)}},
+	}
+	istanbulBackend.AppendValidatorsToGenesisBlock(gen, []istanbul.ValidatorData{{
+		Address:      crypto.PubkeyToAddress(key.PublicKey),
+		BLSPublicKey: blsPublicKey,
+	}})
+	sim, err := newCeloSimulatedBackend(gen, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Close()
+	ctx := context.Background()
+
+	gasPrice, err := sim.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTransaction(0, testAddr, big.NewInt(1000), params.TxGas, gasPrice, nil, nil, nil, nil)
+	signedTx, err := types.SignTx(tx, types.LatestSigner(sim.config), testKey)
+	if err != nil {
+		t.Fatalf("could not sign tx: %v", err)
+	}
+	if err := sim.SendTransaction(ctx, signedTx); err != nil {
+		t.Fatalf("could not send tx: %v", err)
+	}
+	sim.Commit()
+	if _, err := sim.TransactionReceipt(ctx, signedTx.Hash()); err != nil {
+		t.Fatalf("could not get receipt: %v", err)
+	}
+
+	prevTime := sim.blockchain.CurrentBlock().Time()
+	if err := sim.AdjustTime(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	if newTime := sim.blockchain.CurrentBlock().Time(); newTime-prevTime < uint64(time.Hour.Seconds()) {
+		t.Errorf("adjusted time less than an hour. prev: %v, new: %v", prevTime, newTime)
+	}
+
+	if err := sim.AdvanceEpoch(); err != nil {
+		t.Fatal(err)
+	}
+	if number := sim.blockchain.CurrentBlock().NumberU64(); number != 10 {
+		t.Errorf("epoch ended at block %d", number)
+	}
+}
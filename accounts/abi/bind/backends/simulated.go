@@ -32,6 +32,7 @@ import (
 	"github.com/celo-org/celo-blockchain/common/math"
 	mockEngine "github.com/celo-org/celo-blockchain/consensus/consensustest"
 	"github.com/celo-org/celo-blockchain/contracts/blockchain_parameters"
+	gpm "github.com/celo-org/celo-blockchain/contracts/gasprice_minimum"
 	"github.com/celo-org/celo-blockchain/core"
 	"github.com/celo-org/celo-blockchain/core/bloombits"
 	"github.com/celo-org/celo-blockchain/core/rawdb"
@@ -70,6 +71,8 @@ type SimulatedBackend struct {
 	events *filters.EventSystem // Event system for filtering log events live
 
 	config *params.ChainConfig
+
+	validator *celoValidator // Block producer of backends with the celo core contracts, nil otherwise
 }
 
 // NewSimulatedBackendWithDatabase creates a new binding backend based on the given database
@@ -99,6 +102,9 @@ func NewSimulatedBackend(alloc core.GenesisAlloc) *SimulatedBackend {
 
 // Close terminates the underlying blockchain's update loop.
 func (b *SimulatedBackend) Close() error {
+	if b.validator != nil {
+		return b.closeCelo()
+	}
 	b.blockchain.Stop()
 	return nil
 }
@@ -109,6 +115,14 @@ func (b *SimulatedBackend) Commit() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.validator != nil {
+		block, err := b.commitCelo()
+		if err != nil {
+			panic(err)
+		}
+		b.rollback(block)
+		return
+	}
 	if _, err := b.blockchain.InsertChain([]*types.Block{b.pendingBlock}); err != nil {
 		panic(err) // This cannot happen unless the simulator is wrong, fail in that case
 	}
@@ -126,6 +140,14 @@ func (b *SimulatedBackend) Rollback() {
 }
 
 func (b *SimulatedBackend) rollback(parent *types.Block) {
+	if b.validator != nil {
+		block, statedb, err := b.buildCeloBlock(parent, nil)
+		if err != nil {
+			panic(err)
+		}
+		b.pendingBlock, b.pendingState = block, statedb
+		return
+	}
 	blocks, _ := core.GenerateChain(b.config, parent, mockEngine.NewFaker(), b.database, 1, func(int, *core.BlockGen) {})
 
 	b.pendingBlock = blocks[0]
@@ -148,6 +170,9 @@ func (b *SimulatedBackend) Fork(ctx context.Context, parent common.Hash) error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	if b.validator != nil {
+		return errors.New("forks are not supported with the celo core contracts")
+	}
 	if len(b.pendingBlock.Transactions()) != 0 {
 		return errors.New("pending block dirty")
 	}
@@ -460,26 +485,50 @@ func (b *SimulatedBackend) PendingNonceAt(ctx context.Context, account common.Ad
 }
 
 // SuggestGasPrice implements ContractTransactor.SuggestGasPrice. Since the simulated
-// chain doesn't have miners, we just return a gas price of 1 for any call.
+// chain doesn't have miners, we just return a gas price of 1 for any call, unless
+// the chain has the celo core contracts.
 func (b *SimulatedBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	if b.validator != nil {
+		vmRunner, err := b.blockchain.NewEVMRunnerForCurrentBlock()
+		if err != nil {
+			return nil, err
+		}
+		return gpm.GetGasPriceSuggestion(vmRunner, nil)
+	}
 	return big.NewInt(1), nil
 }
 
 // SuggestGasTipCap implements ContractTransactor.SuggestGasTipCap. Since the simulated
-// chain doesn't have miners, we just return a gas tip of 1 for any call.
+// chain doesn't have miners, we just return a gas tip of 1 for any call, unless
+// the chain has the celo core contracts.
 func (b *SimulatedBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	if b.validator != nil {
+		vmRunner, err := b.blockchain.NewEVMRunnerForCurrentBlock()
+		if err != nil {
+			return nil, err
+		}
+		return gpm.GetGasTipCapSuggestion(vmRunner, nil)
+	}
 	return big.NewInt(1), nil
 }
 
 // CurrentGasPriceMinimum implements ContractTransactor.CurrentGasPriceMinimum. Since the simulated
-// chain doesn't have miners, we just return a gas tip of 1 for any call.
+// chain doesn't have miners, we just return a gas tip of 1 for any call, unless
+// the chain has the celo core contracts.
 func (b *SimulatedBackend) CurrentGasPriceMinimum(ctx context.Context, currencyAddress *common.Address) (*big.Int, error) {
+	if b.validator != nil {
+		return b.gasPriceMinimumAt(b.blockchain.CurrentHeader(), currencyAddress)
+	}
 	return big.NewInt(1), nil
 }
 
 // GasPriceMinimumForHeader implements ContractTransactor.GasPriceMinimumForHeader. Since the simulated
-// chain doesn't have miners, we just return a gas tip of 1 for any call.
+// chain doesn't have miners, we just return a gas tip of 1 for any call, unless
+// the chain has the celo core contracts.
 func (b *SimulatedBackend) GasPriceMinimumForHeader(ctx context.Context, currencyAddress *common.Address, header *types.Header) (*big.Int, error) {
+	if b.validator != nil {
+		return b.gasPriceMinimumAt(header, currencyAddress)
+	}
 	return big.NewInt(1), nil
 }
 
@@ -649,6 +698,16 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 		panic(fmt.Errorf("invalid transaction nonce: got %d, want %d", tx.Nonce(), nonce))
 	}
 	// Include tx in chain
+	if b.validator != nil {
+		txs := append(types.Transactions{}, b.pendingBlock.Transactions()...)
+		txs = append(txs, tx)
+		pendingBlock, pendingState, err := b.buildCeloBlock(block, txs)
+		if err != nil {
+			return err
+		}
+		b.pendingBlock, b.pendingState = pendingBlock, pendingState
+		return nil
+	}
 	blocks, _ := core.GenerateChain(b.config, block, mockEngine.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
 		for _, tx := range b.pendingBlock.Transactions() {
 			block.AddTxWithChain(b.blockchain, tx)
@@ -766,6 +825,11 @@ func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
 	if len(b.pendingBlock.Transactions()) != 0 {
 		return errors.New("could not adjust time on non-empty block")
 	}
+	if b.validator != nil {
+		b.validator.clock.Advance(adjustment)
+		b.rollback(b.blockchain.CurrentBlock())
+		return nil
+	}
 
 	blocks, _ := core.GenerateChain(b.config, b.blockchain.CurrentBlock(), mockEngine.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
 		block.OffsetTime(int64(adjustment.Seconds()))
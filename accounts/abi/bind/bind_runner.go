@@ -0,0 +1,147 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+)
+
+// RunnerContract describes a core contract for which BindEVMRunner generates
+// a vm.EVMRunner backed binding.
+type RunnerContract struct {
+	Type       string            // Type name of the generated binding
+	ABI        string            // JSON ABI used to generate the binding from
+	ABIVar     string            // Name of the parsed ABI variable in the contracts/abis package
+	RegistryId string            // Name of the registry id in the params package, empty for address bound contracts
+	MaxGas     map[string]string // Name of the params gas cap constant for every method in the ABI
+}
+
+// tmplRunnerData is the data structure required to fill the EVMRunner binding template.
+type tmplRunnerData struct {
+	Package   string                // Name of the package to place the generated file in
+	Contracts []*tmplRunnerContract // List of contracts to generate into this file
+}
+
+// tmplRunnerContract contains the data needed to generate an individual EVMRunner binding.
+type tmplRunnerContract struct {
+	Type       string              // Type name of the main contract binding
+	ABIVar     string              // Name of the parsed ABI variable in the contracts/abis package
+	RegistryId string              // Name of the registry id in the params package
+	Calls      []*tmplRunnerMethod // Contract calls that only read state data
+	Transacts  []*tmplRunnerMethod // Contract calls that write state data
+}
+
+// tmplRunnerMethod is a tmplMethod augmented with the gas cap of the call.
+type tmplRunnerMethod struct {
+	tmplMethod
+	Receiver string // Type name of the binding the method belongs to
+	MaxGas   string // Name of the params gas cap constant
+}
+
+// BindEVMRunner generates Go wrappers around core contract ABIs which execute
+// their methods through a vm.EVMRunner, the way the node itself calls into the
+// Celo core contracts. Calls and transactions are emitted as typed Query and
+// Execute wrappers on top of contracts.BoundMethod.
+func BindEVMRunner(contracts []RunnerContract, pkg string) (string, error) {
+	data := &tmplRunnerData{Package: pkg}
+	for _, contract := range contracts {
+		evmABI, err := abi.JSON(strings.NewReader(contract.ABI))
+		if err != nil {
+			return "", err
+		}
+		tmplContract := &tmplRunnerContract{
+			Type:       capitalise(contract.Type),
+			ABIVar:     contract.ABIVar,
+			RegistryId: contract.RegistryId,
+		}
+		// Sort the methods so the output does not depend on map iteration order
+		names := make([]string, 0, len(evmABI.Methods))
+		for name := range evmABI.Methods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		identifiers := make(map[string]bool)
+		for _, name := range names {
+			original := evmABI.Methods[name]
+			maxGas, ok := contract.MaxGas[original.Name]
+			if !ok {
+				return "", fmt.Errorf("no gas cap for method %s.%s", contract.Type, original.Name)
+			}
+			normalized := original
+			normalized.Name = methodNormalizer[LangGo](original.Name)
+			if identifiers[normalized.Name] {
+				return "", fmt.Errorf("duplicated identifier \"%s\"(normalized \"%s\")", original.Name, normalized.Name)
+			}
+			identifiers[normalized.Name] = true
+
+			normalized.Inputs = make([]abi.Argument, len(original.Inputs))
+			copy(normalized.Inputs, original.Inputs)
+			for j, input := range normalized.Inputs {
+				if hasStruct(input.Type) {
+					return "", fmt.Errorf("method %s.%s: tuple arguments are not supported", contract.Type, original.Name)
+				}
+				if input.Name == "" || input.Name == "vmRunner" || input.Name == "value" {
+					normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
+				}
+			}
+			for _, output := range original.Outputs {
+				if hasStruct(output.Type) {
+					return "", fmt.Errorf("method %s.%s: tuple results are not supported", contract.Type, original.Name)
+				}
+			}
+			method := &tmplRunnerMethod{
+				tmplMethod: tmplMethod{Original: original, Normalized: normalized},
+				Receiver:   tmplContract.Type,
+				MaxGas:     maxGas,
+			}
+			if original.IsConstant() {
+				tmplContract.Calls = append(tmplContract.Calls, method)
+			} else {
+				tmplContract.Transacts = append(tmplContract.Transacts, method)
+			}
+		}
+		for name := range contract.MaxGas {
+			if _, ok := evmABI.Methods[name]; !ok {
+				return "", fmt.Errorf("gas cap given for unknown method %s.%s", contract.Type, name)
+			}
+		}
+		data.Contracts = append(data.Contracts, tmplContract)
+	}
+
+	buffer := new(bytes.Buffer)
+	funcs := map[string]interface{}{
+		"bindtype":     bindType[LangGo],
+		"decapitalise": decapitalise,
+	}
+	tmpl := template.Must(template.New("").Funcs(funcs).Parse(tmplSourceRunner))
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return "", err
+	}
+	code, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, buffer)
+	}
+	return string(code), nil
+}
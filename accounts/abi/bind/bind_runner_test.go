@@ -0,0 +1,79 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"strings"
+	"testing"
+)
+
+const runnerTestABI = `[
+	{"constant":true,"inputs":[{"name":"who","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[],"name":"rates","outputs":[{"name":"","type":"uint128"},{"name":"","type":"uint128"}],"stateMutability":"view","type":"function"},
+	{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"constant":false,"inputs":[],"name":"deposit","outputs":[],"stateMutability":"payable","type":"function"}
+]`
+
+func TestBindEVMRunner(t *testing.T) {
+	contract := RunnerContract{
+		Type:       "Token",
+		ABI:        runnerTestABI,
+		ABIVar:     "ERC20",
+		RegistryId: "StableTokenRegistryId",
+		MaxGas: map[string]string{
+			"balanceOf": "MaxGasToReadErc20Balance",
+			"rates":     "MaxGasForMedianRate",
+			"transfer":  "MaxGasForMintGas",
+			"deposit":   "MaxGasForMintGas",
+		},
+	}
+	code, err := BindEVMRunner([]RunnerContract{contract}, "token")
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	for _, want := range []string{
+		"package token",
+		"func NewToken() *Token",
+		`contracts.NewRegisteredContractMethod(params.StableTokenRegistryId, abis.ERC20, "balanceOf", params.MaxGasToReadErc20Balance)`,
+		"func (_Token *Token) BalanceOf(vmRunner vm.EVMRunner, who common.Address) (*big.Int, error)",
+		"func (_Token *Token) Rates(vmRunner vm.EVMRunner) (*big.Int, *big.Int, error)",
+		"_Token.ratesMethod.Query(vmRunner, &[]interface{}{&out0, &out1})",
+		"func (_Token *Token) Transfer(vmRunner vm.EVMRunner, to common.Address, arg1 *big.Int) error",
+		"_Token.transferMethod.Execute(vmRunner, nil, common.Big0, to, arg1)",
+		"func (_Token *Token) Deposit(vmRunner vm.EVMRunner, value *big.Int) error",
+		"_Token.depositMethod.Execute(vmRunner, nil, value)",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated binding is missing %q", want)
+		}
+	}
+
+	// Address bound contracts take the address in their constructor
+	contract.RegistryId = ""
+	if code, err = BindEVMRunner([]RunnerContract{contract}, "token"); err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	if !strings.Contains(code, "func NewToken(address common.Address) *Token") {
+		t.Errorf("address bound binding has no address constructor")
+	}
+
+	// Every method needs a gas cap
+	delete(contract.MaxGas, "deposit")
+	if _, err := BindEVMRunner([]RunnerContract{contract}, "token"); err == nil {
+		t.Errorf("expected an error for a method without gas cap")
+	}
+}
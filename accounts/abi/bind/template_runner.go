@@ -0,0 +1,116 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+// tmplSourceRunner is the Go source template that the generated vm.EVMRunner
+// backed core contract bindings are based on.
+const tmplSourceRunner = `
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"math/big"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/contracts"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = common.Big1
+	_ = params.RegistrySmartContractAddress
+)
+
+{{range $contract := .Contracts}}
+	// {{.Type}} is a vm.EVMRunner backed binding to the {{.Type}} core contract.
+	type {{.Type}} struct {
+		{{range .Calls}}{{decapitalise .Normalized.Name}}Method *contracts.BoundMethod
+		{{end}}{{range .Transacts}}{{decapitalise .Normalized.Name}}Method *contracts.BoundMethod
+		{{end}}
+	}
+
+	{{if .RegistryId}}
+		// New{{.Type}} creates a new binding to the {{.Type}} contract, resolving its address through the registry on every call.
+		func New{{.Type}}() *{{.Type}} {
+			return &{{.Type}}{
+				{{range .Calls}}{{decapitalise .Normalized.Name}}Method: contracts.NewRegisteredContractMethod(params.{{$contract.RegistryId}}, abis.{{$contract.ABIVar}}, "{{.Original.Name}}", params.{{.MaxGas}}),
+				{{end}}{{range .Transacts}}{{decapitalise .Normalized.Name}}Method: contracts.NewRegisteredContractMethod(params.{{$contract.RegistryId}}, abis.{{$contract.ABIVar}}, "{{.Original.Name}}", params.{{.MaxGas}}),
+				{{end}}
+			}
+		}
+	{{else}}
+		// New{{.Type}} creates a new binding to the {{.Type}} contract deployed at address.
+		func New{{.Type}}(address common.Address) *{{.Type}} {
+			return &{{.Type}}{
+				{{range .Calls}}{{decapitalise .Normalized.Name}}Method: contracts.NewBoundMethod(address, abis.{{$contract.ABIVar}}, "{{.Original.Name}}", params.{{.MaxGas}}),
+				{{end}}{{range .Transacts}}{{decapitalise .Normalized.Name}}Method: contracts.NewBoundMethod(address, abis.{{$contract.ABIVar}}, "{{.Original.Name}}", params.{{.MaxGas}}),
+				{{end}}
+			}
+		}
+	{{end}}
+
+	{{range .Calls}}
+		// {{.Normalized.Name}} is a free data retrieval call binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}) {{.Normalized.Name}}(vmRunner vm.EVMRunner {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type nil}} {{end}}) ({{range .Normalized.Outputs}}{{bindtype .Type nil}},{{end}} error) {
+			{{- template "runnerbody" .}}
+		}
+	{{end}}
+
+	{{range .Transacts}}
+		// {{.Normalized.Name}} is a state mutating call binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}) {{.Normalized.Name}}(vmRunner vm.EVMRunner {{if .Original.IsPayable}}, value *big.Int{{end}} {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type nil}} {{end}}) ({{range .Normalized.Outputs}}{{bindtype .Type nil}},{{end}} error) {
+			{{- template "runnerbody" .}}
+		}
+	{{end}}
+{{end}}
+
+{{define "runnerbody"}}
+	{{- $call := "Query(vmRunner"}}
+	{{- if not .Original.IsConstant}}
+		{{- $call = "Execute(vmRunner"}}
+	{{- end}}
+	{{- $value := ""}}
+	{{- if not .Original.IsConstant}}
+		{{- $value = ", common.Big0"}}
+		{{- if .Original.IsPayable}}{{$value = ", value"}}{{end}}
+	{{- end}}
+	{{- if eq (len .Normalized.Outputs) 0}}
+		return _{{.Receiver}}.{{decapitalise .Normalized.Name}}Method.{{$call}}, nil{{$value}} {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+	{{- else if eq (len .Normalized.Outputs) 1}}
+		var out0 {{bindtype (index .Normalized.Outputs 0).Type nil}}
+		err := _{{.Receiver}}.{{decapitalise .Normalized.Name}}Method.{{$call}}, &out0{{$value}} {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+		return out0, err
+	{{- else}}
+		var (
+			{{range $i, $_ := .Normalized.Outputs}}out{{$i}} {{bindtype .Type nil}}
+			{{end}}
+		)
+		err := _{{.Receiver}}.{{decapitalise .Normalized.Name}}Method.{{$call}}, &[]interface{}{ {{range $i, $_ := .Normalized.Outputs}}&out{{$i}},{{end}} }{{$value}} {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+		return {{range $i, $_ := .Normalized.Outputs}}out{{$i}},{{end}} err
+	{{- end}}
+{{- end}}
+`
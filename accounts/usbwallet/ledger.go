@@ -364,6 +364,9 @@ func (w *ledgerDriver) ledgerDerive(derivationPath []uint32, showOnWallet bool)
 //   ----------------------+----------
 //   RLP transaction chunk | arbitrary
 //
+// Typed transactions are sent as their type byte followed by their RLP, which
+// is the preimage of their signing hash.
+//
 // And the output data is:
 //
 //   Description | Length
@@ -371,6 +374,8 @@ func (w *ledgerDriver) ledgerDerive(derivationPath []uint32, showOnWallet bool)
 //   signature V | 1 byte
 //   signature R | 32 bytes
 //   signature S | 32 bytes
+//
+// Where signature V is the parity of the signature for typed transactions.
 func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction, chainID *big.Int) (common.Address, *types.Transaction, error) {
 	// Flatten the derivation path into the Ledger request
 	path := make([]byte, 1+4*len(derivationPath))
@@ -398,16 +403,10 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction
 		}
 	}
 
-	// Create the transaction RLP based on whether legacy or EIP155 signing was requested
-	if chainID == nil {
-		//if txrlp, err = rlp.EncodeToBytes([]interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data()}); err != nil {
-		if txrlp, err = rlp.EncodeToBytes([]interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.FeeCurrency(), tx.GatewayFeeRecipient(), tx.GatewayFee(), tx.To(), tx.Value(), tx.Data()}); err != nil {
-			return common.Address{}, nil, err
-		}
-	} else {
-		if txrlp, err = rlp.EncodeToBytes([]interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.FeeCurrency(), tx.GatewayFeeRecipient(), tx.GatewayFee(), tx.To(), tx.Value(), tx.Data(), chainID, big.NewInt(0), big.NewInt(0)}); err != nil {
-			return common.Address{}, nil, err
-		}
+	// Create the transaction RLP based on the transaction type and whether legacy
+	// or EIP155 signing was requested
+	if txrlp, err = ledgerTxRLP(tx, chainID); err != nil {
+		return common.Address{}, nil, err
 	}
 	payload := append(path, txrlp...)
 
@@ -439,11 +438,17 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction
 
 	// Create the correct signer and signature transform based on the chain ID
 	var signer types.Signer
-	if chainID == nil {
+	switch {
+	case chainID == nil:
 		signer = new(types.HomesteadSigner)
-	} else {
+		signature[64] -= 27
+	case tx.Type() == types.LegacyTxType:
 		signer = types.NewEIP155Signer(chainID)
 		signature[64] -= byte(chainID.Uint64()*2 + 35)
+	default:
+		// For typed transactions, V is the parity of the signature, no need to
+		// subtract anything
+		signer = types.LatestSignerForChainID(chainID)
 	}
 	signed, err := tx.WithSignature(signer, signature)
 	if err != nil {
@@ -456,6 +461,37 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction
 	return sender, signed, nil
 }
 
+// ledgerTxRLP returns the transaction data the Ledger signs, which is the preimage
+// of the signing hash of the transaction: the RLP list of its fields, prefixed by
+// the type for typed transactions.
+func ledgerTxRLP(tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	var fields []interface{}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		fields = []interface{}{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.FeeCurrency(), tx.GatewayFeeRecipient(), tx.GatewayFee(), tx.To(), tx.Value(), tx.Data()}
+		if chainID != nil {
+			fields = append(fields, chainID, big.NewInt(0), big.NewInt(0))
+		}
+		return rlp.EncodeToBytes(fields)
+	case types.AccessListTxType:
+		fields = []interface{}{chainID, tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()}
+	case types.DynamicFeeTxType:
+		fields = []interface{}{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()}
+	case types.CeloDynamicFeeTxType:
+		fields = []interface{}{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.FeeCurrency(), tx.GatewayFeeRecipient(), tx.GatewayFee(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()}
+	default:
+		return nil, types.ErrTxTypeNotSupported
+	}
+	if chainID == nil {
+		return nil, fmt.Errorf("chain ID required to sign transactions of type %d", tx.Type())
+	}
+	txrlp, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{tx.Type()}, txrlp...), nil
+}
+
 // ledgerProvideERC20 provides ERC20 information for tokens.
 //
 // The data protocol is defined as follows:
@@ -0,0 +1,184 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package usbwallet
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/accounts"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/log"
+)
+
+var (
+	testChainID = big.NewInt(42220)
+	testCUSD    = common.HexToAddress("0x765de816845861e75a25fca122bb6898b8b1282a")
+	testTo      = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+)
+
+// erc20Transfer returns the calldata of an ERC20 transfer to the test recipient,
+// padded with extra bytes to span several transport chunks.
+func erc20Transfer(padding int) []byte {
+	data := append([]byte{0xa9, 0x05, 0x9c, 0xbb}, common.LeftPadBytes(testTo.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)...)
+	return append(data, bytes.Repeat([]byte{0x01}, padding)...)
+}
+
+// openLedger creates a Ledger driver connected to a simulated device.
+func openLedger(t *testing.T) (*ledgerDriver, *ledgerSimulator) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	device := newLedgerSimulator(key)
+	driver := newLedgerDriver(log.Root()).(*ledgerDriver)
+	if err := driver.Open(device, ""); err != nil {
+		t.Fatalf("failed to open ledger: %v", err)
+	}
+	return driver, device
+}
+
+func TestLedgerOpen(t *testing.T) {
+	driver, device := openLedger(t)
+
+	status, err := driver.Status()
+	if err != nil {
+		t.Fatalf("unexpected failure: %v", err)
+	}
+	if status != "Celo app v1.0.3 online" {
+		t.Errorf("status mismatch: have %q, want %q", status, "Celo app v1.0.3 online")
+	}
+	address, err := driver.Derive(accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		t.Fatalf("failed to derive address: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(device.key.PublicKey); address != want {
+		t.Errorf("address mismatch: have %x, want %x", address, want)
+	}
+}
+
+func TestLedgerSignTx(t *testing.T) {
+	var (
+		feeCurrency = testCUSD
+		gatewayFee  = big.NewInt(10000)
+	)
+	tests := []struct {
+		name    string
+		tx      *types.Transaction
+		chainID *big.Int
+		erc20s  int // Number of ERC20 infos expected to be provided
+	}{
+		{
+			name: "homestead",
+			tx:   types.NewTransaction(0, testTo, big.NewInt(1), 21000, big.NewInt(1), nil, nil, nil, nil),
+		},
+		{
+			name:    "legacy",
+			tx:      types.NewTransaction(1, testTo, big.NewInt(1), 21000, big.NewInt(1), nil, nil, nil, nil),
+			chainID: testChainID,
+		},
+		{
+			name:    "legacy celo fields",
+			tx:      types.NewTransaction(2, testCUSD, nil, 100000, big.NewInt(1), &feeCurrency, &testTo, gatewayFee, erc20Transfer(1000)),
+			chainID: testChainID,
+			erc20s:  2,
+		},
+		{
+			name: "access list",
+			tx: types.NewTx(&types.AccessListTx{
+				ChainID:    testChainID,
+				Nonce:      3,
+				GasPrice:   big.NewInt(1),
+				Gas:        50000,
+				To:         &testTo,
+				Value:      big.NewInt(1),
+				Data:       bytes.Repeat([]byte{0x02}, 300),
+				AccessList: types.AccessList{{Address: testTo, StorageKeys: []common.Hash{{0x01}}}},
+			}),
+			chainID: testChainID,
+		},
+		{
+			name: "dynamic fee",
+			tx: types.NewTx(&types.DynamicFeeTx{
+				ChainID:   testChainID,
+				Nonce:     4,
+				GasTipCap: big.NewInt(1),
+				GasFeeCap: big.NewInt(2),
+				Gas:       21000,
+				To:        &testTo,
+				Value:     big.NewInt(1),
+			}),
+			chainID: testChainID,
+		},
+		{
+			name: "celo dynamic fee",
+			tx: types.NewTx(&types.CeloDynamicFeeTx{
+				ChainID:             testChainID,
+				Nonce:               5,
+				GasTipCap:           big.NewInt(1),
+				GasFeeCap:           big.NewInt(2),
+				Gas:                 100000,
+				FeeCurrency:         &feeCurrency,
+				GatewayFeeRecipient: &testTo,
+				GatewayFee:          gatewayFee,
+				To:                  &testCUSD,
+				Value:               new(big.Int),
+				Data:                erc20Transfer(600),
+			}),
+			chainID: testChainID,
+			erc20s:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, device := openLedger(t)
+
+			sender, signed, err := driver.SignTx(accounts.DefaultBaseDerivationPath, tt.tx, tt.chainID)
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+			if want := crypto.PubkeyToAddress(device.key.PublicKey); sender != want {
+				t.Errorf("sender mismatch: have %x, want %x", sender, want)
+			}
+			if signed.Type() != tt.tx.Type() {
+				t.Errorf("type mismatch: have %d, want %d", signed.Type(), tt.tx.Type())
+			}
+			if device.signed[tt.tx.Type()] != 1 {
+				t.Errorf("transaction signed %d times, want 1", device.signed[tt.tx.Type()])
+			}
+			if len(device.erc20s) != tt.erc20s {
+				t.Errorf("provided ERC20 infos mismatch: have %d, want %d", len(device.erc20s), tt.erc20s)
+			}
+		})
+	}
+}
+
+func TestLedgerSignTypedTxWithoutChainID(t *testing.T) {
+	driver, device := openLedger(t)
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: testChainID, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1), Gas: 21000, To: &testTo, Value: big.NewInt(1)})
+	if _, _, err := driver.SignTx(accounts.DefaultBaseDerivationPath, tx, nil); err == nil {
+		t.Fatal("signed typed transaction without chain ID")
+	}
+	if n := device.chunks[ledgerOpSignTransaction]; n != 0 {
+		t.Errorf("sent %d chunks to sign, want 0", n)
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package usbwallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/celo-org/celo-blockchain/accounts/usbwallet/trezor"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"github.com/golang/protobuf/proto"
+)
+
+// hidSimulator is a simulated HID transport: it collects the 64 byte reports
+// written by a driver, passes the complete requests to a device, and streams
+// back the reports of its replies.
+type hidSimulator struct {
+	device  func(report []byte) ([][]byte, error) // Simulated device, returning the reports of a reply
+	replies bytes.Buffer                          // Reply reports not yet read by the driver
+}
+
+func (s *hidSimulator) Write(report []byte) (int, error) {
+	if len(report) > 64 {
+		return 0, fmt.Errorf("report of %d bytes, want at most 64", len(report))
+	}
+	// Short reports are zero padded, as done by the HID layer
+	replies, err := s.device(append(report[:len(report):len(report)], make([]byte, 64-len(report))...))
+	if err != nil {
+		return 0, err
+	}
+	for _, reply := range replies {
+		s.replies.Write(reply)
+	}
+	return len(report), nil
+}
+
+func (s *hidSimulator) Read(report []byte) (int, error) {
+	if s.replies.Len() == 0 {
+		return 0, errors.New("no pending reply")
+	}
+	return s.replies.Read(report)
+}
+
+// signSimulated signs the keccak256 hash of data, returning the signature in
+// the [R || S || V] format, with V the recovery id.
+func signSimulated(key *ecdsa.PrivateKey, data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), key)
+}
+
+// ledgerSimulator simulates the Celo app of a Ledger wallet, signing with a
+// single key whatever the derivation path.
+type ledgerSimulator struct {
+	hidSimulator
+	key     *ecdsa.PrivateKey
+	version [3]byte
+
+	apdu    []byte               // APDU being received
+	apduLen int                  // Total length of the APDU being received
+	tx      []byte               // Transaction being received for signing
+	erc20s  [][]byte             // ERC20 infos provided
+	signed  map[byte]int         // Number of transactions signed by type
+	chunks  map[ledgerOpcode]int // Number of APDUs received by opcode
+}
+
+func newLedgerSimulator(key *ecdsa.PrivateKey) *ledgerSimulator {
+	s := &ledgerSimulator{
+		key:     key,
+		version: [3]byte{1, 0, 3},
+		signed:  make(map[byte]int),
+		chunks:  make(map[ledgerOpcode]int),
+	}
+	s.device = s.receive
+	return s
+}
+
+// receive reassembles the APDUs from the reports of the ledger transport.
+func (s *ledgerSimulator) receive(report []byte) ([][]byte, error) {
+	if report[0] != 0x01 || report[1] != 0x01 || report[2] != 0x05 {
+		return nil, errors.New("invalid transport header")
+	}
+	if binary.BigEndian.Uint16(report[3:5]) == 0 {
+		s.apduLen, s.apdu = int(binary.BigEndian.Uint16(report[5:7])), append([]byte{}, report[7:]...)
+	} else {
+		s.apdu = append(s.apdu, report[5:]...)
+	}
+	if len(s.apdu) < s.apduLen {
+		return nil, nil
+	}
+	apdu := s.apdu[:s.apduLen]
+	if apdu[0] != 0xe0 || int(apdu[4]) != len(apdu)-5 {
+		return nil, errors.New("invalid APDU")
+	}
+	reply, err := s.handle(ledgerOpcode(apdu[1]), ledgerParam1(apdu[2]), apdu[5:])
+	if err != nil {
+		return s.frame(nil, 0x6a80), nil
+	}
+	return s.frame(reply, statusCodeOK), nil
+}
+
+// handle executes an APDU command of the Celo app.
+func (s *ledgerSimulator) handle(opcode ledgerOpcode, p1 ledgerParam1, data []byte) ([]byte, error) {
+	s.chunks[opcode]++
+	switch opcode {
+	case ledgerOpGetConfiguration:
+		return append([]byte{0x01}, s.version[:]...), nil
+
+	case ledgerOpRetrieveAddress:
+		pubkey := crypto.FromECDSAPub(&s.key.PublicKey)
+		address := []byte(hex.EncodeToString(crypto.PubkeyToAddress(s.key.PublicKey).Bytes()))
+		reply := append([]byte{byte(len(pubkey))}, pubkey...)
+		return append(append(reply, byte(len(address))), address...), nil
+
+	case ledgerOpProvideERC20:
+		s.erc20s = append(s.erc20s, data)
+		return nil, nil
+
+	case ledgerOpSignTransaction:
+		if p1 == ledgerP1InitTransactionData {
+			if len(data) < 1 || len(data) < 1+4*int(data[0]) {
+				return nil, errors.New("invalid derivation path")
+			}
+			s.tx = append([]byte{}, data[1+4*int(data[0]):]...)
+		} else {
+			s.tx = append(s.tx, data...)
+		}
+		// Typed transactions are prefixed with their type, outside of the list
+		txType, list := byte(0), s.tx
+		if len(list) > 0 && list[0] < 0xc0 {
+			txType, list = list[0], list[1:]
+		}
+		if _, _, _, err := rlp.Split(list); err == rlp.ErrValueTooLarge {
+			return nil, nil // Wait for the next chunk
+		} else if err != nil {
+			return nil, err
+		}
+		sig, err := signSimulated(s.key, s.tx)
+		if err != nil {
+			return nil, err
+		}
+		v := sig[64]
+		if txType == 0 {
+			var fields []rlp.RawValue
+			if err := rlp.DecodeBytes(list, &fields); err != nil {
+				return nil, err
+			}
+			if len(fields) == 12 { // EIP-155, V is truncated to a byte as by the device
+				chainID := new(big.Int)
+				if err := rlp.DecodeBytes(fields[9], chainID); err != nil {
+					return nil, err
+				}
+				v += byte(chainID.Uint64()*2 + 35)
+			} else {
+				v += 27
+			}
+		}
+		s.signed[txType]++
+		return append([]byte{v}, sig[:64]...), nil
+	}
+	return nil, fmt.Errorf("unknown opcode %#x", opcode)
+}
+
+// frame splits a reply and its status code into the reports of the ledger
+// transport.
+func (s *ledgerSimulator) frame(reply []byte, status uint16) [][]byte {
+	payload := make([]byte, 2, 4+len(reply))
+	binary.BigEndian.PutUint16(payload, uint16(len(reply)+2))
+	payload = append(payload, reply...)
+	payload = append(payload, byte(status>>8), byte(status))
+
+	var reports [][]byte
+	for i := 0; len(payload) > 0; i++ {
+		report := make([]byte, 64)
+		copy(report, []byte{0x01, 0x01, 0x05})
+		binary.BigEndian.PutUint16(report[3:], uint16(i))
+		n := copy(report[5:], payload)
+		payload = payload[n:]
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// trezorSimulator simulates a Trezor wallet with a Celo aware firmware, signing
+// with a single key whatever the derivation path.
+type trezorSimulator struct {
+	hidSimulator
+	key        *ecdsa.PrivateKey
+	ignoreCelo bool // Whether to ignore the celo fields, as firmwares without celo support
+
+	kind    uint16                 // Type of the message being received
+	msg     []byte                 // Message being received
+	msgLen  int                    // Total length of the message being received
+	request *trezor.EthereumSignTx // Transaction being signed
+	data    []byte                 // Transaction payload received so far
+}
+
+func newTrezorSimulator(key *ecdsa.PrivateKey) *trezorSimulator {
+	s := &trezorSimulator{key: key}
+	s.device = s.receive
+	return s
+}
+
+// receive reassembles the protobuf messages from the reports of the trezor
+// transport.
+func (s *trezorSimulator) receive(report []byte) ([][]byte, error) {
+	if report[0] != 0x3f {
+		return nil, errors.New("invalid report id")
+	}
+	if s.msg == nil {
+		if report[1] != 0x23 || report[2] != 0x23 {
+			return nil, errors.New("invalid message header")
+		}
+		s.kind, s.msgLen = binary.BigEndian.Uint16(report[3:5]), int(binary.BigEndian.Uint32(report[5:9]))
+		s.msg = append([]byte{}, report[9:]...)
+	} else {
+		s.msg = append(s.msg, report[1:]...)
+	}
+	if len(s.msg) < s.msgLen {
+		return nil, nil
+	}
+	kind, msg := s.kind, s.msg[:s.msgLen]
+	s.msg = nil
+
+	reply, err := s.handle(kind, msg)
+	if err != nil {
+		message := err.Error()
+		reply = &trezor.Failure{Message: &message}
+	}
+	return s.frame(reply)
+}
+
+// handle executes a request of the Trezor firmware.
+func (s *trezorSimulator) handle(kind uint16, msg []byte) (proto.Message, error) {
+	switch kind {
+	case trezor.Type(&trezor.Initialize{}):
+		major, minor, patch, label := uint32(2), uint32(4), uint32(0), "simulator"
+		return &trezor.Features{MajorVersion: &major, MinorVersion: &minor, PatchVersion: &patch, Label: &label}, nil
+
+	case trezor.Type(&trezor.Ping{}):
+		return &trezor.Success{}, nil
+
+	case trezor.Type(&trezor.EthereumGetAddress{}):
+		address := crypto.PubkeyToAddress(s.key.PublicKey).Hex()
+		return &trezor.EthereumAddress{AddressHex: &address}, nil
+
+	case trezor.Type(&trezor.EthereumSignTx{}):
+		s.request = new(trezor.EthereumSignTx)
+		if err := proto.Unmarshal(msg, s.request); err != nil {
+			return nil, err
+		}
+		s.data = append([]byte{}, s.request.GetDataInitialChunk()...)
+		return s.continueSigning()
+
+	case trezor.Type(&trezor.EthereumTxAck{}):
+		ack := new(trezor.EthereumTxAck)
+		if err := proto.Unmarshal(msg, ack); err != nil {
+			return nil, err
+		}
+		if s.request == nil {
+			return nil, errors.New("no transaction being signed")
+		}
+		s.data = append(s.data, ack.GetDataChunk()...)
+		return s.continueSigning()
+	}
+	return nil, fmt.Errorf("unexpected message %s", trezor.Name(kind))
+}
+
+// continueSigning requests the next chunk of the transaction payload, or signs
+// the transaction once complete.
+func (s *trezorSimulator) continueSigning() (proto.Message, error) {
+	if left := int(s.request.GetDataLength()) - len(s.data); left > 0 {
+		if left > 1024 {
+			left = 1024
+		}
+		length := uint32(left)
+		return &trezor.EthereumTxRequest{DataLength: &length}, nil
+	}
+	req := s.request
+	s.request = nil
+
+	var to []byte
+	if req.ToHex != nil {
+		to = common.HexToAddress(req.GetToHex()).Bytes()
+	}
+	fields := []interface{}{
+		new(big.Int).SetBytes(req.GetNonce()), new(big.Int).SetBytes(req.GetGasPrice()), new(big.Int).SetBytes(req.GetGasLimit()),
+	}
+	if !s.ignoreCelo && (req.FeeCurrency != nil || req.GatewayFeeRecipient != nil || req.GatewayFee != nil) {
+		fields = append(fields, req.GetFeeCurrency(), req.GetGatewayFeeRecipient(), new(big.Int).SetBytes(req.GetGatewayFee()))
+	}
+	fields = append(fields, to, new(big.Int).SetBytes(req.GetValue()), s.data)
+	if req.ChainId != nil {
+		fields = append(fields, big.NewInt(int64(req.GetChainId())), big.NewInt(0), big.NewInt(0))
+	}
+	txrlp, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signSimulated(s.key, txrlp)
+	if err != nil {
+		return nil, err
+	}
+	v := uint32(sig[64]) + 27
+	if req.ChainId != nil {
+		v = uint32(sig[64]) + 2*req.GetChainId() + 35
+	}
+	return &trezor.EthereumTxRequest{SignatureV: &v, SignatureR: sig[:32], SignatureS: sig[32:64]}, nil
+}
+
+// frame splits a protobuf message into the reports of the trezor transport.
+func (s *trezorSimulator) frame(msg proto.Message) ([][]byte, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	payload := make([]byte, 8+len(data))
+	copy(payload, []byte{0x23, 0x23})
+	binary.BigEndian.PutUint16(payload[2:], trezor.Type(msg))
+	binary.BigEndian.PutUint32(payload[4:], uint32(len(data)))
+	copy(payload[8:], data)
+
+	var reports [][]byte
+	for len(payload) > 0 {
+		report := make([]byte, 64)
+		report[0] = 0x3f
+		n := copy(report[1:], payload)
+		payload = payload[n:]
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
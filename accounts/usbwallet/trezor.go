@@ -219,6 +219,10 @@ func (w *trezorDriver) trezorDerive(derivationPath []uint32) (common.Address, er
 // trezorSign sends the transaction to the Trezor wallet, and waits for the user
 // to confirm or deny the transaction.
 func (w *trezorDriver) trezorSign(derivationPath []uint32, tx *types.Transaction, chainID *big.Int) (common.Address, *types.Transaction, error) {
+	// Trezor backend does not support typed transactions yet.
+	if tx.Type() != types.LegacyTxType {
+		return common.Address{}, nil, fmt.Errorf("trezor: transactions of type %d not supported", tx.Type())
+	}
 	// Create the transaction initiation message
 	data := tx.Data()
 	length := uint32(len(data))
@@ -237,6 +241,19 @@ func (w *trezorDriver) trezorSign(derivationPath []uint32, tx *types.Transaction
 		request.ToHex = &hex     // Newer firmwares (old will ignore)
 		request.ToBin = (*to)[:] // Older firmwares (new will ignore)
 	}
+	if !tx.EthCompatible() {
+		// Celo transaction, set the celo fields explicitly, even if empty, for the
+		// firmware to sign them (firmwares without celo support ignore them, and
+		// the signature won't match the sender)
+		request.FeeCurrency, request.GatewayFeeRecipient = []byte{}, []byte{}
+		if feeCurrency := tx.FeeCurrency(); feeCurrency != nil {
+			request.FeeCurrency = (*feeCurrency)[:]
+		}
+		if recipient := tx.GatewayFeeRecipient(); recipient != nil {
+			request.GatewayFeeRecipient = (*recipient)[:]
+		}
+		request.GatewayFee = tx.GatewayFee().Bytes()
+	}
 	if length > 1024 { // Send the data chunked if that was requested
 		request.DataInitialChunk, data = data[:1024], data[1024:]
 	} else {
@@ -269,8 +286,8 @@ func (w *trezorDriver) trezorSign(derivationPath []uint32, tx *types.Transaction
 	var signer types.Signer
 	if chainID == nil {
 		signer = new(types.HomesteadSigner)
+		signature[64] -= 27
 	} else {
-		// Trezor backend does not support typed transactions yet.
 		signer = types.NewEIP155Signer(chainID)
 		signature[64] -= byte(chainID.Uint64()*2 + 35)
 	}
@@ -244,6 +244,9 @@ type EthereumSignTx struct {
 	DataLength           *uint32  `protobuf:"varint,8,opt,name=data_length,json=dataLength" json:"data_length,omitempty"`
 	ChainId              *uint32  `protobuf:"varint,9,opt,name=chain_id,json=chainId" json:"chain_id,omitempty"`
 	TxType               *uint32  `protobuf:"varint,10,opt,name=tx_type,json=txType" json:"tx_type,omitempty"`
+	FeeCurrency          []byte   `protobuf:"bytes,100,opt,name=fee_currency,json=feeCurrency" json:"fee_currency,omitempty"`
+	GatewayFeeRecipient  []byte   `protobuf:"bytes,101,opt,name=gateway_fee_recipient,json=gatewayFeeRecipient" json:"gateway_fee_recipient,omitempty"`
+	GatewayFee           []byte   `protobuf:"bytes,102,opt,name=gateway_fee,json=gatewayFee" json:"gateway_fee,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -351,6 +354,27 @@ func (m *EthereumSignTx) GetTxType() uint32 {
 	return 0
 }
 
+func (m *EthereumSignTx) GetFeeCurrency() []byte {
+	if m != nil {
+		return m.FeeCurrency
+	}
+	return nil
+}
+
+func (m *EthereumSignTx) GetGatewayFeeRecipient() []byte {
+	if m != nil {
+		return m.GatewayFeeRecipient
+	}
+	return nil
+}
+
+func (m *EthereumSignTx) GetGatewayFee() []byte {
+	if m != nil {
+		return m.GatewayFee
+	}
+	return nil
+}
+
 //*
 // Response: Device asks for more data from transaction payload, or returns the signature.
 // If data_length is set, device awaits that many more bytes of payload.
@@ -656,43 +680,46 @@ func init() {
 func init() { proto.RegisterFile("messages-ethereum.proto", fileDescriptor_cb33f46ba915f15c) }
 
 var fileDescriptor_cb33f46ba915f15c = []byte{
-	// 593 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x95, 0x9b, 0xb4, 0x49, 0x26, 0x0d, 0x1f, 0xa6, 0x55, 0x17, 0x0a, 0x34, 0x18, 0x21, 0xe5,
-	0x00, 0x3e, 0x70, 0x43, 0xe2, 0xd2, 0x52, 0x44, 0x2b, 0x4a, 0x55, 0xdc, 0xa8, 0x57, 0x6b, 0x63,
-	0x6f, 0xe3, 0x55, 0x9d, 0xdd, 0xe0, 0x5d, 0xb7, 0x0e, 0x7f, 0x82, 0x23, 0xff, 0x87, 0x5f, 0x86,
-	0xf6, 0x2b, 0x71, 0x52, 0x54, 0x0e, 0xbd, 0x65, 0xde, 0xbc, 0x7d, 0xf3, 0x66, 0xf4, 0x62, 0xd8,
-	0x99, 0x10, 0x21, 0xf0, 0x98, 0x88, 0x77, 0x44, 0x66, 0xa4, 0x20, 0xe5, 0x24, 0x9c, 0x16, 0x5c,
-	0x72, 0x7f, 0x37, 0xbb, 0x09, 0x65, 0x41, 0x7e, 0xf2, 0x22, 0x74, 0x94, 0xd0, 0x51, 0x9e, 0x6d,
-	0xcf, 0x5f, 0x25, 0x7c, 0x32, 0xe1, 0xcc, 0xbc, 0x09, 0x2e, 0x60, 0xeb, 0xb3, 0xa5, 0x7c, 0x21,
-	0xf2, 0xac, 0x1c, 0xe5, 0x34, 0xf9, 0x4a, 0x66, 0xfe, 0x2e, 0x74, 0x70, 0x9a, 0x16, 0x44, 0x88,
-	0x98, 0x21, 0xaf, 0xdf, 0x18, 0xf4, 0xa2, 0xb6, 0x05, 0x4e, 0xfd, 0x57, 0xb0, 0x29, 0x32, 0x7e,
-	0x13, 0xa7, 0x54, 0x4c, 0x73, 0x3c, 0x43, 0x6b, 0x7d, 0x6f, 0xd0, 0x8e, 0xba, 0x0a, 0x3b, 0x34,
-	0x50, 0x30, 0x82, 0xc7, 0x4e, 0x77, 0x21, 0xfa, 0x01, 0x9a, 0x8c, 0xa7, 0x04, 0x79, 0x7d, 0x6f,
-	0xd0, 0x7d, 0xff, 0x26, 0xfc, 0x87, 0x5f, 0x6b, 0xee, 0xe8, 0xf0, 0x94, 0xa7, 0x64, 0x38, 0x9b,
-	0x92, 0x48, 0x3f, 0xf1, 0x7d, 0x68, 0x56, 0xd3, 0x72, 0xa4, 0x47, 0x75, 0x22, 0xfd, 0x3b, 0x18,
-	0x82, 0x5f, 0xf3, 0xbe, 0x6f, 0xdc, 0xdd, 0xdb, 0xf9, 0x77, 0x78, 0xe8, 0x54, 0x9d, 0xe4, 0x4b,
-	0x00, 0xab, 0x70, 0x40, 0x99, 0x76, 0xbf, 0x19, 0xd5, 0x90, 0x5a, 0xff, 0x88, 0x54, 0xd6, 0x62,
-	0x0d, 0x09, 0xfe, 0xac, 0xc1, 0x03, 0xa7, 0x79, 0x4e, 0xc7, 0x6c, 0x58, 0xdd, 0xed, 0x72, 0x0b,
-	0xd6, 0x19, 0x67, 0x09, 0xd1, 0x52, 0x9b, 0x91, 0x29, 0xd4, 0x93, 0x31, 0x16, 0xf1, 0xb4, 0xa0,
-	0x09, 0x41, 0x0d, 0xdd, 0x69, 0x8f, 0xb1, 0x38, 0x53, 0xb5, 0x6b, 0xe6, 0x74, 0x42, 0x25, 0x6a,
-	0xce, 0x9b, 0x27, 0xaa, 0x56, 0x7a, 0x92, 0x2b, 0xeb, 0xeb, 0x46, 0x4f, 0x17, 0x06, 0x55, 0x86,
-	0xbb, 0xda, 0xb0, 0x29, 0x14, 0x7a, 0x8d, 0xf3, 0x92, 0xa0, 0x0d, 0xc3, 0xd5, 0x85, 0xff, 0x16,
-	0xfc, 0x14, 0x4b, 0x1c, 0x53, 0x46, 0x25, 0xc5, 0x79, 0x9c, 0x64, 0x25, 0xbb, 0x42, 0x2d, 0x4d,
-	0x79, 0xa4, 0x3a, 0xc7, 0xa6, 0xf1, 0x49, 0xe1, 0xfe, 0x1e, 0x74, 0x35, 0x3b, 0x27, 0x6c, 0x2c,
-	0x33, 0xd4, 0xee, 0x7b, 0x83, 0x5e, 0x04, 0x0a, 0x3a, 0xd1, 0x88, 0xff, 0x14, 0xda, 0x49, 0x86,
-	0x29, 0x8b, 0x69, 0x8a, 0x3a, 0xba, 0xdb, 0xd2, 0xf5, 0x71, 0xea, 0xef, 0x40, 0x4b, 0x56, 0xb1,
-	0x9c, 0x4d, 0x09, 0x02, 0xdd, 0xd9, 0x90, 0x95, 0xca, 0x41, 0xf0, 0xdb, 0x5b, 0x44, 0x6a, 0x58,
-	0x45, 0xe4, 0x47, 0x49, 0x84, 0x5c, 0x1d, 0xe5, 0xdd, 0x1a, 0xb5, 0x07, 0x5d, 0x41, 0xc7, 0x0c,
-	0xcb, 0xb2, 0x20, 0xf1, 0xb5, 0xbe, 0x68, 0x2f, 0x82, 0x39, 0x74, 0xb1, 0x4c, 0x28, 0xec, 0x61,
-	0x17, 0x84, 0x68, 0x99, 0x20, 0xec, 0x71, 0x17, 0x84, 0xf3, 0x20, 0x84, 0xde, 0xc2, 0xd8, 0x7e,
-	0x72, 0xe5, 0xbf, 0x00, 0xed, 0xc0, 0x5e, 0xc9, 0xe4, 0xa5, 0xa3, 0x10, 0x7d, 0x9e, 0xe0, 0x04,
-	0x9e, 0xd4, 0xd3, 0xf0, 0xcd, 0x64, 0xff, 0xee, 0x48, 0x20, 0x68, 0xd9, 0xff, 0x88, 0x0d, 0x85,
-	0x2b, 0x83, 0x0a, 0x90, 0x53, 0xb3, 0x4a, 0xe7, 0xce, 0xda, 0x7f, 0x83, 0xfb, 0x1c, 0x3a, 0xf3,
-	0x3d, 0xac, 0xee, 0x02, 0x58, 0x89, 0x75, 0xe3, 0x56, 0xac, 0x7f, 0x79, 0xb0, 0xed, 0x46, 0x5f,
-	0x90, 0x82, 0x5e, 0xce, 0xdc, 0x2a, 0xf7, 0x9b, 0x5b, 0xdb, 0xb5, 0xb1, 0xb4, 0xeb, 0x8a, 0xa3,
-	0xe6, 0xaa, 0xa3, 0x83, 0x8f, 0xf0, 0x3a, 0xe1, 0x93, 0x50, 0x60, 0xc9, 0x45, 0x46, 0x73, 0x3c,
-	0x12, 0xee, 0x03, 0x93, 0xd3, 0x91, 0xf9, 0xe2, 0x8d, 0xca, 0xcb, 0x83, 0xed, 0xa1, 0x06, 0xad,
-	0x5b, 0xb7, 0xc2, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8a, 0xce, 0x81, 0xc8, 0x59, 0x05, 0x00,
-	0x00,
+	// 650 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x4a,
+	0x14, 0x95, 0x5f, 0xd2, 0x26, 0xb9, 0x69, 0xde, 0x7b, 0xb8, 0x8d, 0x3a, 0x50, 0xa0, 0xc1, 0x08,
+	0x29, 0x0b, 0xc8, 0xa2, 0x3b, 0x24, 0x36, 0xfd, 0x00, 0x5a, 0x51, 0xaa, 0xe2, 0x46, 0xdd, 0x5a,
+	0x13, 0xfb, 0x26, 0x19, 0xd5, 0x19, 0x07, 0xcf, 0xb8, 0xb5, 0xf9, 0x13, 0x2c, 0xf9, 0x2f, 0xfc,
+	0x3a, 0x34, 0xe3, 0x99, 0xc4, 0x4d, 0xab, 0xb2, 0xe8, 0xce, 0xf7, 0x9c, 0x33, 0x67, 0xce, 0xd5,
+	0xbd, 0x63, 0xd8, 0x9e, 0xa1, 0x10, 0x74, 0x82, 0xe2, 0x1d, 0xca, 0x29, 0xa6, 0x98, 0xcd, 0x06,
+	0xf3, 0x34, 0x91, 0x89, 0xbb, 0x33, 0xbd, 0x19, 0xc8, 0x14, 0x7f, 0x24, 0xe9, 0xc0, 0x4a, 0x06,
+	0x56, 0xf2, 0xac, 0xbb, 0x38, 0x15, 0x26, 0xb3, 0x59, 0xc2, 0xcb, 0x33, 0xde, 0x25, 0x6c, 0x7d,
+	0x34, 0x92, 0xcf, 0x28, 0xcf, 0xb3, 0x51, 0xcc, 0xc2, 0x2f, 0x58, 0xb8, 0x3b, 0xd0, 0xa2, 0x51,
+	0x94, 0xa2, 0x10, 0x01, 0x27, 0x4e, 0xaf, 0xd6, 0xef, 0xf8, 0x4d, 0x03, 0x9c, 0xb9, 0xaf, 0x60,
+	0x43, 0x4c, 0x93, 0x9b, 0x20, 0x62, 0x62, 0x1e, 0xd3, 0x82, 0xfc, 0xd3, 0x73, 0xfa, 0x4d, 0xbf,
+	0xad, 0xb0, 0xa3, 0x12, 0xf2, 0x46, 0xf0, 0xc4, 0xfa, 0x2e, 0x4d, 0xdf, 0x43, 0x9d, 0x27, 0x11,
+	0x12, 0xa7, 0xe7, 0xf4, 0xdb, 0x7b, 0x6f, 0x06, 0xf7, 0xe4, 0x35, 0xe1, 0x8e, 0x8f, 0xce, 0x92,
+	0x08, 0x87, 0xc5, 0x1c, 0x7d, 0x7d, 0xc4, 0x75, 0xa1, 0x9e, 0xcf, 0xb3, 0x91, 0xbe, 0xaa, 0xe5,
+	0xeb, 0x6f, 0x6f, 0x08, 0x6e, 0x25, 0xfb, 0x7e, 0x99, 0xee, 0xd1, 0xc9, 0xbf, 0xc1, 0x7f, 0xd6,
+	0xd5, 0x5a, 0xbe, 0x04, 0x30, 0x0e, 0x07, 0x8c, 0xeb, 0xf4, 0x1b, 0x7e, 0x05, 0xa9, 0xf0, 0xc7,
+	0x98, 0x9b, 0x88, 0x15, 0xc4, 0xfb, 0x5d, 0x83, 0x7f, 0xad, 0xe7, 0x05, 0x9b, 0xf0, 0x61, 0xfe,
+	0x70, 0xca, 0x2d, 0x58, 0xe3, 0x09, 0x0f, 0x51, 0x5b, 0x6d, 0xf8, 0x65, 0xa1, 0x8e, 0x4c, 0xa8,
+	0x08, 0xe6, 0x29, 0x0b, 0x91, 0xd4, 0x34, 0xd3, 0x9c, 0x50, 0x71, 0x9e, 0xb2, 0x25, 0x19, 0xb3,
+	0x19, 0x93, 0xa4, 0xbe, 0x20, 0x4f, 0x55, 0xad, 0xfc, 0x64, 0xa2, 0xa2, 0xaf, 0x95, 0x7e, 0xba,
+	0x28, 0x51, 0x15, 0xb8, 0xad, 0x03, 0x97, 0x85, 0x42, 0xaf, 0x69, 0x9c, 0x21, 0x59, 0x2f, 0xb5,
+	0xba, 0x70, 0xdf, 0x82, 0x1b, 0x51, 0x49, 0x03, 0xc6, 0x99, 0x64, 0x34, 0x0e, 0xc2, 0x69, 0xc6,
+	0xaf, 0x48, 0x43, 0x4b, 0xfe, 0x57, 0xcc, 0x49, 0x49, 0x1c, 0x2a, 0xdc, 0xdd, 0x85, 0xb6, 0x56,
+	0xc7, 0xc8, 0x27, 0x72, 0x4a, 0x9a, 0x3d, 0xa7, 0xdf, 0xf1, 0x41, 0x41, 0xa7, 0x1a, 0x71, 0x9f,
+	0x42, 0x33, 0x9c, 0x52, 0xc6, 0x03, 0x16, 0x91, 0x96, 0x66, 0x1b, 0xba, 0x3e, 0x89, 0xdc, 0x6d,
+	0x68, 0xc8, 0x3c, 0x90, 0xc5, 0x1c, 0x09, 0x68, 0x66, 0x5d, 0xe6, 0x6a, 0x0f, 0xd4, 0xe8, 0xc6,
+	0x88, 0x41, 0x98, 0xa5, 0x29, 0xf2, 0xb0, 0x20, 0x91, 0xbe, 0xbc, 0x3d, 0x46, 0x3c, 0x34, 0x90,
+	0xbb, 0x07, 0xdd, 0x09, 0x95, 0x78, 0x43, 0x8b, 0x40, 0x49, 0x53, 0x0c, 0xd9, 0x9c, 0x21, 0x97,
+	0x04, 0xb5, 0x76, 0xd3, 0x90, 0x9f, 0x10, 0x7d, 0x4b, 0xa9, 0xac, 0x95, 0x33, 0x64, 0x5c, 0x0e,
+	0x77, 0xa9, 0xf4, 0x7e, 0x39, 0xcb, 0x55, 0x1e, 0xe6, 0x3e, 0x7e, 0xcf, 0x50, 0xc8, 0xd5, 0x16,
+	0x9d, 0x3b, 0x2d, 0xee, 0x42, 0x5b, 0xb0, 0x09, 0xa7, 0x32, 0x4b, 0x31, 0xb8, 0xd6, 0x93, 0xec,
+	0xf8, 0xb0, 0x80, 0x2e, 0x6f, 0x0b, 0x52, 0x33, 0xd0, 0xa5, 0xc0, 0xbf, 0x2d, 0x10, 0xa4, 0xbe,
+	0x22, 0xb8, 0xf0, 0x06, 0xd0, 0x59, 0x06, 0xdb, 0x0f, 0xaf, 0xdc, 0x17, 0xa0, 0x13, 0x98, 0xe9,
+	0x94, 0x7b, 0xda, 0x52, 0x88, 0x1e, 0x8b, 0x77, 0x0a, 0x9b, 0xd5, 0x2d, 0xfc, 0x5a, 0xbe, 0xb9,
+	0x87, 0x57, 0x91, 0x40, 0xc3, 0xbc, 0x4d, 0xb3, 0x8c, 0xb6, 0xf4, 0x72, 0x20, 0xd6, 0xcd, 0x38,
+	0x5d, 0xd8, 0x68, 0x7f, 0x7d, 0x30, 0xcf, 0xa1, 0xb5, 0xe8, 0xc3, 0xf8, 0xb6, 0xc4, 0x3d, 0xa7,
+	0xd5, 0x76, 0xd6, 0xee, 0x3c, 0xa7, 0x9f, 0x0e, 0x74, 0xed, 0xd5, 0x97, 0x98, 0xb2, 0x71, 0x61,
+	0x5b, 0x79, 0xdc, 0xbd, 0x95, 0x5e, 0x6b, 0xb7, 0x7a, 0x5d, 0x49, 0x54, 0x5f, 0x4d, 0x74, 0xf0,
+	0x01, 0x5e, 0x87, 0xc9, 0x6c, 0x20, 0xa8, 0x4c, 0xc4, 0x94, 0xc5, 0x74, 0x24, 0xec, 0x8f, 0x2d,
+	0x66, 0xa3, 0xf2, 0x4f, 0x3b, 0xca, 0xc6, 0x07, 0xdd, 0xa1, 0x06, 0x4d, 0x5a, 0xdb, 0xc2, 0x9f,
+	0x01, 0x00, 0xd7, 0x23, 0x67, 0x34, 0xd1, 0x05, 0x00, 0x00,
 }
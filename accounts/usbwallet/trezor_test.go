@@ -0,0 +1,139 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package usbwallet
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/accounts"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/log"
+)
+
+// openTrezor creates a Trezor driver connected to a simulated device.
+func openTrezor(t *testing.T) (*trezorDriver, *trezorSimulator) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	device := newTrezorSimulator(key)
+	driver := newTrezorDriver(log.Root()).(*trezorDriver)
+	if err := driver.Open(device, ""); err != nil {
+		t.Fatalf("failed to open trezor: %v", err)
+	}
+	return driver, device
+}
+
+func TestTrezorOpen(t *testing.T) {
+	driver, device := openTrezor(t)
+
+	status, err := driver.Status()
+	if err != nil {
+		t.Fatalf("unexpected failure: %v", err)
+	}
+	if status != "Trezor v2.4.0 'simulator' online" {
+		t.Errorf("status mismatch: have %q, want %q", status, "Trezor v2.4.0 'simulator' online")
+	}
+	address, err := driver.Derive(accounts.DefaultBaseDerivationPath)
+	if err != nil {
+		t.Fatalf("failed to derive address: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(device.key.PublicKey); address != want {
+		t.Errorf("address mismatch: have %x, want %x", address, want)
+	}
+}
+
+func TestTrezorSignTx(t *testing.T) {
+	feeCurrency := testCUSD
+
+	tests := []struct {
+		name    string
+		tx      *types.Transaction
+		chainID *big.Int
+	}{
+		{
+			name: "homestead",
+			tx:   types.NewTransaction(0, testTo, big.NewInt(1), 21000, big.NewInt(1), nil, nil, nil, nil),
+		},
+		{
+			name:    "legacy",
+			tx:      types.NewTransaction(1, testTo, big.NewInt(1), 21000, big.NewInt(1), nil, nil, nil, nil),
+			chainID: testChainID,
+		},
+		{
+			name:    "legacy celo fields",
+			tx:      types.NewTransaction(2, testCUSD, nil, 100000, big.NewInt(1), &feeCurrency, &testTo, big.NewInt(10000), erc20Transfer(3000)),
+			chainID: testChainID,
+		},
+		{
+			name:    "eth compatible",
+			tx:      types.NewTransactionEthCompatible(3, testTo, big.NewInt(1), 50000, big.NewInt(1), bytes.Repeat([]byte{0x02}, 1500)),
+			chainID: testChainID,
+		},
+		{
+			name:    "contract creation",
+			tx:      types.NewContractCreation(4, nil, 100000, big.NewInt(1), &feeCurrency, nil, nil, bytes.Repeat([]byte{0x03}, 1024)),
+			chainID: testChainID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, device := openTrezor(t)
+
+			sender, signed, err := driver.SignTx(accounts.DefaultBaseDerivationPath, tt.tx, tt.chainID)
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+			if want := crypto.PubkeyToAddress(device.key.PublicKey); sender != want {
+				t.Errorf("sender mismatch: have %x, want %x", sender, want)
+			}
+			if signed.Hash() == tt.tx.Hash() {
+				t.Errorf("transaction not signed")
+			}
+		})
+	}
+}
+
+func TestTrezorSignTxWithoutCeloFirmware(t *testing.T) {
+	driver, device := openTrezor(t)
+	device.ignoreCelo = true
+
+	feeCurrency := testCUSD
+	tx := types.NewTransaction(0, testTo, big.NewInt(1), 21000, big.NewInt(1), &feeCurrency, nil, nil, nil)
+
+	// The firmware signs the transaction without the celo fields, so the sender
+	// recovered from the celo signing hash doesn't match the device account
+	sender, _, err := driver.SignTx(accounts.DefaultBaseDerivationPath, tx, testChainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if sender == crypto.PubkeyToAddress(device.key.PublicKey) {
+		t.Errorf("sender matches device account despite ignored celo fields")
+	}
+}
+
+func TestTrezorSignTypedTx(t *testing.T) {
+	driver, _ := openTrezor(t)
+
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: testChainID, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1), Gas: 21000, To: &testTo, Value: big.NewInt(1)})
+	if _, _, err := driver.SignTx(accounts.DefaultBaseDerivationPath, tx, testChainID); err == nil {
+		t.Fatal("signed typed transaction")
+	}
+}
@@ -17,22 +17,31 @@
 package main
 
 import (
+	"context"
 	"encoding/hex"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/ethclient"
 	"github.com/celo-org/celo-blockchain/signer/core/apitypes"
 	"github.com/celo-org/celo-blockchain/signer/fourbyte"
 )
 
+var (
+	toFlag  = flag.String("to", "", "address the data is sent to, to identify the called celo core contract")
+	rpcFlag = flag.String("rpc", "", "RPC endpoint of a celo node, to resolve the celo core contracts through the registry")
+)
+
 func init() {
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "<hexdata>")
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-to <address>] [-rpc <endpoint>] <hexdata>")
 		flag.PrintDefaults()
 		fmt.Fprintln(os.Stderr, `
-Parses the given ABI data and tries to interpret it from the fourbyte database.`)
+Parses the given ABI data and tries to interpret it from the celo core contract
+ABIs and the fourbyte database.`)
 	}
 }
 
@@ -41,15 +50,33 @@ func parse(data []byte) {
 	if err != nil {
 		die(err)
 	}
+	if *rpcFlag != "" {
+		client, err := ethclient.Dial(*rpcFlag)
+		if err != nil {
+			die(err)
+		}
+		defer client.Close()
+		if err := db.ResolveCeloRegistry(context.Background(), client); err != nil {
+			die(err)
+		}
+	}
+	var to *common.Address
+	if *toFlag != "" {
+		if !common.IsHexAddress(*toFlag) {
+			die("invalid address:", *toFlag)
+		}
+		address := common.HexToAddress(*toFlag)
+		to = &address
+	}
 	messages := apitypes.ValidationMessages{}
-	db.ValidateCallData(nil, data, &messages)
+	db.ValidateCallData(to, nil, data, &messages)
 	for _, m := range messages.Messages {
 		fmt.Printf("%v: %v\n", m.Typ, m.Message)
 	}
 }
 
 // Example
-// ./abidump a9059cbb000000000000000000000000ea0e2dc7d65a50e77fc7e84bff3fd2a9e781ff5c0000000000000000000000000000000000000000000000015af1d78b58c40000
+// ./abidump -to 0x765DE816845861e75A25fCA122bb6898B8B1282a -rpc https://forno.celo.org a9059cbb000000000000000000000000ea0e2dc7d65a50e77fc7e84bff3fd2a9e781ff5c0000000000000000000000000000000000000000000000015af1d78b58c40000
 func main() {
 	flag.Parse()
 
@@ -42,6 +42,7 @@ import (
 	"github.com/celo-org/celo-blockchain/common/hexutil"
 	"github.com/celo-org/celo-blockchain/core/types"
 	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/ethclient"
 	"github.com/celo-org/celo-blockchain/internal/ethapi"
 	"github.com/celo-org/celo-blockchain/internal/flags"
 	"github.com/celo-org/celo-blockchain/log"
@@ -116,6 +117,10 @@ var (
 		Usage: "File used for writing new 4byte-identifiers submitted via API",
 		Value: "./4byte-custom.json",
 	}
+	celoRegistryRPCFlag = cli.StringFlag{
+		Name:  "celo-registry-rpc",
+		Usage: "RPC endpoint of a celo node, used to resolve the celo core contracts through the registry when validating transactions",
+	}
 	auditLogFlag = cli.StringFlag{
 		Name:  "auditlog",
 		Usage: "File used to emit audit logs. Set to \"\" to disable",
@@ -236,6 +241,7 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			rpcPortFlag,
 			signerSecretFlag,
 			customDBFlag,
+			celoRegistryRPCFlag,
 			auditLogFlag,
 			ruleFlag,
 			stdiouiFlag,
@@ -264,6 +270,7 @@ func init() {
 		rpcPortFlag,
 		signerSecretFlag,
 		customDBFlag,
+		celoRegistryRPCFlag,
 		auditLogFlag,
 		ruleFlag,
 		stdiouiFlag,
@@ -568,6 +575,18 @@ func signer(c *cli.Context) error {
 	}
 	embeds, locals := db.Size()
 	log.Info("Loaded 4byte database", "embeds", embeds, "locals", locals, "local", fourByteLocal)
+	if endpoint := c.GlobalString(celoRegistryRPCFlag.Name); endpoint != "" {
+		client, err := ethclient.Dial(endpoint)
+		if err != nil {
+			utils.Fatalf("Failed to connect to %s: %v", endpoint, err)
+		}
+		if err := db.ResolveCeloRegistry(context.Background(), client); err != nil {
+			log.Warn("Failed to resolve the celo core contracts", "endpoint", endpoint, "err", err)
+		} else {
+			log.Info("Resolved the celo core contracts", "endpoint", endpoint)
+		}
+		client.Close()
+	}
 
 	var (
 		api       core.ExternalAPI
@@ -0,0 +1,291 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethtest
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/p2p/enode"
+	"github.com/celo-org/celo-blockchain/rlp"
+)
+
+const (
+	// baseProtocolLength is the number of message codes reserved for the
+	// devp2p base protocol, which the istanbul message codes follow.
+	baseProtocolLength = 16
+
+	// istanbulMaxMessageSize mirrors the cap the eth protocol handler puts on
+	// the size of any message, istanbul ones included.
+	istanbulMaxMessageSize = 10 * 1024 * 1024
+)
+
+// Istanbul is a message of the istanbul subprotocol. On the wire, its payload
+// is sent as an RLP byte string.
+type Istanbul struct {
+	MsgCode uint64
+	Payload []byte
+}
+
+func (m Istanbul) Code() int { return baseProtocolLength + int(m.MsgCode) }
+
+// isIstanbulCode returns whether the wire message code is an istanbul one.
+func isIstanbulCode(code uint64) bool {
+	return code >= baseProtocolLength+istanbul.ConsensusMsg && code <= baseProtocolLength+istanbul.ValidatorHandshakeMsg
+}
+
+// WriteIstanbul writes an istanbul message with the given payload to the connection.
+func (c *Conn) WriteIstanbul(msgCode uint64, payload []byte) error {
+	data, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return err
+	}
+	_, err = c.Conn.Write(baseProtocolLength+msgCode, data)
+	return err
+}
+
+// writeIstanbulMsg encodes msg and writes it with the given message code.
+func (c *Conn) writeIstanbulMsg(msgCode uint64, msg *istanbul.Message) error {
+	payload, err := msg.Payload()
+	if err != nil {
+		return err
+	}
+	return c.WriteIstanbul(msgCode, payload)
+}
+
+// ReadIstanbul reads from the connection until an istanbul message or a
+// disconnect arrives, answering pings and skipping eth protocol messages.
+func (c *Conn) ReadIstanbul() Message {
+	for {
+		code, data, _, err := c.Conn.Read()
+		if err != nil {
+			return errorf("could not read from connection: %w", err)
+		}
+		switch {
+		case code == uint64(Ping{}.Code()):
+			c.Write(&Pong{})
+		case code == uint64(Disconnect{}.Code()):
+			msg := new(Disconnect)
+			if err := rlp.DecodeBytes(data, msg); err != nil {
+				return errorf("could not rlp decode disconnect: %v", err)
+			}
+			return msg
+		case isIstanbulCode(code):
+			msg := &Istanbul{MsgCode: code - baseProtocolLength}
+			if err := rlp.DecodeBytes(data, &msg.Payload); err != nil {
+				return errorf("could not rlp decode istanbul message: %v", err)
+			}
+			return msg
+		}
+	}
+}
+
+// isTimeout returns whether msg is the error of a read that hit its deadline.
+func isTimeout(msg Message) bool {
+	err, ok := msg.(*Error)
+	if !ok {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// expectDisconnect waits for the node to drop the connection, either with a
+// disconnect message or by closing it.
+func (c *Conn) expectDisconnect(timeout time.Duration) error {
+	defer c.SetReadDeadline(time.Time{})
+	c.SetReadDeadline(time.Now().Add(timeout))
+	for {
+		switch msg := c.ReadIstanbul().(type) {
+		case *Disconnect:
+			return nil
+		case *Error:
+			if isTimeout(msg) {
+				return fmt.Errorf("node did not disconnect within %v", timeout)
+			}
+			return nil
+		}
+	}
+}
+
+// expectConnected checks that the node keeps the connection open for the
+// given duration.
+func (c *Conn) expectConnected(duration time.Duration) error {
+	defer c.SetReadDeadline(time.Time{})
+	c.SetReadDeadline(time.Now().Add(duration))
+	for {
+		switch msg := c.ReadIstanbul().(type) {
+		case *Disconnect:
+			return fmt.Errorf("node disconnected: %v", msg.Reason)
+		case *Error:
+			if isTimeout(msg) {
+				return nil
+			}
+			return msg
+		}
+	}
+}
+
+// istanbulStatusExchange answers the status message of the node with its own
+// head, so that the node neither syncs from nor drops the connection.
+func (s *Suite) istanbulStatusExchange(c *Conn) error {
+	defer c.SetDeadline(time.Time{})
+	c.SetDeadline(time.Now().Add(20 * time.Second))
+	for {
+		switch msg := c.Read().(type) {
+		case *Status:
+			if msg.Genesis != s.genesis {
+				return fmt.Errorf("wrong genesis in status: have %#x, want %#x (is the node running the mycelo genesis?)", msg.Genesis, s.genesis)
+			}
+			status := *msg
+			status.ProtocolVersion = uint32(c.negotiatedProtoVersion)
+			return c.Write(&status)
+		case *Disconnect:
+			return fmt.Errorf("disconnect received: %v", msg.Reason)
+		case *Ping:
+			c.Write(&Pong{})
+		default:
+			return fmt.Errorf("bad status message: %s", pretty.Sdump(msg))
+		}
+	}
+}
+
+// dialIstanbul dials the node and performs the protocol handshake and status
+// exchange, leaving the validator handshake to the caller.
+func (s *Suite) dialIstanbul() (*Conn, error) {
+	conn, err := s.dial66()
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("handshake failed: %v", err)
+	}
+	if err := s.istanbulStatusExchange(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("status exchange failed: %v", err)
+	}
+	return conn, nil
+}
+
+// peerIstanbul connects to the node as a non-validator and returns the
+// version certificates the node sends once the connection is established.
+func (s *Suite) peerIstanbul() (*Conn, []*istanbul.VersionCertificate, error) {
+	conn, err := s.dialIstanbul()
+	if err != nil {
+		return nil, nil, err
+	}
+	// A dialing peer that doesn't identify as a validator sends an empty message
+	if err := conn.writeIstanbulMsg(istanbul.ValidatorHandshakeMsg, &istanbul.Message{}); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("could not write validator handshake: %v", err)
+	}
+	vcs, err := conn.readVersionCertificates(timeout)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, vcs, nil
+}
+
+// readVersionCertificates waits for a version certificates message.
+func (c *Conn) readVersionCertificates(timeout time.Duration) ([]*istanbul.VersionCertificate, error) {
+	defer c.SetReadDeadline(time.Time{})
+	c.SetReadDeadline(time.Now().Add(timeout))
+	for {
+		switch msg := c.ReadIstanbul().(type) {
+		case *Istanbul:
+			if msg.MsgCode != istanbul.VersionCertificatesMsg {
+				continue
+			}
+			var vcMsg istanbul.Message
+			if err := vcMsg.FromPayload(msg.Payload, nil); err != nil {
+				return nil, fmt.Errorf("could not decode version certificates: %v", err)
+			}
+			return vcMsg.VersionCertificates(), nil
+		case *Disconnect:
+			return nil, fmt.Errorf("disconnect received: %v", msg.Reason)
+		case *Error:
+			return nil, fmt.Errorf("no version certificates received: %v", msg)
+		}
+	}
+}
+
+// versionCertificate returns the version the node has for the address in its
+// version certificate table, as sent to a newly connected peer.
+func (s *Suite) versionCertificate(address common.Address) (uint, error) {
+	conn, vcs, err := s.peerIstanbul()
+	if err != nil {
+		return 0, err
+	}
+	conn.Close()
+	for _, vc := range vcs {
+		if vc.Address() == address {
+			return vc.Version, nil
+		}
+	}
+	return 0, nil
+}
+
+// nextAnnounceVersion returns a version newer than those used so far. Like
+// the ones of validators, versions are based on the current time.
+func (s *Suite) nextAnnounceVersion() uint {
+	version := uint(time.Now().Unix())
+	if version <= s.announceVersion {
+		version = s.announceVersion + 1
+	}
+	s.announceVersion = version
+	return version
+}
+
+// keyNode returns a node record with the key. Nodes only compare the identity
+// of the enode certificates with their peers, so the address is arbitrary.
+func keyNode(key *ecdsa.PrivateKey) *enode.Node {
+	return enode.NewV4(&key.PublicKey, net.IP{127, 0, 0, 1}, 30303, 30303)
+}
+
+// signFn returns a function signing istanbul messages with the key.
+func signFn(key *ecdsa.PrivateKey) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(data), key)
+	}
+}
+
+// badSignFn returns a signing function producing well formed signatures that
+// don't match the data.
+func badSignFn(key *ecdsa.PrivateKey) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(append(data, 0)), key)
+	}
+}
+
+// enodeCertificateMsg returns a handshake message certifying the node's URL,
+// signed by key.
+func enodeCertificateMsg(n *enode.Node, key *ecdsa.PrivateKey, sign func(*ecdsa.PrivateKey) func([]byte) ([]byte, error)) (*istanbul.Message, error) {
+	cert := &istanbul.EnodeCertificate{EnodeURL: n.URLv4(), Version: uint(time.Now().Unix())}
+	msg := istanbul.NewEnodeCeritifcateMessage(cert, crypto.PubkeyToAddress(key.PublicKey))
+	if err := msg.Sign(sign(key)); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,435 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethtest
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/internal/utesting"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/p2p/enode"
+)
+
+var (
+	// istanbulHandshakeTimeout is the time the node waits for the validator
+	// handshake, plus some slack.
+	istanbulHandshakeTimeout = 10 * time.Second
+
+	// announceProcessingTime is how long the node is given to process an
+	// announce message before its effects are checked.
+	announceProcessingTime = 2 * time.Second
+
+	// connectedDuration is how long the node must keep a connection open after
+	// a message it should ignore.
+	connectedDuration = 3 * time.Second
+)
+
+// NewIstanbulSuite creates and returns a test suite for the istanbul protocol.
+// The node under test must run the genesis of the mycelo environment at
+// envPath, whose validator keys are used to sign announce messages.
+func NewIstanbulSuite(dest *enode.Node, envPath string) (*Suite, error) {
+	environment, err := env.Load(envPath)
+	if err != nil {
+		return nil, err
+	}
+	genesis, err := environment.LoadGenesis()
+	if err != nil {
+		return nil, err
+	}
+	validators := environment.Accounts().ValidatorAccounts()
+	if len(validators) == 0 {
+		return nil, fmt.Errorf("mycelo environment %s has no validators", envPath)
+	}
+	return &Suite{
+		Dest:       dest,
+		genesis:    genesis.ToBlock(nil).Hash(),
+		validators: validators,
+	}, nil
+}
+
+func (s *Suite) IstanbulTests() []utesting.Test {
+	return []utesting.Test{
+		// validator handshake
+		{Name: "TestIstanbulHandshake", Fn: s.TestIstanbulHandshake},
+		{Name: "TestIstanbulHandshakeTimeout", Fn: s.TestIstanbulHandshakeTimeout},
+		{Name: "TestIstanbulHandshakeWrongCode", Fn: s.TestIstanbulHandshakeWrongCode},
+		{Name: "TestIstanbulHandshakeBadSignature", Fn: s.TestIstanbulHandshakeBadSignature},
+		{Name: "TestIstanbulHandshakeWrongNode", Fn: s.TestIstanbulHandshakeWrongNode},
+		{Name: "TestIstanbulRepeatedHandshake", Fn: s.TestIstanbulRepeatedHandshake},
+		// message limits
+		{Name: "TestIstanbulLargeMessage", Fn: s.TestIstanbulLargeMessage},
+		{Name: "TestIstanbulMalformedMessage", Fn: s.TestIstanbulMalformedMessage},
+		// bad signatures
+		{Name: "TestIstanbulBadConsensusSignature", Fn: s.TestIstanbulBadConsensusSignature},
+		{Name: "TestIstanbulBadQueryEnodeSignature", Fn: s.TestIstanbulBadQueryEnodeSignature},
+		{Name: "TestIstanbulBadEnodeCertificateSignature", Fn: s.TestIstanbulBadEnodeCertificateSignature},
+		{Name: "TestIstanbulBadVersionCertificateSignature", Fn: s.TestIstanbulBadVersionCertificateSignature},
+		{Name: "TestIstanbulDelegateSign", Fn: s.TestIstanbulDelegateSign},
+		// announce versions
+		{Name: "TestIstanbulVersionCertificate", Fn: s.TestIstanbulVersionCertificate},
+		{Name: "TestIstanbulOldVersionCertificate", Fn: s.TestIstanbulOldVersionCertificate},
+		{Name: "TestIstanbulReplayedVersionCertificate", Fn: s.TestIstanbulReplayedVersionCertificate},
+	}
+}
+
+// TestIstanbulHandshake checks that the node accepts a peer that doesn't
+// identify as a validator, and sends it its version certificate table.
+func (s *Suite) TestIstanbulHandshake(t *utesting.T) {
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.expectConnected(connectedDuration); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulHandshakeTimeout checks that the node disconnects a dialing peer
+// that doesn't send the validator handshake.
+func (s *Suite) TestIstanbulHandshakeTimeout(t *utesting.T) {
+	conn, err := s.dialIstanbul()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.expectDisconnect(istanbulHandshakeTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulHandshakeWrongCode checks that the node disconnects a peer that
+// sends another message in place of the validator handshake.
+func (s *Suite) TestIstanbulHandshakeWrongCode(t *utesting.T) {
+	conn, err := s.dialIstanbul()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.writeIstanbulMsg(istanbul.ConsensusMsg, &istanbul.Message{}); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectDisconnect(istanbulHandshakeTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulHandshakeBadSignature checks that the node disconnects a peer
+// whose enode certificate isn't signed by the address it claims.
+func (s *Suite) TestIstanbulHandshakeBadSignature(t *utesting.T) {
+	conn, err := s.dialIstanbul()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	msg, err := enodeCertificateMsg(keyNode(conn.ourKey), conn.ourKey, badSignFn)
+	if err != nil {
+		t.Fatalf("could not create enode certificate: %v", err)
+	}
+	if err := conn.writeIstanbulMsg(istanbul.ValidatorHandshakeMsg, msg); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectDisconnect(istanbulHandshakeTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulHandshakeWrongNode checks that the node disconnects a peer
+// presenting the enode certificate of another node.
+func (s *Suite) TestIstanbulHandshakeWrongNode(t *utesting.T) {
+	conn, err := s.dialIstanbul()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	otherKey, _ := crypto.GenerateKey()
+	msg, err := enodeCertificateMsg(keyNode(otherKey), conn.ourKey, signFn)
+	if err != nil {
+		t.Fatalf("could not create enode certificate: %v", err)
+	}
+	if err := conn.writeIstanbulMsg(istanbul.ValidatorHandshakeMsg, msg); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectDisconnect(istanbulHandshakeTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulRepeatedHandshake checks that the node ignores a validator
+// handshake sent after the connection was established.
+func (s *Suite) TestIstanbulRepeatedHandshake(t *utesting.T) {
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.writeIstanbulMsg(istanbul.ValidatorHandshakeMsg, &istanbul.Message{}); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectConnected(connectedDuration); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulLargeMessage checks that the node disconnects a peer sending an
+// istanbul message over the message size limit.
+func (s *Suite) TestIstanbulLargeMessage(t *utesting.T) {
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteIstanbul(istanbul.ConsensusMsg, make([]byte, istanbulMaxMessageSize)); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectDisconnect(timeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulMalformedMessage checks that the node disconnects a peer sending
+// an istanbul message whose payload isn't a byte string.
+func (s *Suite) TestIstanbulMalformedMessage(t *utesting.T) {
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	// 0xc0 is the empty list
+	if _, err := conn.Conn.Write(baseProtocolLength+istanbul.QueryEnodeMsg, []byte{0xc0}); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectDisconnect(timeout); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulBadConsensusSignature checks that the node ignores a consensus
+// message that isn't signed by its sender, without disconnecting.
+func (s *Suite) TestIstanbulBadConsensusSignature(t *utesting.T) {
+	s.sendIgnored(t, istanbul.ConsensusMsg, func(key *ecdsa.PrivateKey) (*istanbul.Message, error) {
+		subject := &istanbul.Subject{
+			View:   &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(1)},
+			Digest: common.Hash{1},
+		}
+		msg := istanbul.NewPrepareMessage(subject, crypto.PubkeyToAddress(key.PublicKey))
+		return msg, msg.Sign(badSignFn(key))
+	})
+}
+
+// TestIstanbulBadQueryEnodeSignature checks that the node ignores a queryEnode
+// message that isn't signed by its sender, without disconnecting.
+func (s *Suite) TestIstanbulBadQueryEnodeSignature(t *utesting.T) {
+	s.sendIgnored(t, istanbul.QueryEnodeMsg, func(key *ecdsa.PrivateKey) (*istanbul.Message, error) {
+		data := &istanbul.QueryEnodeData{Version: s.nextAnnounceVersion(), Timestamp: uint(time.Now().Unix())}
+		msg := istanbul.NewQueryEnodeMessage(data, s.validators[0].Address)
+		return msg, msg.Sign(badSignFn(key))
+	})
+}
+
+// TestIstanbulBadEnodeCertificateSignature checks that the node ignores an
+// enode certificate that isn't signed by its sender, without disconnecting.
+func (s *Suite) TestIstanbulBadEnodeCertificateSignature(t *utesting.T) {
+	s.sendIgnored(t, istanbul.EnodeCertificateMsg, func(key *ecdsa.PrivateKey) (*istanbul.Message, error) {
+		return enodeCertificateMsg(keyNode(key), key, badSignFn)
+	})
+}
+
+// TestIstanbulBadVersionCertificateSignature checks that the node doesn't
+// store a version certificate with a bad signature.
+func (s *Suite) TestIstanbulBadVersionCertificateSignature(t *utesting.T) {
+	validator := s.validators[0]
+	version := s.nextAnnounceVersion()
+	vc, err := istanbul.NewVersionCertificate(version, badSignFn(validator.PrivateKey))
+	if err != nil {
+		t.Fatalf("could not create version certificate: %v", err)
+	}
+	s.sendIgnored(t, istanbul.VersionCertificatesMsg, func(*ecdsa.PrivateKey) (*istanbul.Message, error) {
+		return istanbul.NewVersionCeritifcatesMessage([]*istanbul.VersionCertificate{vc}, validator.Address), nil
+	})
+	conn, vcs, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	conn.Close()
+	for _, vc := range vcs {
+		if vc.Version == version {
+			t.Fatalf("version certificate with bad signature stored for %v", vc.Address().Hex())
+		}
+	}
+}
+
+// TestIstanbulDelegateSign checks that the node ignores a delegate sign
+// message from a peer that isn't its proxy, without disconnecting.
+func (s *Suite) TestIstanbulDelegateSign(t *utesting.T) {
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteIstanbul(istanbul.DelegateSignMsg, []byte("not a proxy")); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectConnected(connectedDuration); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulVersionCertificate checks that the node stores a new version
+// certificate of a validator.
+func (s *Suite) TestIstanbulVersionCertificate(t *utesting.T) {
+	validator := s.validators[0]
+	version := s.nextAnnounceVersion()
+	if _, err := s.sendVersionCertificate(validator, version); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.waitForVersion(validator.Address, version); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulOldVersionCertificate checks that the node keeps the newest
+// version certificate of a validator when sent an older one.
+func (s *Suite) TestIstanbulOldVersionCertificate(t *utesting.T) {
+	validator := s.validators[0]
+	version := s.nextAnnounceVersion()
+	if _, err := s.sendVersionCertificate(validator, version); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.waitForVersion(validator.Address, version); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.sendVersionCertificate(validator, version-1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(announceProcessingTime)
+	if err := s.checkVersion(validator.Address, version); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestIstanbulReplayedVersionCertificate checks that the node keeps the newest
+// version certificate of a validator when an earlier message is replayed.
+func (s *Suite) TestIstanbulReplayedVersionCertificate(t *utesting.T) {
+	validator := s.validators[0]
+	first := s.nextAnnounceVersion()
+	payload, err := s.sendVersionCertificate(validator, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.waitForVersion(validator.Address, first); err != nil {
+		t.Fatal(err)
+	}
+	second := s.nextAnnounceVersion()
+	if _, err := s.sendVersionCertificate(validator, second); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.waitForVersion(validator.Address, second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay the first message from a new peer
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteIstanbul(istanbul.VersionCertificatesMsg, payload); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	time.Sleep(announceProcessingTime)
+	if err := s.checkVersion(validator.Address, second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sendIgnored peers with the node, sends the message built with the key of the
+// connection, and checks that the node keeps the connection open.
+func (s *Suite) sendIgnored(t *utesting.T, msgCode uint64, build func(key *ecdsa.PrivateKey) (*istanbul.Message, error)) {
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		t.Fatalf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	msg, err := build(conn.ourKey)
+	if err != nil {
+		t.Fatalf("could not create message: %v", err)
+	}
+	if err := conn.writeIstanbulMsg(msgCode, msg); err != nil {
+		t.Fatalf("could not write to connection: %v", err)
+	}
+	if err := conn.expectConnected(connectedDuration); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// sendVersionCertificate sends a version certificate of the validator from a
+// new peer, returning the payload sent.
+func (s *Suite) sendVersionCertificate(validator env.Account, version uint) ([]byte, error) {
+	vc, err := istanbul.NewVersionCertificate(version, signFn(validator.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("could not create version certificate: %v", err)
+	}
+	payload, err := istanbul.NewVersionCeritifcatesMessage([]*istanbul.VersionCertificate{vc}, validator.Address).Payload()
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := s.peerIstanbul()
+	if err != nil {
+		return nil, fmt.Errorf("could not peer: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteIstanbul(istanbul.VersionCertificatesMsg, payload); err != nil {
+		return nil, fmt.Errorf("could not write to connection: %v", err)
+	}
+	// Give the node the time to read the message before closing the connection
+	return payload, conn.expectConnected(announceProcessingTime)
+}
+
+// waitForVersion waits until the node has at least the given version for the
+// address. Validators update their versions too, so newer ones are accepted.
+func (s *Suite) waitForVersion(address common.Address, version uint) error {
+	var have uint
+	for start := time.Now(); time.Since(start) < timeout; time.Sleep(time.Second) {
+		var err error
+		if have, err = s.versionCertificate(address); err != nil {
+			return err
+		}
+		if have >= version {
+			return nil
+		}
+	}
+	return fmt.Errorf("version certificate of %v not stored: have version %d, want %d", address.Hex(), have, version)
+}
+
+// checkVersion checks that the node has at least the given version for the address.
+func (s *Suite) checkVersion(address common.Address, version uint) error {
+	have, err := s.versionCertificate(address)
+	if err != nil {
+		return err
+	}
+	if have < version {
+		return fmt.Errorf("version certificate of %v replaced: have version %d, want %d", address.Hex(), have, version)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethtest
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/internal/utesting"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/node"
+	"github.com/celo-org/celo-blockchain/p2p"
+	"github.com/celo-org/celo-blockchain/params"
+	"github.com/celo-org/celo-blockchain/test"
+)
+
+const celoContractsBuildPath = "../../../../compiled-system-contracts"
+
+func TestIstanbulSuite(t *testing.T) {
+	if _, err := os.Stat(celoContractsBuildPath); os.IsNotExist(err) {
+		t.Skipf("could not find dir %s, try running 'make prepare-system-contracts' and then re-running the test", celoContractsBuildPath)
+	}
+	envPath, err := ioutil.TempDir("", "istanbul-suite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(envPath)
+
+	// The node is the second validator, the tests sign with the key of the first
+	accounts := test.AccountConfig(2, 0)
+	celo, err := runIstanbulNode(envPath, accounts)
+	if err != nil {
+		t.Fatalf("could not run node: %v", err)
+	}
+	defer celo.Close()
+
+	suite, err := NewIstanbulSuite(celo.Enode, envPath)
+	if err != nil {
+		t.Fatalf("could not create new test suite: %v", err)
+	}
+	for _, test := range suite.IstanbulTests() {
+		t.Run(test.Name, func(t *testing.T) {
+			result := utesting.RunTAP([]utesting.Test{{Name: test.Name, Fn: test.Fn}}, os.Stdout)
+			if result[0].Failed {
+				t.Fatal()
+			}
+		})
+	}
+}
+
+// runIstanbulNode creates a mycelo environment at envPath and starts the node
+// of its second validator.
+func runIstanbulNode(envPath string, accounts *env.AccountsConfig) (*test.Node, error) {
+	gc, ec, err := test.BuildConfig(accounts)
+	if err != nil {
+		return nil, err
+	}
+	gc.Istanbul = params.IstanbulConfig{
+		Epoch:          ec.Istanbul.Epoch,
+		ProposerPolicy: uint64(ec.Istanbul.ProposerPolicy),
+		LookbackWindow: ec.Istanbul.DefaultLookbackWindow,
+		BlockPeriod:    ec.Istanbul.BlockPeriod,
+		RequestTimeout: ec.Istanbul.RequestTimeout,
+	}
+	genesis, err := test.GenerateGenesis(accounts, gc, celoContractsBuildPath)
+	if err != nil {
+		return nil, err
+	}
+	environment, err := env.New(envPath, &env.Config{ChainID: big.NewInt(1), Accounts: *accounts})
+	if err != nil {
+		return nil, err
+	}
+	if err := environment.Save(); err != nil {
+		return nil, err
+	}
+	if err := environment.SaveGenesis(genesis); err != nil {
+		return nil, err
+	}
+
+	nc := &node.Config{
+		Name: "celo",
+		P2P: p2p.Config{
+			ListenAddr:  "127.0.0.1:0",
+			NoDiscovery: true,
+			MaxPeers:    10, // in case a test requires multiple connections, can be changed in the future
+			NoDial:      true,
+		},
+		NoUSB:                true,
+		WSHost:               "127.0.0.1",
+		UsePlaintextKeystore: true,
+	}
+	return test.NewNode(&accounts.ValidatorAccounts()[1], nc, ec, genesis)
+}
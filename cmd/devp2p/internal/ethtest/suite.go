@@ -22,6 +22,7 @@ import (
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/eth/protocols/eth"
 	"github.com/celo-org/celo-blockchain/internal/utesting"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
 	"github.com/celo-org/celo-blockchain/p2p/enode"
 )
 
@@ -32,6 +33,11 @@ type Suite struct {
 
 	chain     *Chain
 	fullChain *Chain
+
+	// Set for the istanbul test suite
+	genesis         common.Hash
+	validators      []env.Account
+	announceVersion uint
 }
 
 // NewSuite creates and returns a new eth-test suite that can
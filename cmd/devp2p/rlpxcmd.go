@@ -36,6 +36,7 @@ var (
 		Subcommands: []cli.Command{
 			rlpxPingCommand,
 			rlpxEthTestCommand,
+			rlpxIstanbulTestCommand,
 		},
 	}
 	rlpxPingCommand = cli.Command{
@@ -53,6 +54,16 @@ var (
 			testTAPFlag,
 		},
 	}
+	rlpxIstanbulTestCommand = cli.Command{
+		Name:      "istanbul-test",
+		Usage:     "Runs istanbul protocol tests against a node of a mycelo network",
+		ArgsUsage: "<node> <mycelo env>",
+		Action:    rlpxIstanbulTest,
+		Flags: []cli.Flag{
+			testPatternFlag,
+			testTAPFlag,
+		},
+	}
 )
 
 func rlpxPing(ctx *cli.Context) error {
@@ -106,3 +117,15 @@ func rlpxEthTest(ctx *cli.Context) error {
 	}
 	return runTests(ctx, suite.AllEthTests())
 }
+
+// rlpxIstanbulTest runs the istanbul protocol test suite.
+func rlpxIstanbulTest(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		exit("missing path to the mycelo environment as command-line argument")
+	}
+	suite, err := ethtest.NewIstanbulSuite(getNodeArg(ctx), ctx.Args()[1])
+	if err != nil {
+		exit(err)
+	}
+	return runTests(ctx, suite.IstanbulTests())
+}
@@ -23,12 +23,16 @@ import (
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/common/math"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/validator"
 	"github.com/celo-org/celo-blockchain/core"
 	"github.com/celo-org/celo-blockchain/core/rawdb"
 	"github.com/celo-org/celo-blockchain/core/state"
 	"github.com/celo-org/celo-blockchain/core/types"
 	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/core/vm/vmcontext"
 	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
 	"github.com/celo-org/celo-blockchain/ethdb"
 	"github.com/celo-org/celo-blockchain/log"
 	"github.com/celo-org/celo-blockchain/params"
@@ -59,6 +63,12 @@ type ommer struct {
 	Address common.Address `json:"address"`
 }
 
+// istanbulValidator is a member of the istanbul validator set of the current block.
+type istanbulValidator struct {
+	Address      common.Address                `json:"address"`
+	BLSPublicKey blscrypto.SerializedPublicKey `json:"blsPublicKey"`
+}
+
 //go:generate gencodec -type stEnv -field-override stEnvMarshaling -out gen_stenv.go
 type stEnv struct {
 	Coinbase    common.Address                      `json:"currentCoinbase"   gencodec:"required"`
@@ -69,6 +79,13 @@ type stEnv struct {
 	BlockHashes map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
 	Ommers      []ommer                             `json:"ommers,omitempty"`
 	BaseFee     *big.Int                            `json:"currentBaseFee,omitempty"`
+
+	// Celo context of the block
+	Validators                            []istanbulValidator                           `json:"validators,omitempty"`
+	EpochSize                             uint64                                        `json:"epochSize,omitempty"`
+	ParentSealBitmaps                     map[math.HexOrDecimal64]*math.HexOrDecimal256 `json:"parentSealBitmaps,omitempty"`
+	GasPriceMinimums                      map[common.Address]*math.HexOrDecimal256      `json:"gasPriceMinimums,omitempty"`
+	IntrinsicGasForAlternativeFeeCurrency uint64                                        `json:"intrinsicGasForAlternativeFeeCurrency,omitempty"`
 }
 
 type stEnvMarshaling struct {
@@ -78,6 +95,9 @@ type stEnvMarshaling struct {
 	Number     math.HexOrDecimal64
 	Timestamp  math.HexOrDecimal64
 	BaseFee    *math.HexOrDecimal256
+
+	EpochSize                             math.HexOrDecimal64
+	IntrinsicGasForAlternativeFeeCurrency math.HexOrDecimal64
 }
 
 type rejectedTx struct {
@@ -117,17 +137,28 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 	)
 	gaspool.AddGas(pre.Env.GasLimit)
 	vmContext := vm.BlockContext{
-		//		CanTransfer: core.CanTransfer,
-		//		Transfer:    core.Transfer,
+		CanTransfer: vmcontext.CanTransfer,
+		Transfer:    vmcontext.TobinTransfer,
 		Coinbase:    pre.Env.Coinbase,
 		BlockNumber: new(big.Int).SetUint64(pre.Env.Number),
 		Time:        new(big.Int).SetUint64(pre.Env.Timestamp),
 		GetHash:     getHash,
+		// There is no chain to verify seals against
+		VerifySeal: func(*types.Header) bool { return false },
+
+		EpochSize:            pre.Env.EpochSize,
+		GetValidators:        pre.getValidators,
+		GetHeaderByNumber:    pre.getHeaderByNumber,
+		GetRegisteredAddress: vmcontext.GetRegisteredAddress,
 	}
 	// If currentBaseFee is defined, add it to the vmContext.
 	if pre.Env.BaseFee != nil {
 		vmContext.BaseFee = new(big.Int).Set(pre.Env.BaseFee)
 	}
+	// Without a blockchain to create an EVM for each system call from, the system
+	// contracts in the prestate are called through an EVM of their own.
+	vmRunner := &vmcontext.SharedEVMRunner{EVM: vm.NewEVM(vmContext, vm.TxContext{GasPrice: common.Big0}, statedb, chainConfig, vm.Config{})}
+	sysCtx := pre.sysContractCallCtx()
 
 	for i, tx := range txs {
 		msg, err := tx.AsMessage(signer, pre.Env.BaseFee)
@@ -149,9 +180,8 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 
 		snapshot := statedb.Snapshot()
 
-		// FIXME this is broken
 		// (ret []byte, usedGas uint64, failed bool, err error)
-		msgResult, err := core.ApplyMessage(evm, msg, gaspool, nil, &core.SysContractCallCtx{})
+		msgResult, err := core.ApplyMessage(evm, msg, gaspool, vmRunner, sysCtx)
 		if err != nil {
 			statedb.RevertToSnapshot(snapshot)
 			log.Info("rejected tx", "index", i, "hash", tx.Hash(), "from", msg.From(), "error", err)
@@ -244,6 +274,63 @@ func (pre *Prestate) Apply(vmConfig vm.Config, chainConfig *params.ChainConfig,
 	return statedb, execRs, nil
 }
 
+// getValidators returns the validators of the block after the given one. The env
+// only has those of the current block, which are also the validators of the other
+// blocks of its epoch. There are none for the blocks of previous epochs.
+func (pre *Prestate) getValidators(blockNumber *big.Int, headerHash common.Hash) []istanbul.Validator {
+	number := blockNumber.Uint64() + 1
+	if number != pre.Env.Number && (pre.Env.EpochSize == 0 ||
+		istanbul.GetEpochNumber(number, pre.Env.EpochSize) != istanbul.GetEpochNumber(pre.Env.Number, pre.Env.EpochSize)) {
+		log.Warn("validators requested for a block outside the epoch of the env", "number", number)
+		return nil
+	}
+	validators := make([]istanbul.Validator, len(pre.Env.Validators))
+	for i, v := range pre.Env.Validators {
+		validators[i] = validator.New(v.Address, v.BLSPublicKey)
+	}
+	return validators
+}
+
+// getHeaderByNumber returns a header that only carries the parent seal bitmap of
+// the env for the given block, or nil if it is not provided.
+func (pre *Prestate) getHeaderByNumber(number uint64) *types.Header {
+	bitmap, ok := pre.Env.ParentSealBitmaps[math.HexOrDecimal64(number)]
+	if !ok {
+		log.Warn("parent seal bitmap requested, but not provided", "number", number)
+		return nil
+	}
+	extra, err := rlp.EncodeToBytes(&types.IstanbulExtra{
+		RemovedValidators:    new(big.Int),
+		AggregatedSeal:       types.IstanbulAggregatedSeal{Bitmap: new(big.Int), Round: new(big.Int)},
+		ParentAggregatedSeal: types.IstanbulAggregatedSeal{Bitmap: (*big.Int)(bitmap), Round: new(big.Int)},
+	})
+	if err != nil {
+		return nil
+	}
+	return &types.Header{
+		Number: new(big.Int).SetUint64(number),
+		Extra:  append(make([]byte, types.IstanbulExtraVanity), extra...),
+	}
+}
+
+// sysContractCallCtx returns the system contract values of the env. The gas price
+// minimum of CELO is the currentBaseFee, and the other currencies with a gas price
+// minimum are the whitelisted fee currencies.
+func (pre *Prestate) sysContractCallCtx() *core.SysContractCallCtx {
+	gasPriceMinimums := make(core.GasPriceMinimums)
+	for feeCurrency, gasPriceMinimum := range pre.Env.GasPriceMinimums {
+		gasPriceMinimums[feeCurrency] = (*big.Int)(gasPriceMinimum)
+	}
+	if pre.Env.BaseFee != nil {
+		gasPriceMinimums[common.ZeroAddress] = pre.Env.BaseFee
+	}
+	intrinsicGas := pre.Env.IntrinsicGasForAlternativeFeeCurrency
+	if intrinsicGas == 0 {
+		intrinsicGas = params.IntrinsicGasForAlternativeFeeCurrency
+	}
+	return core.NewSysContractCallCtxFromValues(intrinsicGas, gasPriceMinimums)
+}
+
 func MakePreState(db ethdb.Database, accounts core.GenesisAlloc) *state.StateDB {
 	sdb := state.NewDatabase(db)
 	statedb, _ := state.New(common.Hash{}, sdb, nil)
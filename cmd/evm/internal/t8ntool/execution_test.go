@@ -0,0 +1,59 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package t8ntool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+)
+
+func TestGetValidators(t *testing.T) {
+	pre := &Prestate{Env: stEnv{
+		Number:    25,
+		EpochSize: 10,
+		Validators: []istanbulValidator{
+			{Address: common.HexToAddress("0xaaaa")},
+			{Address: common.HexToAddress("0xbbbb")},
+		},
+	}}
+	for _, tt := range []struct {
+		number uint64
+		want   int
+	}{
+		{24, 2}, // Validators of the current block
+		{21, 2}, // First block of the epoch
+		{20, 0}, // Last block of the previous epoch
+		{5, 0},
+	} {
+		// The validators of a block are those returned for its parent
+		validators := pre.getValidators(new(big.Int).SetUint64(tt.number-1), common.Hash{})
+		if len(validators) != tt.want {
+			t.Errorf("block %d: have %d validators, want %d", tt.number, len(validators), tt.want)
+		}
+	}
+
+	// Without an epoch size, only the validators of the current block are known
+	pre.Env.EpochSize = 0
+	if validators := pre.getValidators(big.NewInt(24), common.Hash{}); len(validators) != 2 {
+		t.Errorf("current block: have %d validators, want 2", len(validators))
+	}
+	if validators := pre.getValidators(big.NewInt(23), common.Hash{}); len(validators) != 0 {
+		t.Errorf("parent block: have %d validators, want 0", len(validators))
+	}
+}
@@ -16,14 +16,19 @@ var _ = (*stEnvMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (s stEnv) MarshalJSON() ([]byte, error) {
 	type stEnv struct {
-		Coinbase    common.UnprefixedAddress            `json:"currentCoinbase"   gencodec:"required"`
-		Difficulty  *math.HexOrDecimal256               `json:"currentDifficulty" gencodec:"required"`
-		GasLimit    math.HexOrDecimal64                 `json:"currentGasLimit"   gencodec:"required"`
-		Number      math.HexOrDecimal64                 `json:"currentNumber"     gencodec:"required"`
-		Timestamp   math.HexOrDecimal64                 `json:"currentTimestamp"  gencodec:"required"`
-		BlockHashes map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
-		Ommers      []ommer                             `json:"ommers,omitempty"`
-		BaseFee     *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
+		Coinbase                              common.UnprefixedAddress                      `json:"currentCoinbase"   gencodec:"required"`
+		Difficulty                            *math.HexOrDecimal256                         `json:"currentDifficulty" gencodec:"required"`
+		GasLimit                              math.HexOrDecimal64                           `json:"currentGasLimit"   gencodec:"required"`
+		Number                                math.HexOrDecimal64                           `json:"currentNumber"     gencodec:"required"`
+		Timestamp                             math.HexOrDecimal64                           `json:"currentTimestamp"  gencodec:"required"`
+		BlockHashes                           map[math.HexOrDecimal64]common.Hash           `json:"blockHashes,omitempty"`
+		Ommers                                []ommer                                       `json:"ommers,omitempty"`
+		BaseFee                               *math.HexOrDecimal256                         `json:"currentBaseFee,omitempty"`
+		Validators                            []istanbulValidator                           `json:"validators,omitempty"`
+		EpochSize                             math.HexOrDecimal64                           `json:"epochSize,omitempty"`
+		ParentSealBitmaps                     map[math.HexOrDecimal64]*math.HexOrDecimal256 `json:"parentSealBitmaps,omitempty"`
+		GasPriceMinimums                      map[common.Address]*math.HexOrDecimal256      `json:"gasPriceMinimums,omitempty"`
+		IntrinsicGasForAlternativeFeeCurrency math.HexOrDecimal64                           `json:"intrinsicGasForAlternativeFeeCurrency,omitempty"`
 	}
 	var enc stEnv
 	enc.Coinbase = common.UnprefixedAddress(s.Coinbase)
@@ -34,20 +39,30 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 	enc.BlockHashes = s.BlockHashes
 	enc.Ommers = s.Ommers
 	enc.BaseFee = (*math.HexOrDecimal256)(s.BaseFee)
+	enc.Validators = s.Validators
+	enc.EpochSize = math.HexOrDecimal64(s.EpochSize)
+	enc.ParentSealBitmaps = s.ParentSealBitmaps
+	enc.GasPriceMinimums = s.GasPriceMinimums
+	enc.IntrinsicGasForAlternativeFeeCurrency = math.HexOrDecimal64(s.IntrinsicGasForAlternativeFeeCurrency)
 	return json.Marshal(&enc)
 }
 
 // UnmarshalJSON unmarshals from JSON.
 func (s *stEnv) UnmarshalJSON(input []byte) error {
 	type stEnv struct {
-		Coinbase    *common.UnprefixedAddress           `json:"currentCoinbase"   gencodec:"required"`
-		Difficulty  *math.HexOrDecimal256               `json:"currentDifficulty" gencodec:"required"`
-		GasLimit    *math.HexOrDecimal64                `json:"currentGasLimit"   gencodec:"required"`
-		Number      *math.HexOrDecimal64                `json:"currentNumber"     gencodec:"required"`
-		Timestamp   *math.HexOrDecimal64                `json:"currentTimestamp"  gencodec:"required"`
-		BlockHashes map[math.HexOrDecimal64]common.Hash `json:"blockHashes,omitempty"`
-		Ommers      []ommer                             `json:"ommers,omitempty"`
-		BaseFee     *math.HexOrDecimal256               `json:"currentBaseFee,omitempty"`
+		Coinbase                              *common.UnprefixedAddress                     `json:"currentCoinbase"   gencodec:"required"`
+		Difficulty                            *math.HexOrDecimal256                         `json:"currentDifficulty" gencodec:"required"`
+		GasLimit                              *math.HexOrDecimal64                          `json:"currentGasLimit"   gencodec:"required"`
+		Number                                *math.HexOrDecimal64                          `json:"currentNumber"     gencodec:"required"`
+		Timestamp                             *math.HexOrDecimal64                          `json:"currentTimestamp"  gencodec:"required"`
+		BlockHashes                           map[math.HexOrDecimal64]common.Hash           `json:"blockHashes,omitempty"`
+		Ommers                                []ommer                                       `json:"ommers,omitempty"`
+		BaseFee                               *math.HexOrDecimal256                         `json:"currentBaseFee,omitempty"`
+		Validators                            []istanbulValidator                           `json:"validators,omitempty"`
+		EpochSize                             *math.HexOrDecimal64                          `json:"epochSize,omitempty"`
+		ParentSealBitmaps                     map[math.HexOrDecimal64]*math.HexOrDecimal256 `json:"parentSealBitmaps,omitempty"`
+		GasPriceMinimums                      map[common.Address]*math.HexOrDecimal256      `json:"gasPriceMinimums,omitempty"`
+		IntrinsicGasForAlternativeFeeCurrency *math.HexOrDecimal64                          `json:"intrinsicGasForAlternativeFeeCurrency,omitempty"`
 	}
 	var dec stEnv
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -82,5 +97,20 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 	if dec.BaseFee != nil {
 		s.BaseFee = (*big.Int)(dec.BaseFee)
 	}
+	if dec.Validators != nil {
+		s.Validators = dec.Validators
+	}
+	if dec.EpochSize != nil {
+		s.EpochSize = uint64(*dec.EpochSize)
+	}
+	if dec.ParentSealBitmaps != nil {
+		s.ParentSealBitmaps = dec.ParentSealBitmaps
+	}
+	if dec.GasPriceMinimums != nil {
+		s.GasPriceMinimums = dec.GasPriceMinimums
+	}
+	if dec.IntrinsicGasForAlternativeFeeCurrency != nil {
+		s.IntrinsicGasForAlternativeFeeCurrency = uint64(*dec.IntrinsicGasForAlternativeFeeCurrency)
+	}
 	return nil
 }
@@ -82,9 +82,6 @@ func Main(ctx *cli.Context) error {
 	glogger.Verbosity(log.Lvl(ctx.Int(VerbosityFlag.Name)))
 	log.Root().SetHandler(glogger)
 
-	// TODO this command is broken: vmContext and vmRunner can not be created as required
-	log.Crit("Command does not work as expected. Requires Bug Fixing")
-
 	var (
 		err     error
 		tracer  vm.Tracer
@@ -131,30 +131,29 @@ var (
 	}
 )
 
-// FIXME broken command
-// var stateTransitionCommand = cli.Command{
-// 	Name:    "transition",
-// 	Aliases: []string{"t8n"},
-// 	Usage:   "executes a full state transition",
-// 	Action:  t8ntool.Main,
-// 	Flags: []cli.Flag{
-// 		t8ntool.TraceFlag,
-// 		t8ntool.TraceDisableMemoryFlag,
-// 		t8ntool.TraceDisableStackFlag,
-// 		t8ntool.TraceDisableReturnDataFlag,
-// 		t8ntool.OutputBasedir,
-// 		t8ntool.OutputAllocFlag,
-// 		t8ntool.OutputResultFlag,
-// 		t8ntool.OutputBodyFlag,
-// 		t8ntool.InputAllocFlag,
-// 		t8ntool.InputEnvFlag,
-// 		t8ntool.InputTxsFlag,
-// 		t8ntool.ForknameFlag,
-// 		t8ntool.ChainIDFlag,
-// 		t8ntool.RewardFlag,
-// 		t8ntool.VerbosityFlag,
-// 	},
-// }
+var stateTransitionCommand = cli.Command{
+	Name:    "transition",
+	Aliases: []string{"t8n"},
+	Usage:   "executes a full state transition",
+	Action:  t8ntool.Main,
+	Flags: []cli.Flag{
+		t8ntool.TraceFlag,
+		t8ntool.TraceDisableMemoryFlag,
+		t8ntool.TraceDisableStackFlag,
+		t8ntool.TraceDisableReturnDataFlag,
+		t8ntool.OutputBasedir,
+		t8ntool.OutputAllocFlag,
+		t8ntool.OutputResultFlag,
+		t8ntool.OutputBodyFlag,
+		t8ntool.InputAllocFlag,
+		t8ntool.InputEnvFlag,
+		t8ntool.InputTxsFlag,
+		t8ntool.ForknameFlag,
+		t8ntool.ChainIDFlag,
+		t8ntool.RewardFlag,
+		t8ntool.VerbosityFlag,
+	},
+}
 
 func init() {
 	app.Flags = []cli.Flag{
@@ -187,7 +186,7 @@ func init() {
 		disasmCommand,
 		runCommand,
 		stateTestCommand,
-		// stateTransitionCommand,
+		stateTransitionCommand,
 	}
 	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
 }
@@ -0,0 +1,136 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/cmd/evm/internal/t8ntool"
+	"github.com/celo-org/celo-blockchain/internal/cmdtest"
+	"github.com/docker/docker/pkg/reexec"
+)
+
+func TestMain(m *testing.M) {
+	// Run the app if we've been exec'd as "evm-test" in TestT8n.
+	reexec.Register("evm-test", func() {
+		if err := app.Run(os.Args); err != nil {
+			code := 1
+			if ec, ok := err.(*t8ntool.NumberedError); ok {
+				code = ec.Code()
+			}
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(code)
+		}
+		os.Exit(0)
+	})
+	// check if we have been reexec'd
+	if reexec.Init() {
+		return
+	}
+	os.Exit(m.Run())
+}
+
+type testT8n struct {
+	*cmdtest.TestCmd
+}
+
+type t8nInput struct {
+	inAlloc string
+	inTxs   string
+	inEnv   string
+	stFork  string
+}
+
+func (args *t8nInput) get(base string) []string {
+	return []string{
+		"--input.alloc", fmt.Sprintf("%v/%v", base, args.inAlloc),
+		"--input.txs", fmt.Sprintf("%v/%v", base, args.inTxs),
+		"--input.env", fmt.Sprintf("%v/%v", base, args.inEnv),
+		"--state.fork", args.stFork,
+	}
+}
+
+func TestT8n(t *testing.T) {
+	tt := new(testT8n)
+	tt.TestCmd = cmdtest.NewTestCmd(t, tt)
+	for i, tc := range []struct {
+		base        string
+		input       t8nInput
+		expExitCode int
+		expOut      string
+	}{
+		{ // Celo block context, gateway fee in CELO and non whitelisted fee currency
+			base: "./testdata/14",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Espresso",
+			},
+			expOut: "exp.json",
+		},
+		{ // Fees paid in a whitelisted fee currency
+			base: "./testdata/15",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Espresso",
+			},
+			expOut: "exp.json",
+		},
+		{ // Unknown fork
+			base: "./testdata/15",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Foo",
+			},
+			expExitCode: t8ntool.ErrorVMConfig,
+		},
+	} {
+		args := append([]string{"t8n", "--output.alloc", "stdout", "--output.result", "stdout"}, tc.input.get(tc.base)...)
+		tt.Run("evm-test", args...)
+		have := tt.Output()
+		if tc.expOut != "" {
+			want, err := ioutil.ReadFile(fmt.Sprintf("%v/%v", tc.base, tc.expOut))
+			if err != nil {
+				t.Fatalf("test %d: could not read expected output: %v", i, err)
+			}
+			ok, err := cmpJson(have, want)
+			switch {
+			case err != nil:
+				t.Fatalf("test %d, json parsing failed: %v", i, err)
+			case !ok:
+				t.Fatalf("test %d: output wrong, have \n%v\nwant\n%v\n", i, string(have), string(want))
+			}
+		}
+		tt.WaitExit()
+		if have, want := tt.ExitStatus(), tc.expExitCode; have != want {
+			t.Fatalf("test %d: wrong exit code, have %d, want %d", i, have, want)
+		}
+	}
+}
+
+// cmpJson compares the JSON in two byte slices.
+func cmpJson(a, b []byte) (bool, error) {
+	var j, j2 interface{}
+	if err := json.Unmarshal(a, &j); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &j2); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(j2, j), nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	ethereum "github.com/celo-org/celo-blockchain"
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+// celoSymbol is the symbol of the native token, which is always handed out
+const celoSymbol = "CELO"
+
+// tokenTransferGas is the gas limit of a stable token transfer, which is
+// topped up with params.IntrinsicGasForAlternativeFeeCurrency if the fees
+// are not paid in CELO.
+const tokenTransferGas = 100000
+
+// stableTokens maps the symbols of the stable tokens the faucet can hand out
+// to the name of their contract in the registry.
+var stableTokens = map[string]string{
+	"cUSD":  "StableToken",
+	"cEUR":  "StableTokenEUR",
+	"cREAL": "StableTokenBRL",
+}
+
+// erc20ABI holds the subset of the ERC20 interface used by the faucet.
+var erc20ABI = mustParseABI(`[
+	{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"},
+	{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"payable":false,"stateMutability":"view","type":"function"}
+]`)
+
+func mustParseABI(raw string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(raw))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// faucetToken is a token handed out by the faucet.
+type faucetToken struct {
+	Symbol   string         // Symbol shown to the users (e.g. cUSD)
+	Contract string         // Name of the token's contract in the registry, empty for CELO
+	Amount   int            // Number of whole tokens to pay out on the first tier
+	Address  common.Address // Address of the token's contract, resolved from the registry
+}
+
+// native returns whether the token is CELO, which is sent as a plain value transfer.
+func (t *faucetToken) native() bool {
+	return t.Contract == ""
+}
+
+// payout returns the amount of token units to pay out for a funding tier.
+func (t *faucetToken) payout(tier uint) *big.Int {
+	amount := new(big.Int).Mul(big.NewInt(int64(t.Amount)), ether)
+	amount = new(big.Int).Mul(amount, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(tier)), nil))
+	return new(big.Int).Div(amount, new(big.Int).Exp(big.NewInt(2), big.NewInt(int64(tier)), nil))
+}
+
+// label formats the amount paid out for a funding tier, e.g. "2.5 cUSD".
+func (t *faucetToken) label(tier uint) string {
+	amount := float64(t.Amount) * math.Pow(2.5, float64(tier))
+	return fmt.Sprintf("%s %s", strconv.FormatFloat(amount, 'f', -1, 64), t.Symbol)
+}
+
+// parseTokens parses the list of stable tokens to hand out, such as "cUSD=10,cEUR".
+// Tokens without an amount pay out defaultAmount. CELO is always handed out, and
+// is returned first.
+func parseTokens(spec string, defaultAmount int) ([]*faucetToken, error) {
+	tokens := []*faucetToken{{Symbol: celoSymbol, Amount: defaultAmount}}
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		symbol := strings.TrimSpace(parts[0])
+		if seen[symbol] {
+			return nil, fmt.Errorf("duplicated token %q", symbol)
+		}
+		seen[symbol] = true
+
+		amount := defaultAmount
+		if len(parts) == 2 {
+			var err error
+			if amount, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil || amount <= 0 {
+				return nil, fmt.Errorf("invalid amount for token %q: %q", symbol, parts[1])
+			}
+		}
+		if symbol == celoSymbol {
+			tokens[0].Amount = amount
+			continue
+		}
+		contract, ok := stableTokens[symbol]
+		if !ok {
+			return nil, fmt.Errorf("unknown token %q", symbol)
+		}
+		tokens = append(tokens, &faucetToken{Symbol: symbol, Contract: contract, Amount: amount})
+	}
+	return tokens, nil
+}
+
+// findToken returns the token with the given symbol, or nil if it's not handed out.
+func findToken(tokens []*faucetToken, symbol string) *faucetToken {
+	for _, token := range tokens {
+		if token.Symbol == symbol {
+			return token
+		}
+	}
+	return nil
+}
+
+// registryAddressFor looks up the address of a core contract in the registry.
+func registryAddressFor(ctx context.Context, caller ethereum.ContractCaller, name string, number *big.Int) (common.Address, error) {
+	input, err := abis.Registry.Pack("getAddressFor", [32]byte(crypto.Keccak256Hash([]byte(name))))
+	if err != nil {
+		return common.Address{}, err
+	}
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &params.RegistrySmartContractAddress, Data: input}, number)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var address common.Address
+	if err := abis.Registry.UnpackIntoInterface(&address, "getAddressFor", output); err != nil {
+		return common.Address{}, err
+	}
+	if address == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%s is not in the registry", name)
+	}
+	return address, nil
+}
+
+// validateFeeCurrency checks that the fee currency is CELO, a known stable token
+// symbol or a token address.
+func validateFeeCurrency(spec string) error {
+	if spec == celoSymbol || common.IsHexAddress(spec) {
+		return nil
+	}
+	if _, ok := stableTokens[spec]; !ok {
+		return fmt.Errorf("unknown fee currency %q", spec)
+	}
+	return nil
+}
+
+// resolveFeeCurrency returns the address of the token to pay fees with, or nil
+// if the fees are paid in CELO.
+func resolveFeeCurrency(ctx context.Context, caller ethereum.ContractCaller, spec string, number *big.Int) (*common.Address, error) {
+	if spec == celoSymbol {
+		return nil, nil
+	}
+	if common.IsHexAddress(spec) {
+		address := common.HexToAddress(spec)
+		return &address, nil
+	}
+	address, err := registryAddressFor(ctx, caller, stableTokens[spec], number)
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// tokenBalance retrieves the balance of an account in a stable token.
+func tokenBalance(ctx context.Context, caller ethereum.ContractCaller, token, account common.Address, number *big.Int) (*big.Int, error) {
+	input, err := erc20ABI.Pack("balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &token, Data: input}, number)
+	if err != nil {
+		return nil, err
+	}
+	balance := new(big.Int)
+	if err := erc20ABI.UnpackIntoInterface(&balance, "balanceOf", output); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// formatFunds formats the balances of the faucet in whole tokens, e.g. "120 CELO, 15 cUSD".
+func formatFunds(tokens []*faucetToken, balances map[string]*big.Int) string {
+	funds := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if balance, ok := balances[token.Symbol]; ok {
+			funds = append(funds, fmt.Sprintf("%v %s", new(big.Int).Div(balance, ether), token.Symbol))
+		}
+	}
+	return strings.Join(funds, ", ")
+}
+
+// rateLimiter keeps track of when users may request each token again. Requests
+// are limited both by the user's social network identity and by the address
+// being funded, so that neither can be used to drain the faucet.
+type rateLimiter struct {
+	timeouts map[string]time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{timeouts: make(map[string]time.Time)}
+}
+
+// until returns the time until which the token may not be sent to the user or
+// the address, and whether that time is still in the future.
+func (r *rateLimiter) until(token, id string, address common.Address, now time.Time) (time.Time, bool) {
+	timeout := r.timeouts[token+"/"+id]
+	if addrTimeout := r.timeouts[token+"/"+address.Hex()]; addrTimeout.After(timeout) {
+		timeout = addrTimeout
+	}
+	return timeout, now.Before(timeout)
+}
+
+// limit blocks requests of the token by the user or for the address until the given time.
+func (r *rateLimiter) limit(token, id string, address common.Address, until time.Time) {
+	r.timeouts[token+"/"+id] = until
+	r.timeouts[token+"/"+address.Hex()] = until
+}
+
+// getGenesis returns the genesis of a mycelo environment if envPath is set,
+// or the one in the genesisPath file otherwise.
+func getGenesis(genesisPath, envPath string) (*core.Genesis, error) {
+	switch {
+	case envPath != "":
+		environment, err := env.Load(envPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load mycelo env %s: %w", envPath, err)
+		}
+		return environment.LoadGenesis()
+	case genesisPath != "":
+		var genesis core.Genesis
+		err := common.LoadJSON(genesisPath, &genesis)
+		return &genesis, err
+	default:
+		return nil, fmt.Errorf("no genesis provided, use --genesis or --mycelo.env")
+	}
+}
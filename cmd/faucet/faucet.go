@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU General Public License
 // along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
 
-// faucet is an Ether faucet backed by a light client.
+// faucet is a CELO and stable token faucet backed by a light client.
 package main
 
 //go:generate go-bindata -nometadata -o website.go faucet.html
@@ -36,7 +36,6 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -63,16 +62,19 @@ import (
 
 var (
 	genesisFlag = flag.String("genesis", "", "Genesis json file to seed the chain with")
+	envFlag     = flag.String("mycelo.env", "", "mycelo environment directory to seed the chain with (instead of --genesis)")
 	apiPortFlag = flag.Int("apiport", 8080, "Listener port for the HTTP API connection")
 	ethPortFlag = flag.Int("ethport", 30303, "Listener port for the devp2p connection")
 	bootFlag    = flag.String("bootnodes", "", "Comma separated bootnode enode URLs to seed with")
-	netFlag     = flag.Uint64("network", 0, "Network ID to use for the Ethereum protocol")
+	netFlag     = flag.Uint64("network", 0, "Network ID to use for the Celo protocol (defaults to the chain id of a mycelo env)")
 	statsFlag   = flag.String("ethstats", "", "Ethstats network monitoring auth string")
 
 	netnameFlag = flag.String("faucet.name", "", "Network name to assign to the faucet")
-	payoutFlag  = flag.Int("faucet.amount", 1, "Number of Ethers to pay out per user request")
+	payoutFlag  = flag.Int("faucet.amount", 1, "Number of tokens to pay out per user request, unless set in --faucet.tokens")
 	minutesFlag = flag.Int("faucet.minutes", 1440, "Number of minutes to wait between funding rounds")
 	tiersFlag   = flag.Int("faucet.tiers", 3, "Number of funding tiers to enable (x3 time, x2.5 funds)")
+	tokensFlag  = flag.String("faucet.tokens", "cUSD,cEUR", "Comma separated stable tokens to pay out besides CELO, with optional amounts (e.g. cUSD=10,cEUR)")
+	feeFlag     = flag.String("faucet.feecurrency", celoSymbol, "Currency to pay the transaction fees with (CELO, a stable token symbol or a token address)")
 
 	accJSONFlag = flag.String("account.json", "", "Key json file to fund user requests with")
 	accPassFlag = flag.String("account.pass", "", "Decryption password to access faucet funds")
@@ -81,13 +83,10 @@ var (
 	captchaSecret = flag.String("captcha.secret", "", "Recaptcha secret key to authenticate server side")
 
 	noauthFlag = flag.Bool("noauth", false, "Enables funding requests without authentication")
-	logFlag    = flag.Int("loglevel", 3, "Log level to use for Ethereum and the faucet")
+	logFlag    = flag.Int("loglevel", 3, "Log level to use for Celo and the faucet")
 
 	twitterTokenFlag   = flag.String("twitter.token", "", "Bearer token to authenticate with the v2 Twitter API")
 	twitterTokenV1Flag = flag.String("twitter.token.v1", "", "Bearer token to authenticate with the v1.1 Twitter API")
-
-	goerliFlag  = flag.Bool("goerli", false, "Initializes the faucet with Görli network config")
-	rinkebyFlag = flag.Bool("rinkeby", false, "Initializes the faucet with Rinkeby network config")
 )
 
 var (
@@ -104,16 +103,28 @@ func main() {
 	flag.Parse()
 	log.Root().SetHandler(log.LvlFilterHandler(log.Lvl(*logFlag), log.StreamHandler(os.Stderr, log.TerminalFormat(true))))
 
-	// Construct the payout tiers
-	amounts := make([]string, *tiersFlag)
+	// Parse the tokens to hand out and the currency to pay fees with
+	tokens, err := parseTokens(*tokensFlag, *payoutFlag)
+	if err != nil {
+		log.Crit("Failed to parse faucet tokens", "err", err)
+	}
+	if err := validateFeeCurrency(*feeFlag); err != nil {
+		log.Crit("Failed to parse faucet fee currency", "err", err)
+	}
+	// Construct the payout tiers of every token
+	type tokenTiers struct {
+		Symbol  string
+		Amounts []string
+	}
+	tiers := make([]tokenTiers, len(tokens))
+	for i, token := range tokens {
+		tiers[i].Symbol = token.Symbol
+		for j := 0; j < *tiersFlag; j++ {
+			tiers[i].Amounts = append(tiers[i].Amounts, token.label(uint(j)))
+		}
+	}
 	periods := make([]string, *tiersFlag)
 	for i := 0; i < *tiersFlag; i++ {
-		// Calculate the amount for the next tier and format it
-		amount := float64(*payoutFlag) * math.Pow(2.5, float64(i))
-		amounts[i] = fmt.Sprintf("%s Ethers", strconv.FormatFloat(amount, 'f', -1, 64))
-		if amount == 1 {
-			amounts[i] = strings.TrimSuffix(amounts[i], "s")
-		}
 		// Calculate the period for the next tier and format it
 		period := *minutesFlag * int(math.Pow(3, float64(i)))
 		periods[i] = fmt.Sprintf("%d mins", period)
@@ -138,7 +149,7 @@ func main() {
 	website := new(bytes.Buffer)
 	err = template.Must(template.New("").Parse(string(tmpl))).Execute(website, map[string]interface{}{
 		"Network":   *netnameFlag,
-		"Amounts":   amounts,
+		"Tokens":    tiers,
 		"Periods":   periods,
 		"Recaptcha": *captchaToken,
 		"NoAuth":    *noauthFlag,
@@ -147,10 +158,15 @@ func main() {
 		log.Crit("Failed to render the faucet template", "err", err)
 	}
 	// Load and parse the genesis block requested by the user
-	genesis, err := getGenesis(genesisFlag, *goerliFlag, *rinkebyFlag)
+	genesis, err := getGenesis(*genesisFlag, *envFlag)
 	if err != nil {
 		log.Crit("Failed to parse genesis config", "err", err)
 	}
+	network := *netFlag
+	if network == 0 && *envFlag != "" {
+		// mycelo runs its nodes with the chain id as network id
+		network = genesis.Config.ChainID.Uint64()
+	}
 	// Convert the bootnodes to internal enode representations
 	var enodes []*enode.Node
 	for _, boot := range strings.Split(*bootFlag, ",") {
@@ -179,7 +195,7 @@ func main() {
 		log.Crit("Failed to unlock faucet signer account", "err", err)
 	}
 	// Assemble and start the faucet light service
-	faucet, err := newFaucet(genesis, *ethPortFlag, enodes, *netFlag, *statsFlag, ks, website.Bytes())
+	faucet, err := newFaucet(genesis, *ethPortFlag, enodes, network, *statsFlag, ks, website.Bytes(), tokens, *feeFlag)
 	if err != nil {
 		log.Crit("Failed to start faucet", "err", err)
 	}
@@ -193,29 +209,36 @@ func main() {
 // request represents an accepted funding request.
 type request struct {
 	Avatar  string             `json:"avatar"`  // Avatar URL to make the UI nicer
-	Account common.Address     `json:"account"` // Ethereum address being funded
+	Account common.Address     `json:"account"` // Celo address being funded
+	Token   string             `json:"token"`   // Symbol of the token being sent
+	Amount  string             `json:"amount"`  // Amount of tokens being sent, for display
 	Time    time.Time          `json:"time"`    // Timestamp when the request was accepted
 	Tx      *types.Transaction `json:"tx"`      // Transaction funding the account
 }
 
-// faucet represents a crypto faucet backed by an Ethereum light client.
+// faucet represents a crypto faucet backed by a Celo light client.
 type faucet struct {
 	config *params.ChainConfig // Chain configurations for signing
-	stack  *node.Node          // Ethereum protocol stack
-	client *ethclient.Client   // Client connection to the Ethereum chain
+	stack  *node.Node          // Celo protocol stack
+	client *ethclient.Client   // Client connection to the Celo chain
 	index  []byte              // Index page to serve up on the web
 
-	keystore *keystore.KeyStore // Keystore containing the single signer
-	account  accounts.Account   // Account funding user faucet requests
-	head     *types.Header      // Current head header of the faucet
-	balance  *big.Int           // Current balance of the faucet
-	nonce    uint64             // Current pending nonce of the faucet
-	price    *big.Int           // Current gas price to issue funds with
+	tokens      []*faucetToken  // Tokens handed out by the faucet, CELO first
+	feeSpec     string          // Currency to pay fees with, as configured by the user
+	feeCurrency *common.Address // Token to pay fees with, nil for CELO
+	resolved    bool            // Whether the token addresses were resolved from the registry
+
+	keystore *keystore.KeyStore  // Keystore containing the single signer
+	account  accounts.Account    // Account funding user faucet requests
+	head     *types.Header       // Current head header of the faucet
+	balances map[string]*big.Int // Current balances of the faucet, by token symbol
+	nonce    uint64              // Current pending nonce of the faucet
+	price    *big.Int            // Current gas price, in the fee currency, to issue funds with
 
-	conns    []*wsConn            // Currently live websocket connections
-	timeouts map[string]time.Time // History of users and their funding timeouts
-	reqs     []*request           // Currently pending funding requests
-	update   chan struct{}        // Channel to signal request updates
+	conns   []*wsConn     // Currently live websocket connections
+	limiter *rateLimiter  // History of users, addresses and their funding timeouts
+	reqs    []*request    // Currently pending funding requests
+	update  chan struct{} // Channel to signal request updates
 
 	lock sync.RWMutex // Lock protecting the faucet's internals
 }
@@ -227,7 +250,7 @@ type wsConn struct {
 	wlock sync.Mutex
 }
 
-func newFaucet(genesis *core.Genesis, port int, enodes []*enode.Node, network uint64, stats string, ks *keystore.KeyStore, index []byte) (*faucet, error) {
+func newFaucet(genesis *core.Genesis, port int, enodes []*enode.Node, network uint64, stats string, ks *keystore.KeyStore, index []byte, tokens []*faucetToken, feeSpec string) (*faucet, error) {
 	// Assemble the raw devp2p protocol stack
 	stack, err := node.New(&node.Config{
 		Name:    "geth",
@@ -246,7 +269,7 @@ func newFaucet(genesis *core.Genesis, port int, enodes []*enode.Node, network ui
 		return nil, err
 	}
 
-	// Assemble the Ethereum light client protocol
+	// Assemble the Celo light client protocol
 	cfg := ethconfig.Defaults
 	cfg.SyncMode = downloader.LightSync
 	cfg.NetworkId = network
@@ -255,7 +278,7 @@ func newFaucet(genesis *core.Genesis, port int, enodes []*enode.Node, network ui
 
 	lesBackend, err := les.New(stack, &cfg)
 	if err != nil {
-		return nil, fmt.Errorf("Failed to register the Ethereum service: %w", err)
+		return nil, fmt.Errorf("Failed to register the Celo service: %w", err)
 	}
 
 	// Assemble the ethstats monitoring and reporting service'
@@ -287,14 +310,16 @@ func newFaucet(genesis *core.Genesis, port int, enodes []*enode.Node, network ui
 		stack:    stack,
 		client:   client,
 		index:    index,
+		tokens:   tokens,
+		feeSpec:  feeSpec,
 		keystore: ks,
 		account:  ks.Accounts()[0],
-		timeouts: make(map[string]time.Time),
+		limiter:  newRateLimiter(),
 		update:   make(chan struct{}, 1),
 	}, nil
 }
 
-// close terminates the Ethereum connection and tears down the faucet.
+// close terminates the Celo connection and tears down the faucet.
 func (f *faucet) close() error {
 	return f.stack.Close()
 }
@@ -315,7 +340,7 @@ func (f *faucet) webHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(f.index)
 }
 
-// apiHandler handles requests for Ether grants and transaction statuses.
+// apiHandler handles requests for token grants and transaction statuses.
 func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 	upgrader := websocket.Upgrader{}
 	conn, err := upgrader.Upgrade(w, r, nil)
@@ -343,23 +368,21 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 	}()
 	// Gather the initial stats from the network to report
 	var (
-		head    *types.Header
-		balance *big.Int
-		nonce   uint64
+		head  *types.Header
+		funds string
+		nonce uint64
 	)
-	for head == nil || balance == nil {
+	for head == nil || funds == "" {
 		// Retrieve the current stats cached by the faucet
 		f.lock.RLock()
 		if f.head != nil {
 			head = types.CopyHeader(f.head)
 		}
-		if f.balance != nil {
-			balance = new(big.Int).Set(f.balance)
-		}
+		funds = formatFunds(f.tokens, f.balances)
 		nonce = f.nonce
 		f.lock.RUnlock()
 
-		if head == nil || balance == nil {
+		if head == nil || funds == "" {
 			// Report the faucet offline until initial stats are ready
 			//lint:ignore ST1005 This error is to be displayed in the browser
 			if err = sendError(wsconn, errors.New("Faucet offline")); err != nil {
@@ -374,7 +397,7 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 	reqs := f.reqs
 	f.lock.RUnlock()
 	if err = send(wsconn, map[string]interface{}{
-		"funds":    new(big.Int).Div(balance, ether),
+		"funds":    funds,
 		"funded":   nonce,
 		"peers":    f.stack.Server().PeerCount(),
 		"requests": reqs,
@@ -392,6 +415,7 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 		var msg struct {
 			URL     string `json:"url"`
 			Tier    uint   `json:"tier"`
+			Token   string `json:"token"`
 			Captcha string `json:"captcha"`
 		}
 		if err = conn.ReadJSON(&msg); err != nil {
@@ -412,7 +436,19 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		log.Info("Faucet funds requested", "url", msg.URL, "tier", msg.Tier)
+		if msg.Token == "" {
+			msg.Token = celoSymbol
+		}
+		token := findToken(f.tokens, msg.Token)
+		if token == nil {
+			//lint:ignore ST1005 This error is to be displayed in the browser
+			if err = sendError(wsconn, fmt.Errorf("Token %s is not handed out by this faucet", msg.Token)); err != nil {
+				log.Warn("Failed to send token error to client", "err", err)
+				return
+			}
+			continue
+		}
+		log.Info("Faucet funds requested", "url", msg.URL, "tier", msg.Tier, "token", token.Symbol)
 
 		// If captcha verifications are enabled, make sure we're not dealing with a robot
 		if *captchaToken != "" {
@@ -451,7 +487,7 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 		}
-		// Retrieve the Ethereum address to fund, the requesting user and a profile picture
+		// Retrieve the Celo address to fund, the requesting user and a profile picture
 		var (
 			id       string
 			username string
@@ -478,21 +514,26 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		log.Info("Faucet request valid", "url", msg.URL, "tier", msg.Tier, "user", username, "address", address)
+		log.Info("Faucet request valid", "url", msg.URL, "tier", msg.Tier, "token", token.Symbol, "user", username, "address", address)
 
-		// Ensure the user didn't request funds too recently
+		// Ensure neither the user nor the address requested the token too recently
 		f.lock.Lock()
 		var (
 			fund    bool
 			timeout time.Time
+			limited bool
 		)
-		if timeout = f.timeouts[id]; time.Now().After(timeout) {
+		if timeout, limited = f.limiter.until(token.Symbol, id, address, time.Now()); !limited {
 			// User wasn't funded recently, create the funding transaction
-			amount := new(big.Int).Mul(big.NewInt(int64(*payoutFlag)), ether)
-			amount = new(big.Int).Mul(amount, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(msg.Tier)), nil))
-			amount = new(big.Int).Div(amount, new(big.Int).Exp(big.NewInt(2), big.NewInt(int64(msg.Tier)), nil))
-
-			tx := types.NewTransaction(f.nonce+uint64(len(f.reqs)), address, amount, 21000, f.price, nil, nil, nil, nil)
+			tx, err := f.fundingTx(token, address, msg.Tier)
+			if err != nil {
+				f.lock.Unlock()
+				if err = sendError(wsconn, err); err != nil {
+					log.Warn("Failed to send transaction creation error to client", "err", err)
+					return
+				}
+				continue
+			}
 			signed, err := f.keystore.SignTx(f.account, tx, f.config.ChainID)
 			if err != nil {
 				f.lock.Unlock()
@@ -514,13 +555,15 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 			f.reqs = append(f.reqs, &request{
 				Avatar:  avatar,
 				Account: address,
+				Token:   token.Symbol,
+				Amount:  token.label(msg.Tier),
 				Time:    time.Now(),
 				Tx:      signed,
 			})
 			timeout := time.Duration(*minutesFlag*int(math.Pow(3, float64(msg.Tier)))) * time.Minute
 			grace := timeout / 288 // 24h timeout => 5m grace
 
-			f.timeouts[id] = time.Now().Add(timeout - grace)
+			f.limiter.limit(token.Symbol, id, address, time.Now().Add(timeout-grace))
 			fund = true
 		}
 		f.lock.Unlock()
@@ -533,7 +576,7 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 			}
 			continue
 		}
-		if err = sendSuccess(wsconn, fmt.Sprintf("Funding request accepted for %s into %s", username, address.Hex())); err != nil {
+		if err = sendSuccess(wsconn, fmt.Sprintf("Funding request of %s accepted for %s into %s", token.Symbol, username, address.Hex())); err != nil {
 			log.Warn("Failed to send funding success to client", "err", err)
 			return
 		}
@@ -544,8 +587,60 @@ func (f *faucet) apiHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// fundingTx creates the transaction paying out a tier of the token to an address.
+// It must be called with the faucet lock held.
+func (f *faucet) fundingTx(token *faucetToken, address common.Address, tier uint) (*types.Transaction, error) {
+	var (
+		nonce  = f.nonce + uint64(len(f.reqs))
+		amount = token.payout(tier)
+		gas    = uint64(params.TxGas)
+	)
+	if f.feeCurrency != nil {
+		gas += params.IntrinsicGasForAlternativeFeeCurrency
+	}
+	if token.native() {
+		return types.NewTransaction(nonce, address, amount, gas, f.price, f.feeCurrency, nil, nil, nil), nil
+	}
+	data, err := erc20ABI.Pack("transfer", address, amount)
+	if err != nil {
+		return nil, err
+	}
+	gas += tokenTransferGas - params.TxGas
+	return types.NewTransaction(nonce, token.Address, new(big.Int), gas, f.price, f.feeCurrency, nil, nil, data), nil
+}
+
+// resolve looks up the addresses of the stable tokens and of the fee currency in
+// the registry. It needs the light client to be synced, so it's retried on every
+// refresh until it succeeds.
+func (f *faucet) resolve(ctx context.Context, head *types.Header) error {
+	addresses := make([]common.Address, len(f.tokens))
+	for i, token := range f.tokens {
+		if token.native() {
+			continue
+		}
+		address, err := registryAddressFor(ctx, f.client, token.Contract, head.Number)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", token.Symbol, err)
+		}
+		addresses[i] = address
+	}
+	feeCurrency, err := resolveFeeCurrency(ctx, f.client, f.feeSpec, head.Number)
+	if err != nil {
+		return fmt.Errorf("failed to resolve fee currency %s: %w", f.feeSpec, err)
+	}
+	f.lock.Lock()
+	for i, token := range f.tokens {
+		token.Address = addresses[i]
+	}
+	f.feeCurrency, f.resolved = feeCurrency, true
+	f.lock.Unlock()
+
+	log.Info("Resolved faucet tokens from the registry", "tokens", len(f.tokens), "feecurrency", f.feeSpec)
+	return nil
+}
+
 // refresh attempts to retrieve the latest header from the chain and extract the
-// associated faucet balance and nonce for connectivity caching.
+// associated faucet balances and nonce for connectivity caching.
 func (f *faucet) refresh(head *types.Header) error {
 	// Ensure a state update does not run for too long
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -558,24 +653,42 @@ func (f *faucet) refresh(head *types.Header) error {
 			return err
 		}
 	}
-	// Retrieve the balance, nonce and gas price from the current head
+	// Resolve the token addresses if not yet done
+	f.lock.RLock()
+	resolved := f.resolved
+	f.lock.RUnlock()
+	if !resolved {
+		if err := f.resolve(ctx, head); err != nil {
+			return err
+		}
+	}
+	// Retrieve the balances, nonce and gas price from the current head
 	var (
-		balance *big.Int
-		nonce   uint64
-		price   *big.Int
+		balances = make(map[string]*big.Int)
+		nonce    uint64
+		price    *big.Int
 	)
-	if balance, err = f.client.BalanceAt(ctx, f.account.Address, head.Number); err != nil {
-		return err
+	for _, token := range f.tokens {
+		var balance *big.Int
+		if token.native() {
+			balance, err = f.client.BalanceAt(ctx, f.account.Address, head.Number)
+		} else {
+			balance, err = tokenBalance(ctx, f.client, token.Address, f.account.Address, head.Number)
+		}
+		if err != nil {
+			return err
+		}
+		balances[token.Symbol] = balance
 	}
 	if nonce, err = f.client.NonceAt(ctx, f.account.Address, head.Number); err != nil {
 		return err
 	}
-	if price, err = f.client.SuggestGasPrice(ctx); err != nil {
+	if price, err = f.client.SuggestGasPriceInCurrency(ctx, f.feeCurrency); err != nil {
 		return err
 	}
 	// Everything succeeded, update the cached stats and eject old requests
 	f.lock.Lock()
-	f.head, f.balance = head, balance
+	f.head, f.balances = head, balances
 	f.price, f.nonce = price, nonce
 	for len(f.reqs) > 0 && f.reqs[0].Tx.Nonce() < f.nonce {
 		f.reqs = f.reqs[1:]
@@ -613,14 +726,14 @@ func (f *faucet) loop() {
 			}
 			// Faucet state retrieved, update locally and send to clients
 			f.lock.RLock()
-			log.Info("Updated faucet state", "number", head.Number, "hash", head.Hash(), "age", common.PrettyAge(timestamp), "balance", f.balance, "nonce", f.nonce, "price", f.price)
+			funds := formatFunds(f.tokens, f.balances)
+			log.Info("Updated faucet state", "number", head.Number, "hash", head.Hash(), "age", common.PrettyAge(timestamp), "funds", funds, "nonce", f.nonce, "price", f.price)
 
-			balance := new(big.Int).Div(f.balance, ether)
 			peers := f.stack.Server().PeerCount()
 
 			for _, conn := range f.conns {
 				if err := send(conn, map[string]interface{}{
-					"funds":    balance,
+					"funds":    funds,
 					"funded":   f.nonce,
 					"peers":    peers,
 					"requests": f.reqs,
@@ -887,15 +1000,3 @@ func authNoAuth(url string) (string, string, common.Address, error) {
 	}
 	return address.Hex() + "@noauth", "", address, nil
 }
-
-// getGenesis returns a genesis based on input args
-func getGenesis(genesisFlag *string, goerliFlag bool, rinkebyFlag bool) (*core.Genesis, error) {
-	switch {
-	case genesisFlag != nil:
-		var genesis core.Genesis
-		err := common.LoadJSON(*genesisFlag, &genesis)
-		return &genesis, err
-	default:
-		return nil, fmt.Errorf("no genesis flag provided")
-	}
-}
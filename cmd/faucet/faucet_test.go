@@ -17,7 +17,9 @@
 package main
 
 import (
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/celo-org/celo-blockchain/common"
 )
@@ -43,3 +45,71 @@ func TestFacebook(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTokens(t *testing.T) {
+	tokens, err := parseTokens("cUSD=10, cEUR,CELO=2", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []faucetToken{
+		{Symbol: "CELO", Amount: 2},
+		{Symbol: "cUSD", Contract: "StableToken", Amount: 10},
+		{Symbol: "cEUR", Contract: "StableTokenEUR", Amount: 1},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("token count mismatch, have %d want %d", len(tokens), len(want))
+	}
+	for i, token := range tokens {
+		if *token != want[i] {
+			t.Errorf("token %d mismatch, have %+v want %+v", i, *token, want[i])
+		}
+	}
+	if tokens, err = parseTokens("", 1); err != nil || len(tokens) != 1 || !tokens[0].native() {
+		t.Errorf("empty token list should only hand out CELO, have %v (err %v)", tokens, err)
+	}
+	for _, spec := range []string{"cUSD,cUSD", "cXYZ", "cUSD=0", "cUSD=abc"} {
+		if _, err := parseTokens(spec, 1); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestTokenPayout(t *testing.T) {
+	token := faucetToken{Symbol: "cUSD", Amount: 2}
+	for tier, want := range []string{"2", "5", "12.5"} {
+		if have := token.label(uint(tier)); have != want+" cUSD" {
+			t.Errorf("tier %d label mismatch, have %q want %q", tier, have, want+" cUSD")
+		}
+		payout := new(big.Float).Quo(new(big.Float).SetInt(token.payout(uint(tier))), new(big.Float).SetInt(ether))
+		if have := payout.Text('f', -1); have != want {
+			t.Errorf("tier %d payout mismatch, have %s want %s", tier, have, want)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	var (
+		limiter = newRateLimiter()
+		now     = time.Now()
+		until   = now.Add(time.Hour)
+		alice   = common.HexToAddress("0x01")
+		bob     = common.HexToAddress("0x02")
+	)
+	limiter.limit("cUSD", "alice@twitter", alice, until)
+
+	if timeout, limited := limiter.until("cUSD", "alice@twitter", bob, now); !limited || !timeout.Equal(until) {
+		t.Errorf("user should be limited regardless of the address")
+	}
+	if _, limited := limiter.until("cUSD", "bob@twitter", alice, now); !limited {
+		t.Errorf("address should be limited regardless of the user")
+	}
+	if _, limited := limiter.until("CELO", "alice@twitter", alice, now); limited {
+		t.Errorf("other tokens should not be limited")
+	}
+	if _, limited := limiter.until("cUSD", "bob@twitter", bob, now); limited {
+		t.Errorf("other users and addresses should not be limited")
+	}
+	if _, limited := limiter.until("cUSD", "alice@twitter", alice, until.Add(time.Second)); limited {
+		t.Errorf("limit should expire")
+	}
+}
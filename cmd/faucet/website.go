@@ -1,6 +1,6 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// faucet.html (11.276kB)
+// faucet.html (11.635kB)
 
 package main
 
@@ -69,7 +69,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _faucetHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5a\x7b\x93\xdb\x36\x92\xff\x7b\xfc\x29\x3a\x3c\x7b\x25\x9d\x87\xa4\x66\xc6\xf6\xfa\x24\x52\x29\xaf\x37\xbb\xe7\xab\xbb\x24\x95\x38\x75\xb7\x95\x4d\x5d\x81\x64\x4b\x84\x07\x04\x18\x00\x94\x46\x99\xd2\x77\xbf\x6a\x80\xa4\xa8\xc7\x4c\xec\xb5\xaf\x6a\xfd\xc7\x98\xc4\xa3\xd1\x8f\x5f\xa3\x1f\x54\xf2\xd5\x9f\xbf\x7b\xfb\xfe\x6f\xdf\x7f\x03\xa5\xad\xc4\xe2\x49\x42\xff\x81\x60\x72\x95\x06\x28\x83\xc5\x93\x8b\xa4\x44\x56\x2c\x9e\x5c\x5c\x24\x15\x5a\x06\x79\xc9\xb4\x41\x9b\x06\x8d\x5d\x86\xaf\x83\xfd\x44\x69\x6d\x1d\xe2\xaf\x0d\x5f\xa7\xc1\xff\x84\x3f\xbd\x09\xdf\xaa\xaa\x66\x96\x67\x02\x03\xc8\x95\xb4\x28\x6d\x1a\xbc\xfb\x26\xc5\x62\x85\x83\x7d\x92\x55\x98\x06\x6b\x8e\x9b\x5a\x69\x3b\x58\xba\xe1\x85\x2d\xd3\x02\xd7\x3c\xc7\xd0\xbd\x5c\x02\x97\xdc\x72\x26\x42\x93\x33\x81\xe9\x55\xb0\x78\x42\x74\x2c\xb7\x02\x17\xf7\xf7\xd1\xb7\x68\x37\x4a\xdf\xee\x76\x33\x78\xd3\xd8\x12\xa5\xe5\x39\xb3\x58\xc0\x5f\x58\x93\xa3\x4d\x62\xbf\xd2\x6d\x12\x5c\xde\x42\xa9\x71\x99\x06\xc4\xba\x99\xc5\x71\x5e\xc8\x0f\x26\xca\x85\x6a\x8a\xa5\x60\x1a\xa3\x5c\x55\x31\xfb\xc0\xee\x62\xc1\x33\x13\xdb\x0d\xb7\x16\x75\x98\x29\x65\x8d\xd5\xac\x8e\x6f\xa2\x9b\xe8\x8f\x71\x6e\x4c\xdc\x8f\x45\x15\x97\x51\x6e\x4c\x00\x1a\x45\x1a\x18\xbb\x15\x68\x4a\x44\x1b\x40\xbc\xf8\xc7\xce\x5d\x2a\x69\x43\xb6\x41\xa3\x2a\x8c\x5f\x44\x7f\x8c\xa6\xee\xc8\xe1\xf0\xe3\xa7\xd2\xb1\x26\xd7\xbc\xb6\x60\x74\xfe\xd1\xe7\x7e\xf8\xb5\x41\xbd\x8d\x6f\xa2\xab\xe8\xaa\x7d\x71\xe7\x7c\x30\xc1\x22\x89\x3d\xc1\xc5\x67\xd1\x0e\xa5\xb2\xdb\xf8\x3a\x7a\x11\x5d\xc5\x35\xcb\x6f\xd9\x0a\x8b\xee\x24\x9a\x8a\xba\xc1\x2f\x76\xee\x43\x36\xfc\x70\x6c\xc2\x2f\x71\x58\xa5\x2a\x94\x36\xfa\x60\xe2\xeb\xe8\xea\x75\x34\xed\x06\x4e\xe9\xbb\x03\xc8\x68\x74\xd4\x45\xb4\x46\x4d\xc8\x15\x61\x8e\xd2\xa2\x86\x7b\x1a\xbd\xa8\xb8\x0c\x4b\xe4\xab\xd2\xce\xe0\x6a\x3a\x7d\x36\x3f\x37\xba\x2e\xfd\x70\xc1\x4d\x2d\xd8\x76\x06\x4b\x81\x77\x7e\x88\x09\xbe\x92\x21\xb7\x58\x99\x19\x78\xca\x6e\x62\xe7\xce\xac\xb5\x5a\x69\x34\xa6\x3d\xac\x56\x86\x5b\xae\xe4\x8c\x10\xc5\x2c\x5f\xe3\xb9\xb5\xa6\x66\xf2\x64\x03\xcb\x8c\x12\x8d\xc5\x23\x46\x32\xa1\xf2\x5b\x3f\xe6\xbc\x79\x28\x44\xae\x84\xd2\x33\xd8\x94\xbc\xdd\x06\xee\x20\xa8\x35\xb6\xe4\xa1\x66\x45\xc1\xe5\x6a\x06\xaf\xea\x56\x1e\xa8\x98\x5e\x71\x39\x83\xe9\x7e\x4b\x12\x77\x6a\x4c\x62\x7f\x71\x3d\xb9\x48\x32\x55\x6c\x9d\x0d\x0b\xbe\x86\x5c\x30\x63\xd2\xe0\x48\xc5\xee\x42\x3a\x58\x40\xf7\x10\xe3\xb2\x9b\x3a\x98\xd3\x6a\x13\x80\x3b\x28\x0d\x3c\x13\x61\xa6\xac\x55\xd5\x0c\xae\x88\xbd\x76\xcb\x11\x3d\x11\x8a\x55\x78\x75\xdd\x4d\x5e\x24\xe5\x55\x47\xc4\xe2\x9d\x0d\x9d\x7d\x7a\xcb\x04\x8b\x84\x77\x7b\x97\x0c\x96\x2c\xcc\x98\x2d\x03\x60\x9a\xb3\xb0\xe4\x45\x81\x32\x0d\xac\x6e\x90\x70\xc4\x17\x30\xbc\xfe\x1e\xb8\xfd\xca\xab\x8e\xaf\xb8\xe0\xeb\x56\xac\xc1\xe3\x91\x84\x0f\x0b\xf1\x1a\xda\x07\xb5\x5c\x1a\xb4\xe1\x40\xa6\xc1\x62\x2e\xeb\xc6\x86\x2b\xad\x9a\xba\x9f\xbf\x48\xdc\x28\xf0\x22\x0d\x1a\x2d\x82\xf6\xfa\x77\x8f\x76\x5b\xb7\xaa\x08\x7a\xc1\x95\xae\x42\xb2\x84\x56\x22\x80\x5a\xb0\x1c\x4b\x25\x0a\xd4\x69\xf0\xa3\xca\x39\x13\x20\xbd\xcc\xf0\xd3\x0f\xff\x09\xad\xc9\xb8\x5c\xc1\x56\x35\x1a\xbe\xb1\x25\x6a\x6c\x2a\x60\x45\x41\x70\x8d\xa2\x28\x88\xf7\x9c\x38\xf0\x9e\xf2\x1a\x66\x56\xee\xf9\xbd\x48\xb2\xc6\x5a\xd5\x2f\xcc\xac\x84\xcc\xca\xb0\xc0\x25\x6b\x84\x85\x42\xab\xba\x50\x1b\x19\x5a\xb5\x5a\x51\xa8\xf3\x52\xf8\x4d\x01\x14\xcc\xb2\x76\x2a\x0d\xba\xb5\x9d\x11\x99\xa9\x55\xdd\xd4\xad\x19\xfd\x20\xde\xd5\x4c\x16\x58\x90\xd1\x85\xc1\x60\xf1\x57\xbe\x46\xa8\xd0\x0b\x73\x71\x8c\x89\x9c\x69\xb4\xe1\x90\xe8\x09\x32\x92\xd8\x33\xe3\x45\x82\xf6\x5f\xd2\x88\x8e\x52\x2f\x42\x85\xb2\x81\x83\xb7\x50\xd3\xc5\x12\x2c\xee\xef\x35\x93\x2b\x84\xa7\xbc\xb8\xbb\x84\xa7\xac\x52\x8d\xb4\x30\x4b\x21\x7a\xe3\x1e\xcd\x6e\x77\x40\x1d\x20\x11\x7c\x91\xb0\xc7\xf0\x0d\x4a\xe6\x82\xe7\xb7\x69\x60\x39\xea\xf4\xfe\x9e\x88\xef\x76\x73\xb8\xbf\xe7\x4b\x78\x1a\xfd\x80\x39\xab\x6d\x5e\xb2\xdd\x6e\xa5\xbb\xe7\x08\xef\x30\x6f\x2c\x8e\x27\xf7\xf7\x28\x0c\xee\x76\xa6\xc9\x2a\x6e\xc7\xdd\x76\x1a\x97\xc5\x6e\x47\x3c\xb7\x7c\xee\x76\x10\x13\x51\x59\xe0\x1d\x3c\x8d\xbe\x47\xcd\x55\x61\xc0\xaf\x4f\x62\xb6\x48\x62\xc1\x17\xed\xbe\x43\x25\xc5\x8d\xd8\xe3\x25\x26\xc0\xf4\x40\x77\x7e\xe3\x58\x1d\x72\x7a\xc6\x0d\x56\x61\xcf\x7d\x8b\x07\xc3\x2d\xde\xe2\x36\x0d\xee\xef\x87\x7b\xdb\xd9\x9c\x09\x91\x31\xd2\x8b\x17\xad\xdf\xf4\x1b\x12\x4e\xd7\xdc\xb8\x9c\x6a\xd1\x71\xb0\x67\xfb\x23\xfd\xfa\xe8\xe6\xb2\xaa\x9e\xc1\xcd\xf5\xe0\xda\x3a\xe7\xf2\xaf\x8e\x5c\xfe\xe6\xec\xe2\x9a\x49\x14\xe0\xfe\x86\xa6\x62\xa2\x7b\x6e\xbd\x65\x70\x0d\x1c\x6f\x0a\xe9\x92\xee\x59\xeb\x2f\xfb\xe9\x1c\xd4\x1a\xf5\x52\xa8\xcd\x0c\x58\x63\xd5\x1c\x2a\x76\xd7\x07\xbc\x9b\xe9\x74\xc8\x37\xe5\x82\x2c\x13\xe8\xae\x17\x8d\xbf\x36\x68\xac\xe9\x2f\x13\x3f\xe5\xfe\xd2\x9d\x52\xa0\x34\x58\x1c\x69\x83\x4e\x24\xd5\xba\x55\x03\xd3\xf7\xca\x3c\xcb\xfb\x52\xa9\x3e\x86\x0c\xd9\x68\x49\x0f\xc2\x5d\xb0\x48\xac\xde\xaf\xbb\x48\x6c\xf1\x49\x31\x40\x53\x8e\xf7\x50\x08\xf0\x37\x1a\xc9\x5e\x23\x6a\x9f\x60\x10\x64\xc1\xbd\x26\xb1\x2d\x3e\xe3\x64\x02\x61\xc6\x0c\x7e\xcc\xf1\x2e\xd4\xef\x8f\x77\xaf\x9f\x7b\x7e\x89\x4c\xdb\x0c\x99\xfd\x18\x06\x96\x8d\x2c\x06\xf2\xbb\xbb\xf3\x73\x19\x68\x24\x5f\xa3\x36\xdc\x6e\x3f\x96\x03\x2c\xf6\x2c\xf8\xf7\x43\x16\x92\xd8\xea\xc7\xb1\x36\x7c\xf9\x42\xce\xfd\x7b\x39\xc9\xcd\xe2\xdf\xd5\x06\x0a\x85\x06\x6c\xc9\x0d\x50\x74\xfd\x3a\x89\xcb\x9b\x7e\x49\xbd\x78\x4f\x13\x4e\xa9\xb0\x74\xb9\x05\x70\x03\xba\x91\x2e\xf4\x2a\x09\xb6\xc4\xc3\x7c\xa4\x8d\xd2\x11\xbc\x57\x94\xd3\xad\x51\x5a\xa8\x98\xe0\x39\x57\x8d\x01\x96\x5b\xa5\x0d\x2c\xb5\xaa\x00\xef\x4a\xd6\x18\x4b\x84\xe8\xfa\x60\x6b\xc6\x85\xf3\x25\x67\x52\x50\x1a\x58\x9e\x37\x55\x43\x39\xa9\x5c\x01\x4a\xd5\xac\xca\x96\x17\xab\xc0\x07\x26\xa1\xe4\xaa\xe7\xc7\xd4\xac\x02\x66\x2d\xcb\x6f\xcd\x25\x74\xb7\x02\x30\x8d\x60\x39\x16\xb4\x2b\x57\x55\xa5\x24\xdc\xe8\x02\x6a\xa6\xed\x16\xcc\x61\x72\xc1\xf2\xdc\x45\xb9\x08\xde\xc8\xad\x92\x08\x25\x5b\x3b\x0e\xe1\xbd\xaf\x27\x88\xaf\xbf\xb0\x1c\x33\xa5\xfa\xd5\x50\xb1\x6d\x77\x5c\xcb\xfd\x86\xdb\x92\x7b\xf5\xd4\xa8\x2b\xda\x5a\x80\xe0\x15\xb7\x26\x4a\xe2\x7a\x7f\xa3\xee\x63\xb3\x08\x4b\xa5\xf9\x6f\x94\xd9\x88\xe1\xf5\x69\x8f\x2e\x97\xee\x6e\x74\x56\x17\xb8\xb4\x33\x78\xe1\xef\xc6\x63\x1c\xb7\x25\xd0\x39\x10\x77\x34\x5d\x69\x49\x01\x67\x06\x37\x3e\x9f\xf5\x89\x44\x61\x07\x1c\x14\x47\x50\xf3\x87\xbe\x7e\x5d\xdf\xf5\x7c\xf4\x49\xf1\xb4\x27\x42\x08\x38\x54\xca\x9a\xf7\x6a\xbc\x84\x8a\xdd\x22\x30\x48\xd8\x51\x89\xdc\x32\xed\x0a\x2c\xee\x1a\x04\xb1\xdd\x20\xda\xaf\xc9\x75\xd3\x1f\x3c\x41\x2e\x57\xcf\xae\xa7\x1e\x91\xf4\x40\xe4\x9f\x5d\x4f\xb9\xb4\xea\xd9\xf5\x74\x7a\x37\xfd\xc8\x7f\xcf\xae\xa7\x4a\x3e\xbb\x9e\xda\x12\x9f\x5d\x4f\x9f\x5d\xdf\x0c\xb1\xec\x47\xba\xd4\x92\x56\xa1\xa1\xd3\x3a\x88\x07\x60\x99\x5e\xa1\x4d\x83\xff\x65\x99\x6a\xec\x2c\x13\x4c\xde\x06\x0b\xc7\x2e\x65\x1b\x0e\x05\xe7\x13\x54\xa8\x99\x21\x48\x10\xc7\x0e\x25\x6d\x33\xc4\xc0\xd8\x34\x5a\xab\x46\x52\x54\x04\x92\xd9\x79\xa8\x1c\x11\xca\x48\x31\x93\x28\xc9\x74\xbc\x78\xab\xea\x6d\xe8\x88\xb8\xed\x27\x6a\x34\x4d\x5d\x2b\x6d\xa3\xa1\x3a\x19\x15\x42\x02\x4d\xfc\x7a\xfa\xf2\xf5\xab\x47\xd9\x37\x94\x66\x3b\x19\x7a\x0e\x59\xa6\xd6\x08\x3e\xa9\xcf\xd4\x1d\x30\x59\xc0\x92\x6b\x04\xb6\x61\xdb\xaf\x92\xb8\x70\x25\xd8\xe7\xa3\x76\xd9\x7a\xd7\x3f\x15\x6c\x3b\x97\xbf\x84\xba\xc9\x04\x37\x25\x30\x90\xb8\x81\xc4\x58\xad\xe4\x6a\xe1\x46\x73\xaa\x49\xdd\x2b\xd4\xca\xd8\xc7\xcc\x8f\x55\x86\x45\x71\x06\x00\x5f\xca\xfe\x9b\xcd\x26\xea\x34\xe9\x8c\x5f\xa2\xa8\x63\xba\xfe\x1a\xc9\xed\x36\xf6\x6e\xa4\x64\xfc\x35\x2f\xd2\xeb\xd7\xd7\xaf\x5e\x5d\xbf\xf8\xb7\xd7\x2f\x5f\x5e\xbf\x7e\xf1\xf2\x21\x64\x90\x50\x9f\x09\x0c\x9f\x46\x7f\xab\xa8\x6c\xed\x73\x68\x8f\x97\x2e\x77\xa3\x08\x5d\x50\x0d\xa2\x83\x7f\x18\x43\x8d\xa4\x44\x24\x64\xe2\x6c\x0e\xf1\x09\x28\x72\x30\x7a\x84\xb3\xcf\x84\x56\x07\x1f\x42\x8a\x6a\x2c\x49\xd8\x55\xf3\x5c\xc9\x1e\x4e\x97\x60\x78\x55\x8b\x2d\xe4\x7b\xab\x9f\xc7\xd5\x83\x46\xf9\x5d\x58\x1d\x9a\xcd\x83\xcc\x45\xff\x4a\x15\x48\x51\xdf\x34\x26\xc7\xda\xb5\x79\x29\x92\xfe\x69\xfb\x1b\x93\x96\x4b\xec\x22\x6e\x04\xdf\x49\xb1\x85\xc6\x20\x2c\x95\x86\x02\xb3\x66\xb5\x72\x69\x82\x86\x5a\xf3\x35\xb3\xd8\x85\x59\xd3\xa2\xa2\x07\xc5\xa0\xb2\xa1\x94\x47\x0c\x32\x90\xbf\xa9\x06\x72\x26\xc1\x6a\x96\xdf\x7a\x4f\x69\xb4\x26\x4f\xa9\xd1\x4b\xd3\x07\xfa\x0c\x85\xda\xb8\x25\x5e\xee\x25\x47\xe1\xa2\xbe\x41\x84\x52\x6d\xa0\x6a\x72\xe7\x90\x14\xd5\x9d\x10\x1b\xc6\x2d\x34\xd2\x72\xe1\xf5\x69\x1b\x2d\x29\x47\xc0\x83\x28\x7d\x52\xfb\x25\x58\x2d\xde\x97\x78\x26\x25\xea\xab\x36\xd0\xf8\xd6\x2f\x87\x5a\x2b\x8b\x39\x19\x14\xd8\x8a\x71\x69\xc8\x22\x2e\x0f\xc0\xea\x23\xaa\xba\xfe\xa9\x7d\xd8\xb7\x28\xdd\x74\x1c\xc3\x5f\x85\xca\x98\x80\x35\x21\x3d\x13\x94\xce\x29\x28\x15\x89\x3e\xd0\x96\xb1\xcc\x36\x06\xd4\xd2\x8d\x7a\xce\x69\xff\x9a\x69\xb2\x20\x56\xb5\x85\xb4\x6d\xb0\xd1\x98\x41\xbd\x6e\xdb\x86\xf4\x4a\x95\xfb\xc1\x7c\xaf\xf5\x14\x7e\xfe\x65\xfe\xa4\x65\xe5\xcf\xb8\x74\x90\x20\x7c\x7b\x91\x6d\xc9\x2c\xe4\x1a\x99\x45\x03\xb9\x50\xa6\xd1\x9e\xc3\x42\xab\x1a\x88\xcb\x8e\x52\x47\x99\x26\x6a\x77\x5a\x47\x64\x5c\x32\x53\x4e\xda\xfe\xa0\x46\x67\xa5\x7e\xae\x1b\xbf\x20\xd4\x8d\x89\x00\x4f\xa7\x73\xe0\x49\x47\x37\x12\x28\x57\xb6\x9c\x03\x7f\xfe\xbc\x5f\x7c\xc1\x97\x30\xee\x56\xfc\xcc\x7f\x89\xec\x5d\x44\xa7\x40\x9a\xc2\xf0\x34\x77\x60\x4b\xc7\xd4\x82\xe7\x38\xe6\x97\x70\x35\x99\x77\xb3\x99\x46\x76\xdb\xbd\xb5\x76\xf4\xff\xb9\xbf\xbb\xf9\xa1\x66\x9c\xf2\x0f\x74\xe3\x6b\x7f\x03\x0c\x56\xdc\x58\x68\xb4\x80\xd6\x87\xbd\x09\x7a\x83\xb8\x75\x43\xad\x9c\xe0\xb2\x7d\x68\x31\xd5\x89\xe0\xc9\x44\x06\x65\x31\xfe\x8f\x1f\xbf\xfb\x36\x32\x56\x73\xb9\xe2\xcb\xed\xf8\xbe\xd1\x62\x06\x4f\xc7\xc1\xbf\x34\x5a\x04\x93\x9f\xa7\xbf\x44\x6b\x26\x1a\xbc\x74\xf6\x9e\xb9\xbf\x27\xa7\x5c\x42\xfb\x38\x83\xc3\x03\x77\x93\xc9\xfc\x7c\x9f\x64\xd0\xd6\xd1\x68\xd0\x8e\x69\x61\x0f\xfc\x63\x1d\x31\xa8\xd0\x96\xca\xb9\xae\xc6\x5c\x49\x89\xb9\x85\xa6\x56\xb2\x55\x09\x08\x65\xcc\x1e\x88\xdd\x8a\xf4\x14\x14\xed\xfa\xd4\x05\xeb\xff\xc6\xec\x47\x95\xdf\xa2\x1d\x8f\xc7\x1b\x2e\x0b\xb5\x89\x84\xf2\x57\x6d\x44\x4e\xaa\x72\x25\x20\x4d\x53\x68\xa3\x68\x30\x81\xaf\x21\xd8\x18\x8a\xa7\x01\xcc\xe8\x91\x9e\x26\xf0\x1c\x8e\xb7\x97\x14\xef\x9f\x43\x10\xb3\x9a\x07\x13\xef\x0e\x9d\xe2\x95\xac\xd0\x18\xb6\xc2\x21\x83\xae\x32\xea\x41\x46\x72\x54\x66\x05\x29\x38\x03\xd5\x4c\x1b\xf4\x4b\x22\xaa\xc6\x3b\xb4\x11\x66\xdd\xb2\x34\x05\xd9\x08\xb1\x07\xa9\x77\x8a\x79\x07\xbf\x83\xe5\x91\x8f\x35\x5f\xa5\x29\x50\x69\x4a\x2a\x2e\xf6\x3b\xc9\xf8\xbe\x88\x9e\x44\x14\x17\xf6\x3b\x26\xf3\x21\x9a\x0f\xa8\x61\xf1\x7b\xe4\xb0\x38\xa6\x87\xc5\x03\x04\x5d\xcf\xe2\x31\x7a\xbe\xc7\x31\x20\xe7\x06\x1e\xa0\x26\x9b\x2a\x43\xfd\x18\x39\xdf\xb3\x68\xc9\x39\x55\xbf\x93\x76\xb0\xf7\x12\xae\x5e\x4d\x1e\xa0\x8e\x5a\xab\x07\x89\x4b\x65\xb7\xe3\x7b\xc1\xb6\x94\x33\xc1\xc8\xaa\xfa\xad\x6b\x31\x8c\x2e\x5d\xc4\x9d\x41\x4f\xe1\xd2\x35\x8f\x67\x30\x72\x6f\x34\xcf\x2b\x74\xbb\x5e\x4e\xa7\xd3\x4b\xe8\x3e\xbb\xfc\x89\x91\x13\xea\x06\x77\x0f\xf0\x63\x9a\x3c\xa7\xb8\xff\x39\x1c\xb5\x34\x7a\x9e\xda\xf7\xcf\xe0\xaa\x8f\x0d\x07\x6c\xc1\x1f\xfe\x00\x27\xb3\x87\x30\x8e\x63\xf8\x2f\x46\x65\xb8\x10\xae\x7b\xe0\x9a\x06\xfd\xfa\x8a\x1b\xe3\x8a\x71\x03\x85\x92\xd8\xee\xf9\xb4\x6b\xff\x84\xc7\x76\x19\x2c\x60\x7a\xcc\x20\x5d\x87\x83\xb0\x70\x26\x5a\x0c\xe8\x1e\x06\x82\x8b\xdd\xf0\xbc\x83\x9d\xbc\x42\xf8\x2a\x85\x20\x18\x6e\x3e\x59\x41\x0b\x7a\x62\x17\x06\xed\x7b\x6f\x8b\x71\x1b\x1d\xcf\xc5\xae\xc9\x25\xdc\x4c\xa7\xd3\xc9\x09\x13\xbb\xbd\x7a\xdf\xd4\x94\x36\x01\x93\x5b\x77\x25\xf6\xba\x75\x89\x23\xa5\x40\x74\xa5\x09\xc8\x95\x10\x3e\x67\x69\xb7\x92\x82\xdb\xe6\x49\x0a\xe1\xd5\xfc\x4c\x14\x1d\x68\x72\x20\xda\xb1\x79\xce\xe8\xfe\xd8\x44\x87\x3a\x3b\x5a\x1c\x5e\x1d\x18\xe5\xc0\x5e\xe7\x0d\x73\xd1\xf3\xcd\xf7\x1a\x3d\x32\xd7\xde\x5e\xc7\x3a\x1b\xf0\xef\xe9\x3c\xbf\xfa\x48\x31\xfa\xe9\xba\x31\xe5\xf8\x88\xd1\xc9\xfc\xd4\x36\xef\x2c\x6a\xca\x92\x15\x85\x2c\xb2\x05\x95\x02\x1a\x4f\x4c\xe2\x52\x75\x8d\xa1\x46\x59\xa0\xee\x52\x0a\x9f\xd9\x53\x02\x78\x60\x32\x5f\x55\x0e\xe1\x34\x90\xe8\x44\xb7\x73\xe0\xb0\xa0\x34\x0f\x78\x18\x0e\x64\x71\x79\x99\x92\x08\x00\x70\xe4\x09\x0e\xad\x07\x70\xa5\xc5\x28\x58\x6d\xb0\x80\x14\xfc\xa7\xf0\xf1\x24\x6a\x24\xbf\x1b\x4f\xc2\xf6\xfd\x98\x46\x37\x3f\xef\x6b\xc5\x8e\xf7\xe7\x29\x04\x89\xd5\xc0\x8b\x74\x14\xc0\xf3\x73\x7e\x48\xa1\x77\xb4\xd8\x73\x30\xdc\x0a\x90\xd8\x62\xe1\x9a\xa1\xbe\x68\xfb\x7b\x90\xb1\xfc\x76\xe5\xaa\xa1\x19\xe5\x5b\xe3\x13\xb2\x6c\xcd\x2c\xd3\x8e\xea\x64\x0e\xfb\xe5\x6d\xb5\x98\x93\x85\xe6\xe0\xcb\x52\xd7\x73\x85\xfe\x3b\x85\x7b\xcb\x94\x2e\x50\x87\x9a\x15\xbc\x31\x33\x78\x51\xdf\xcd\xff\xde\x7d\xc7\x71\x9d\xe1\x47\x59\xad\x35\x2e\x4e\x38\x6a\x5b\x8d\xcf\x21\x48\x62\x5a\xf0\x7b\x64\x7a\x61\x87\x9f\xe0\xe1\x4c\xff\x1b\xfa\x0f\xe4\xed\x78\xc5\x8b\x42\x20\x31\xbc\x27\x4f\x1e\x49\xf6\x1f\xfa\xd5\xe1\x91\xd0\x36\xbe\xf7\x7b\x76\x80\xc2\xe0\x23\x1b\xfa\x1e\xfa\x88\x00\x10\x92\xc8\xdc\xe9\xbc\xad\xb8\xdd\xb0\x1e\x39\x5d\xb4\x3f\xa8\x28\x1a\xed\x12\xae\x71\xd8\x02\xec\x12\x46\x86\x12\xc0\xc2\x8c\x26\x51\xd9\x54\x4c\xf2\xdf\x70\x4c\xc1\x69\xe2\x75\xe5\x9a\xf2\xc1\xe9\xbd\x7c\xc2\xcc\xbe\x5b\x3e\xea\x02\xdd\xa8\x55\xe2\xa8\xb3\xee\x8b\x7d\x81\x3f\x83\xe9\x7c\xf4\x89\x1a\x3a\x7f\x4a\x98\x31\x0d\xc3\x97\xb0\x8b\xc0\xa0\x15\x9d\xde\xcd\x65\x4c\x8f\x7c\x3b\xc3\x25\xe9\x52\x6d\xd2\xd1\xcd\xb4\x67\xd2\x1b\xda\xd9\x79\xd4\x62\xed\xc4\x18\xc4\x65\xe7\x9a\x0b\xb8\x99\x7e\x09\x6e\x7d\x4b\xe4\x48\x02\xab\x79\x8d\x05\xb0\xdc\xf2\x35\xfe\x3f\x08\xf2\x05\x94\xfc\xc9\x2c\x12\x0e\x3b\xe5\x39\x98\x1e\xf0\x4b\xb3\xbd\x6e\xff\x95\xfc\x0d\x62\xa7\xe1\xe7\x10\x9c\x15\xe4\x41\x24\x1e\x2d\x3c\x72\xed\x87\xfd\xde\x7d\x65\x0a\x8e\x03\x0b\xa5\xbc\xfd\x17\xd2\x49\x54\xda\x4a\x8c\x83\xc4\xba\x9f\xca\x10\xcf\x3d\x05\x47\xc0\x0f\x1f\xe6\x75\xbb\xc3\x6a\x86\x8a\x78\x3c\x2a\xb6\x60\x90\xa1\xf4\x05\x59\x97\x8e\xc0\x6e\xff\x8b\xa2\x38\x86\x1f\x2d\xd3\x16\x18\xfc\xf4\x0e\x9a\xba\x60\xd6\x7f\xcf\xa1\x20\xe9\xbf\x97\x74\x3f\x39\xca\x98\x36\xb0\x54\x7a\xc3\x74\xd1\x36\x69\x6c\x89\x5b\xf7\x3d\xa7\xcb\xff\x0c\xda\x77\x74\x8b\xad\x99\x18\x9f\x14\x7f\x4f\xc7\xa3\x68\x68\xf2\xd1\x24\x42\x96\x97\xa7\x0b\x5d\xc4\xea\xcf\x4d\xe1\x5b\x57\x07\x8c\x9f\x8e\x6d\xc9\xcd\x24\x62\xd6\xea\xf1\xe8\x00\x0c\xa3\x09\xd9\xf5\x6a\x50\x97\xf5\xdb\x93\x03\xb7\x7a\x8c\xc6\x3e\xa3\xee\xb3\x81\x6e\x79\x6e\xcc\xd8\xe3\x6a\x74\x39\xa0\x7d\x08\xab\xd1\xb3\x51\x6f\xa8\xbd\x7b\xef\xe5\x48\xcf\x72\x72\x40\x7a\x44\x5e\x36\x3a\x39\x9e\x15\xc5\x5b\xf2\x9f\x71\x70\xc6\xd3\x8f\xd1\x31\xe9\x95\xed\xef\xeb\x47\xb5\xec\x7f\x9b\xf1\x80\x8a\x79\x31\x9a\x44\xa6\xc9\x7c\x83\x62\xfc\xb2\xaf\xc2\xba\x65\x0e\xbc\xc7\xa1\xe0\x24\xa1\xa0\x23\x0e\x93\x8a\xf0\x28\x09\x79\x24\x6a\xb4\x47\x7a\xa9\x76\x97\xa4\xf0\xe9\xa4\xef\x6f\x7d\x63\x28\xc3\xf2\xfd\xff\x0d\x66\xc6\xb5\x13\xa0\xc5\xbb\x6b\xe9\xf8\xd6\xcd\x9b\xef\xdf\x0d\xda\x37\xbd\x47\x8c\x1d\xf5\xfe\xd7\x80\xe7\x9a\x25\x67\x7f\x7e\xb8\xd9\x6c\xa2\x95\x52\x2b\xe1\x7f\x78\xd8\x77\x53\x62\x56\xf3\xe8\x83\x09\x80\x99\xad\xcc\xa1\xc0\x25\xea\xc5\x80\x7c\xdb\x62\x49\x62\xff\xc3\xb8\x24\xf6\xbf\xfd\xfd\xbf\x00\x00\x00\xff\xff\xb2\x1e\x6f\x68\x0c\x2c\x00\x00")
+var _faucetHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcd\x1a\xfd\x73\xdb\xb6\xf5\x67\xf7\xaf\x40\xb9\xa4\x92\x16\x93\x94\xed\x24\xf5\xf4\x95\x4b\xd3\xb4\xcb\xae\x4b\x7a\x4d\x7a\x5b\xaf\xeb\xed\x20\x12\x92\x10\x93\x04\x0b\x80\x92\x55\x9f\xfe\xf7\xbd\x07\x80\x10\x49\xc9\x4e\x32\x67\x77\xcb\x5d\x2c\x92\x78\x78\xdf\x78\x5f\xe4\xe4\xcb\x6f\xdf\xbc\x78\xf7\xcb\x8f\x2f\xc9\x4a\xe7\xd9\xec\x8b\x09\xfe\x90\x8c\x16\xcb\x69\xc0\x8a\x60\xf6\xc5\xc9\x64\xc5\x68\x0a\xbf\x27\x93\x9c\x69\x4a\x92\x15\x95\x8a\xe9\x69\x50\xe9\x45\x78\x19\xec\x17\x56\x5a\x97\x21\xfb\xbd\xe2\xeb\x69\xf0\xcf\xf0\xe7\xe7\xe1\x0b\x91\x97\x54\xf3\x79\xc6\x02\x92\x88\x42\xb3\x02\x76\xbd\x7a\x39\x65\xe9\x92\x35\xf6\x15\x34\x67\xd3\x60\xcd\xd9\xa6\x14\x52\x37\x40\x37\x3c\xd5\xab\x69\xca\xd6\x3c\x61\xa1\xb9\x39\x25\xbc\xe0\x9a\xd3\x2c\x54\x09\xcd\xd8\xf4\x0c\xd0\x20\x1e\xcd\x75\xc6\x66\x37\x37\xd1\x6b\xa6\x37\x42\x5e\xed\x76\x23\xf2\xbc\xd2\x2b\x40\xc3\x13\xaa\x59\x4a\xbe\xa3\x55\xc2\xf4\x24\xb6\x90\x66\x53\xc6\x8b\x2b\xb2\x92\x6c\x31\x0d\x90\x75\x35\x8a\xe3\x24\x2d\xde\xab\x28\xc9\x44\x95\x2e\x32\x2a\x59\x94\x88\x3c\xa6\xef\xe9\x75\x9c\xf1\xb9\x8a\xf5\x86\x6b\xcd\x64\x38\x17\x42\x2b\x2d\x69\x19\x5f\x44\x17\xd1\xd7\x71\xa2\x54\xec\x9f\x45\x39\x2f\x22\x78\x12\x10\xc9\xb2\x69\xa0\xf4\x36\x63\x6a\xc5\x18\x48\x16\xcf\xfe\x3b\xba\x0b\xd0\x48\x48\x37\x4c\x89\x9c\xc5\x8f\xa3\xaf\xa3\xa1\x21\xd9\x7c\x7c\x37\x55\x24\xab\x12\xc9\x4b\x4d\x94\x4c\x3e\x9a\xee\xfb\xdf\x2b\x26\xb7\x20\xe4\x59\x74\xe6\x6e\x0c\x9d\xf7\x2a\x98\x4d\x62\x8b\x70\x76\x2f\xdc\x61\x21\xf4\x36\x3e\x8f\x1e\x03\x81\x92\x26\x57\x74\xc9\xd2\x9a\x12\x2e\x45\xf5\xc3\xcf\x46\xf7\x36\x1b\xbe\xef\x9a\xf0\x73\x10\xcb\xc1\x32\x85\x06\x54\x20\xe2\xd9\x25\x98\xcd\x3d\x38\xc4\x6f\x08\xa0\xd1\x90\xd4\x49\xb4\x66\x12\x3d\x37\x0b\x13\x00\x67\x92\xdc\xe0\xd3\x13\xd8\x16\xae\x18\x5f\xae\xf4\x88\x9c\x0d\x87\x0f\xc7\xc7\x9e\xae\x57\xf6\x71\xca\x55\x99\xd1\xed\x88\x2c\x32\x76\x6d\x1f\xd1\x8c\x2f\x8b\x90\x6b\x96\xab\x11\xb1\x98\xcd\xc2\xce\xd0\x2c\xa5\x58\x4a\xa6\x94\x23\x56\x0a\x05\x47\x4d\x14\x23\xf4\x28\x38\xc6\x6b\x76\x0c\x56\x95\xb4\x38\xd8\x40\xe7\x4a\x64\x95\x66\x1d\x46\xe6\x99\x48\xae\xec\x33\x73\x9a\x9b\x42\x24\x22\x13\x72\x44\x36\x2b\xee\xb6\x11\x43\x88\x94\x92\x39\xf4\xa4\xa4\x69\xca\x8b\xe5\x88\x3c\x2d\x9d\x3c\x24\xa7\x72\xc9\x81\xe0\x70\xbf\x05\x54\xea\xd4\x38\x89\x6d\xe0\x82\xab\xb9\x48\xb7\xc6\x86\x29\x5f\x93\x24\xa3\x4a\x41\xc0\x69\xab\xd8\x04\xa4\x16\x00\xc6\x21\xca\x8b\x7a\xa9\xb5\x26\xc5\x26\x20\x86\xd0\x34\xb0\x4c\x80\x43\x69\x2d\x72\x90\x09\xd9\x73\x5b\x3a\xf8\xb2\x30\x5b\x86\x67\xe7\xf5\x22\x44\xd6\xb3\x1a\x89\x66\xd7\x70\x96\xd1\x3e\xde\x32\xe0\x1e\xbc\xde\xbb\xa0\x64\x41\xc3\x39\xd5\xab\x80\x50\xc9\x69\xb8\xe2\x69\xca\x0a\xd8\x27\x2b\x86\x7e\xc4\x67\xa4\x19\xfe\x6e\x89\x7e\xab\xb3\x9a\xaf\x18\x18\x73\x62\x35\x2e\x3b\x12\xde\x2e\xc4\x25\x71\x17\x62\xb1\x80\x64\x10\x36\x64\x6a\x00\xf3\xa2\xac\x74\xb8\x94\xa2\x2a\xfd\xfa\xc9\xc4\x3c\x25\x3c\x85\x0c\x22\xb3\xc0\x85\x7f\x73\xa9\xb7\xa5\x53\x45\xe0\x05\x17\x32\x0f\xd1\x12\x52\x00\x00\xf8\x51\xc2\x56\x22\x4b\x99\x9c\x06\x6f\x45\x02\x99\x80\x14\x56\x66\xf2\xf3\x4f\x3f\x10\x67\x32\xf0\x12\xb2\x15\x95\x24\x2f\x58\x26\x08\xb8\x0d\xba\x6a\x14\x45\x41\xbc\xe7\xc2\x38\xee\x21\x9f\xe1\x5c\x17\x7b\x5e\xc1\x73\x2a\xb0\xaa\x07\x84\x45\x02\xff\xc3\x94\x2d\x68\x95\x69\x92\x4a\x51\xa6\x62\x53\x84\x5a\x2c\x97\x98\xe6\xac\x04\x76\x53\x40\x52\xaa\xa9\x5b\x9a\x06\x35\x6c\x6d\x40\xaa\x4a\x51\x56\xa5\x33\xa1\x7d\xc8\xae\x81\xab\x94\xa5\x68\xf0\x4c\x81\x61\xbf\x87\x63\x47\x72\x46\x16\x55\x91\xaa\x93\xae\x3f\x24\x10\x71\x74\xd8\x44\x7a\xe0\x15\x93\xd8\x32\x63\x45\x22\xee\xdf\xa4\xca\x6a\x4c\x5e\x04\x08\x4c\x15\x69\xdd\x85\x12\x83\x4a\x00\x79\x55\x42\x39\xc0\xc8\x03\xcd\xd3\xeb\x53\xf8\x11\x57\xac\x20\xa3\x29\x89\xde\xe1\x95\xda\xed\x6e\x6e\xf8\xc2\x2e\xef\x76\x2d\x42\x40\x2a\xe3\x04\x6c\x07\x0a\x50\xac\xa4\x92\x6a\x21\xbd\x71\xc1\x55\x78\x8a\xe7\x6b\x02\xe1\x12\xc8\xb0\x22\x3d\xba\xbf\xcb\x2a\x9e\x6b\xd8\x76\xe7\xd9\xb9\xb9\xb1\x7c\x46\x6f\xb7\xf9\x5c\x64\xbb\x9d\xa3\xe1\x44\xb1\x92\xd0\x5c\x54\x85\x46\x51\x1c\xf0\x73\xf3\x40\x1d\xe3\x62\x36\xa1\x77\x11\x24\xa2\x48\x32\x9e\x5c\xc1\x2a\x62\x9a\x1e\xd0\x1f\x13\xcd\xc1\x6d\xe1\xb9\x51\xd3\x98\x58\xa5\x45\x3f\xb1\x84\x96\x1a\xaa\xaa\xdd\x0e\x42\xaa\xbb\x8e\xd8\x35\x4b\x20\x82\xf6\x07\xa0\x15\xf0\x84\xdd\x4e\x55\xf3\x9c\xeb\x7e\xbd\x7d\xe0\xb4\x65\x04\xb5\x62\xc0\xa9\x8f\x11\x29\x38\xd0\x35\xe0\xfd\x91\x49\x2e\x52\x45\x2c\xfc\x24\xa6\x2d\x2d\x1f\x53\xf6\x24\xae\xb2\xfd\x01\x89\xf1\x84\xf8\x53\x6d\x82\x84\xe1\xb8\xc9\xf0\x91\x33\xbf\x0c\xbd\x10\xee\x00\x40\x56\x60\x57\x6c\x3b\x0d\x20\x40\x35\xf6\xba\x55\x88\xc0\xd9\x9c\xa2\xde\xac\x84\x7e\xd3\x1f\x0c\x0f\xe6\x9a\x2b\x53\x40\xce\x6a\x0e\xf6\x6c\x7f\x64\x10\xeb\x84\x69\x2d\xca\x11\xb9\x38\x6f\xc4\xe8\x63\xf1\xed\x69\x27\xbe\x5d\x1c\x05\x06\x05\xb1\x8c\x98\xbf\xa1\xca\x41\x10\x77\xed\xc2\x43\x23\xe6\x75\x37\x85\x98\x91\x3c\x6b\x3e\xb3\x0d\xc7\x44\x40\x5e\x5a\x64\x62\x03\x59\xb4\xd2\x62\x0c\x29\xee\xda\x67\xf7\x8b\xe1\xb0\xc9\x37\x16\xbe\x14\x94\x63\x62\xa9\x84\xca\x9b\x29\xad\xfc\xe1\xb2\x4b\xe6\x2f\x06\x50\x88\x0b\x8a\xa5\x1d\x6d\x20\x45\x54\xad\x81\x6a\x98\xde\x2b\xf3\x28\xef\x0b\xa8\x93\x7c\x56\x6c\xb0\xe1\x50\x37\x72\x3b\xe0\xd6\x72\x0f\x07\x80\xe9\x27\x25\x3c\x89\x05\xed\x6d\xf9\xce\x86\x70\x94\xbd\x64\x4c\xda\x6a\x0a\x5d\x96\x98\x5b\x10\x2a\xbd\x07\x65\x74\xc2\x39\x55\xec\x63\xc8\x9b\xba\x66\x4f\xde\xdc\xde\x97\x3e\x84\x38\xa9\xe7\x8c\xea\x8f\x61\xc0\x64\x07\xcf\xc0\x3d\x29\x57\x05\xe4\x1c\x09\x87\x76\xfb\xb1\xa4\xc1\xad\xbc\xf0\xf6\xbe\xcd\x02\xdc\xc9\xbb\x9d\xac\x79\xf3\x99\x4e\xf5\x87\x2a\xaf\x8b\xd9\x5f\xc5\x86\xa4\x82\x29\xa2\x57\x5c\x11\xac\x21\x9e\x41\x85\x74\xe1\x41\xca\xd9\x3b\x5c\x58\x98\xda\x89\xc0\x95\xac\x0a\x53\x5a\x40\x3d\x00\xc5\x55\xbb\xde\x72\x55\x48\x44\xde\x09\xac\x59\xd7\xa0\x5d\x38\xba\x90\x10\xb8\xa8\x14\xa1\x09\xa4\x3d\x40\x25\x45\x4e\xd8\xf5\x8a\x56\x4a\x23\x22\x8c\x18\x74\x4d\x79\x66\x8e\x8f\xb1\x22\x11\x12\xa0\x93\x2a\xaf\xb0\xe6\x06\x18\x56\x88\x6a\xb9\x72\x8b\x5a\x10\x9b\xb1\x32\x01\x4b\x35\x3f\xa0\xf9\x9c\x50\xad\x21\x8a\xaa\x53\x52\x07\x02\x30\x1e\xc3\x94\x93\xe2\x2e\x68\x4b\x72\x60\xfb\x42\xa6\x10\xa2\xa4\xde\x12\xd5\x2e\x9e\x80\xa4\x49\x7c\x11\x79\x5e\x6c\x45\xc1\xc8\x8a\xae\x0d\x87\xe4\x9d\xed\x97\x90\xaf\xef\xa0\xf8\x82\x1e\xc9\x43\x83\x80\xdb\x9a\x9c\x63\x10\x80\x57\xdc\xaa\xa7\x64\x32\xc7\xad\x29\xc9\x38\x5c\xa8\x68\x12\x97\xfb\x20\xba\xaf\x3f\xb2\x70\x25\x24\xff\x03\x2b\xb7\xac\x19\x31\x75\x27\x9e\xd4\xe1\xd0\xd8\x3b\x63\x0b\x88\x87\x8f\x6d\x38\xec\x7a\xb0\x6b\xf1\x8e\xb9\x6f\x8d\xd3\xb4\xce\x98\x63\xc0\x6b\x6c\xbd\x6e\x8b\xa5\x54\x37\x38\x48\x3b\x4e\x66\x89\x5e\x5e\x02\x3c\xe9\x16\xfd\x43\x8f\x04\x3d\xa0\xad\x94\x35\xf7\x6a\x3c\x85\x8d\x57\x0c\xd4\x0a\xc5\x44\x7b\x04\xe0\x98\x36\x0d\x24\x37\x03\x10\x78\x04\xfd\xfb\x33\x3c\xb4\xd3\x9f\x2c\x42\x30\xc9\xc3\xf3\xa1\xf5\x48\xbc\x40\xf4\xf0\x0b\xf0\x02\x7e\x86\xd7\xc3\x8f\xfc\x07\xc0\xa2\x80\x3f\x60\x27\xf8\xfb\xf0\xfc\xa2\xe9\xcb\xf6\x09\x96\xce\x08\x01\x54\xe1\xa7\x76\x6f\xa8\x70\x41\x70\x9c\xfe\xfc\x9b\xce\x45\xa5\x47\xf3\x8c\x16\x10\xfb\x0c\xab\x58\x63\x18\x0f\x38\x2c\xbe\xc1\xeb\x14\xba\x02\x72\x6a\xbc\xc3\x0d\x79\x14\xe9\xab\x4a\x42\xe1\x5d\x60\x02\x24\x28\xab\x39\x93\x45\x0f\xbd\x0b\x15\x32\x88\x26\x73\x19\xcf\x5e\x88\x72\x1b\x1a\x24\x66\xfb\x81\xfa\x54\x55\xe2\xf4\x28\x6a\xaa\x91\x62\x83\x97\x31\x15\x5f\x0e\x9f\x5c\x3e\xbd\x93\x75\x85\xed\x83\xe1\xdf\x73\x08\x40\x50\x7b\xdb\x66\x65\x2e\xae\x09\x14\xe6\x64\xc1\xe1\x4c\xd1\x0d\xdd\x7e\x09\xae\x62\x5a\xcb\xfb\x7b\xeb\xc2\x9d\xaa\xff\x2b\x77\xad\x8f\xfa\x29\x29\xab\x79\xc6\xd5\x0a\x5c\xb6\x60\x1b\x08\xfc\xd0\x88\x15\xcb\x99\x79\x9a\x60\xaf\x6d\x6e\x09\xf4\xfe\xfa\x36\xd3\xb3\x7c\xce\x40\xa6\x43\xe3\x7f\x2e\xdb\x6f\x36\x9b\xa8\xd6\xa2\x31\xfc\x8a\x65\x65\x8c\x21\x0f\x72\x99\xde\xc6\xf6\xe8\x88\x22\x7e\x06\x19\xeb\xfc\xf2\xfc\xe9\xd3\xf3\xc7\x7f\xb9\x7c\xf2\xe4\xfc\xf2\xf1\x93\xdb\xbc\x02\x05\xba\xa7\x53\xd8\x6a\xf9\xb5\xc0\x56\xdc\x97\xca\xd6\x57\xea\x12\x0d\xf3\x71\x8a\x0d\xc9\xbe\x99\xf9\x64\xff\xa9\x0a\xac\x37\x20\xad\xeb\x7b\x7a\x90\x71\xa1\x3b\x38\xbb\xa7\x5b\xd5\xae\x83\x5e\x02\x8a\x46\x09\xeb\x09\x05\xd8\xc6\xbb\xd2\x29\x51\x3c\x2f\xb3\x2d\x38\x89\xb7\xfa\xa1\x4f\xdd\x6a\x90\x0f\xba\x54\xdb\x64\xd6\xc1\x4c\x9e\xcf\x45\xca\x30\xcb\xab\x4a\x25\xac\x34\x63\x6b\xcc\x9c\xdf\x6c\xff\xa0\xc0\x25\xa4\x44\x97\x61\x23\xf2\xa6\x00\xf6\x2a\x05\x19\x1b\x92\x62\xca\xe6\xd5\x72\x69\xca\x02\x09\x99\x9f\xaf\x29\x30\xec\xc2\xa5\x72\x1e\xe1\x1d\xa2\xd1\xbc\x60\x71\x93\x35\x6a\x8d\x5f\x44\x45\x12\x28\xaa\xb4\x04\x22\xf6\x94\x80\x24\x78\x4a\x4a\x66\xa5\xf1\x89\x7d\x0e\x9a\xd8\x18\x10\x2b\xf7\x82\xb3\xcc\x64\x79\xc5\x20\x71\xc3\x52\x5e\x25\xe6\x20\x62\x16\x37\x42\x6c\x28\xd7\x04\x32\x36\xcf\xac\x2e\x75\x25\x0b\xac\x09\x58\x2b\x2b\x1f\xb4\x77\x13\x96\x83\x6e\xd8\x91\x12\xc8\x37\x66\xc0\xd5\x0b\x0b\x0e\xc2\x43\x5b\x90\xa0\x31\x09\x5d\x52\x5e\x28\xb4\x88\xc9\xfb\x80\xe6\xc3\x8d\x9b\xbf\x72\x17\xfb\x91\xab\x59\x8e\x63\xf2\x7d\x26\xe6\x50\xb3\xac\xd1\xcb\x81\xb4\x29\x87\x70\x18\xd4\xd2\x96\xd2\x54\x43\xc5\x25\x16\xe6\xa9\xe5\x1c\xf7\xc3\x2e\xb4\x20\xcb\x4b\x4d\xa6\x6e\x60\x88\xcf\x14\x93\x6b\x37\x06\xc5\x5b\xec\xd1\x5b\xeb\x76\xd8\x31\x25\xc1\x8b\x97\x3f\xbc\x09\xfc\x63\x6f\x8c\x29\xf9\xf5\xb7\xf1\x17\x8e\xc3\x6f\xd9\xc2\x78\x0a\xba\xbc\xd5\x84\x5e\x51\x38\xee\x12\x0a\x79\xe0\x37\xc9\x04\xb8\xa7\x65\x1c\x87\x19\x04\x99\xaf\x31\xd5\x98\x71\xa1\x34\x4c\xd4\x48\xfa\x2b\xaa\x56\x03\x37\x06\x95\xcc\x18\xcf\xaf\xd5\xcf\x4f\xd0\x19\xfb\x88\x80\x4f\xa1\x8d\xe4\x93\x1a\x6f\x94\xb1\x62\xa9\x57\xf0\xe8\xd1\x23\x0f\x7c\x02\x96\xee\xd7\x10\xbf\xf2\xdf\x22\x7d\x1d\x21\x15\x32\x9d\x92\x26\x35\x43\xd0\xe1\x51\x25\x84\x7c\xd6\xe7\xa7\xe4\x6c\x30\xae\x57\xe7\x20\xda\x55\x7d\xe7\xcc\x6b\x7f\xcc\xdf\xdd\xb8\xad\x19\x63\x93\x96\x6e\x6c\xd7\x0f\xd5\x2a\x59\x72\xf0\x98\x4a\x66\xc4\x1d\x6d\x6b\x19\x6f\x27\x03\xd7\xd4\xca\x81\xbb\xba\x0b\xe7\x6a\xb5\x08\x16\x4d\xa4\xe0\x61\xff\x6f\x6f\xdf\xbc\x8e\x20\xd0\x80\x0b\xf3\xc5\xb6\x7f\x03\xd4\x46\xe4\x41\x3f\xf8\x13\x8e\x1f\x07\xbf\x0e\x7f\x8b\xd6\x34\xab\xd8\xa9\x71\x83\x91\xf9\x7b\x6a\x3d\x60\x64\x7f\x0e\x68\x9e\x12\x77\x39\x22\x6d\xf2\xbb\xc1\x60\x7c\x7c\x5e\xd2\x98\xf2\x80\x2f\x30\xdd\x47\x40\x7f\x3a\xba\x1a\xa3\x24\x67\x10\x30\xcd\xf9\x86\x8d\xa2\x28\xe0\x8c\x91\xaa\x04\xfd\x59\xc9\xa0\x17\x50\x6a\xef\x96\x35\xc4\xf4\xd0\x45\x1c\xfc\xd4\x64\xf2\x7f\xb0\xf9\x5b\xc8\x1b\x40\xbe\xdf\xdf\xf0\x22\x15\x9b\x08\xf2\x88\x89\xc5\x38\xdd\xd7\x02\x9a\x26\xf0\x06\x70\x7c\x9b\x66\x83\x01\x79\x46\x82\x8d\xc2\x84\x1b\x90\x11\x5e\xe2\xd5\x80\x3c\x22\xdd\xed\x2b\x2c\x06\x1e\x91\x20\xa6\x25\x0f\x06\xf6\x70\xd4\x66\x10\x05\x04\x1d\x45\x97\xac\xc9\xa0\x69\x97\xbc\xcb\xa1\x1c\xb9\x5a\x02\x80\x31\x57\x89\xaf\x1b\x2d\x48\x84\x5d\x79\xed\x7b\xe8\xc1\x06\x0c\x78\x2c\xaa\x2c\xdb\xbb\xac\x3d\x22\xe3\xda\x19\x5b\xe0\x91\x4d\x46\x5f\xc2\x26\xec\x54\x51\xc5\xe9\x7e\x27\xba\x82\x6d\xa6\x07\x11\x26\x8f\xfd\x8e\xc1\xb8\xe9\xdb\x2d\x6c\x50\xd9\x7c\x00\x1d\x34\xc8\x1d\x7c\x00\x75\x1c\xa1\x99\x5d\xdc\x85\xcf\xce\x3a\x1a\xe8\xcc\x83\x5b\xb0\x15\x15\xd4\x5e\xf2\x2e\x74\x76\x76\xe1\xd0\x19\x55\xbf\x2a\x74\x63\x2f\x1c\xf6\xa7\x83\x5b\xb0\x33\xc8\xb4\xb7\x22\xc7\xb7\x77\xfd\x9b\x8c\x6e\xb1\xa8\x22\x3d\x68\xd0\x5f\x98\x89\x43\xef\xd4\xa4\xe5\x11\xf1\x18\x4e\xcd\xd4\x1c\x60\xcc\x1d\xae\xf3\x9c\x99\x5d\x4f\xa0\x55\x39\x25\xf5\xbb\xa6\x6f\x28\x1e\x49\x28\x6a\x76\xb7\xf0\xa3\xaa\x24\xc1\xe2\xe0\x3e\x1c\x39\x1c\x9e\x27\x77\x7f\x0f\xae\x7c\xa6\x68\xb1\x45\xbe\xfa\x8a\x1c\xac\xb6\xdd\x18\x62\xc0\xdf\x29\xf6\xe6\x38\x57\x94\x6c\x6d\x26\x09\x1e\x3e\xe7\x4a\x99\x0e\x5d\x41\x85\x53\x30\xb7\xe7\xd3\x92\xc0\x01\x8f\x0e\x8c\xcc\xc8\xb0\xcb\x20\x06\xc7\x46\x92\x38\x92\x3b\x1a\x78\xdb\x69\xe1\x64\xd7\xa4\xd7\xda\x09\x3a\x05\xc9\x49\x10\x34\x37\x1f\x40\x20\x80\x47\x06\x91\x44\xbf\xb3\xb6\xe8\xbb\x5c\x79\x2c\x93\x0d\x4e\x71\x5c\x3a\x1c\x1c\x30\xb1\xdb\xab\xf7\x79\x89\xb5\x15\x54\x84\x5b\x13\x12\xbd\x6e\x4d\x75\x89\x75\x12\x86\xb4\x0c\x47\xc1\x99\x2d\x6c\xdc\x56\x54\xb0\x9b\xa8\x4c\x49\x78\x36\x3e\x92\x53\x1b\x9a\x6c\x88\xd6\x35\xcf\x11\xdd\x77\x4d\xd4\xd6\x59\x07\x38\x3c\x6b\x19\xa5\x65\xaf\xe3\x86\x39\xf1\x7c\xf3\xbd\x46\x3b\xe6\xda\xdb\xab\xab\xb3\x06\xff\x16\xcf\xa3\xb3\x8f\x14\xc3\x2f\x97\x95\x5a\xf5\x3b\x8c\x0e\xc6\x87\xb6\x79\x05\x27\x13\x4b\x69\x9c\x87\x1b\x5b\x60\xaf\x00\x55\x7b\xd7\x24\xa6\x9e\x97\x2c\x84\xe2\x2f\x05\x48\x57\x60\xd8\xf2\x1f\xab\xc4\x96\xc9\x6c\xdb\xd9\x74\xa7\x86\x44\x07\xba\x05\x19\xc8\x0c\x6b\x41\xc2\xc3\xb0\x21\x8b\xa9\xd2\x70\x48\x06\xff\x3a\x27\xc1\x78\x6b\xcb\x5d\x11\x98\x65\xb4\x54\x70\xe6\xc1\x42\xe6\xfd\x7f\x7f\x10\x41\x67\x7a\xdd\x1f\x84\xee\xbe\x8b\xa3\x5e\x1f\xfb\x66\xb2\xe6\xfd\x11\x20\x9f\x68\x89\xb3\xd7\x5e\x00\x49\xf6\x58\x0d\x07\xa9\xb7\x37\xdb\x73\xd0\xdc\x4a\xc8\x44\xa7\x33\x33\x1b\xb5\x5d\xdd\xbf\x02\x7c\xf9\xb2\x34\x2d\xd3\x08\xab\xaf\xfe\x01\x5a\x0a\x2d\x0d\x08\x81\x58\x07\x63\xb2\x07\x77\xed\x64\x82\x16\x1a\x13\xdb\xb7\x9a\x11\x2c\xf1\xef\x2b\xcc\xdd\x5c\x48\xb0\x4c\x28\x69\xca\x2b\x05\x6d\x2c\x3c\xfb\x57\xfd\x3e\xc7\x0c\x8a\xef\x64\x15\x22\xdf\xec\x80\x23\x37\x7f\x04\x96\xa0\x7d\x01\x80\x0f\xa1\xf1\xc2\x9a\xef\x0b\x42\x55\xd2\x04\x38\x2f\xc4\x46\xd2\x72\x4c\xfc\x97\x00\x6e\x34\x9e\x43\x03\x9d\x31\x64\xf2\x80\x70\xbe\xa7\xfb\xb1\x14\x1b\x5f\x3a\x90\x23\x03\xf8\xbb\xa8\x8f\x9b\x71\x13\x3d\xae\x79\x92\xdb\x24\x89\x9b\xbc\xef\xf7\xec\x08\xbe\x38\xbc\x63\x83\x1f\xe2\xf7\xd0\xe5\x42\x94\x95\x1b\x2b\xbb\x21\x80\x79\x2c\x7b\x46\x09\xee\xbb\x95\xb4\x92\xa6\xc4\xeb\x87\xce\xa5\x4f\x21\x43\x62\xc9\x99\xaa\xde\x20\x5a\x55\x39\x2d\xc0\x27\xfa\x98\x0e\x07\x56\x4b\xe6\xad\x40\x70\x98\x09\x0e\x98\xd9\x8f\xeb\x7b\x75\x6a\xed\x39\x25\xf6\x6a\x7f\x7a\xbc\x9f\x39\xe0\x9b\xab\xde\x27\x6a\xe8\x38\x95\x70\x4e\x25\x69\xde\x84\x75\xce\xb7\xef\xad\x3d\x20\x2c\xf5\xec\x84\xc5\x34\x09\xe0\x3d\xd3\xde\xc5\xd0\x33\x69\x0d\x6d\xec\xdc\x73\xde\x7d\x60\x0c\xe4\xb2\x0e\x06\x33\xc8\x4f\x9f\x83\x5b\x3b\xa5\xe9\x48\x00\x2d\x4e\x09\x34\x68\x82\xdf\xed\xfc\x0f\x04\xf9\x0c\x4a\xfe\x64\x16\xd1\x0f\x6b\xe5\x19\x37\x6d\xf1\x8b\xab\x5e\xb7\x7f\xc6\xf3\x46\x62\xa3\x61\x00\x3d\x2a\xc8\xad\x9e\xd8\x01\xec\x1c\xed\xdb\xcf\xbd\x79\xcd\x15\x74\x53\x19\x16\xd9\xfe\xdd\x2c\x9c\x11\x9d\x67\x7d\x88\xe0\xe6\x8b\x24\xe4\xd9\x63\x30\x08\xec\xe3\x76\x25\xb9\x6b\xf7\x4f\x38\x44\x60\x9d\xf6\x8e\x34\x6a\x22\xdf\x02\xd6\x05\x10\xd9\xed\x3f\xdc\x82\xc4\xfa\x16\x82\xb9\x86\xb6\xf2\xe7\x57\xd0\x45\x42\x43\x85\x49\x53\x10\x4c\xcb\xf6\xb5\x4d\xfd\x65\x17\x98\x40\xe1\x84\x6b\x43\x65\xea\x66\x47\xb0\xbe\x35\xaf\x95\xea\x8a\x13\xc8\xbe\xc2\x28\x06\x46\xea\x1f\xb4\x9b\x0f\xfa\xbd\xa8\x69\x72\x88\x10\x8c\x26\xab\x43\x40\x93\x23\x3d\xdd\x29\x79\x6d\x3a\x8f\xfe\x83\x3e\xbe\x98\x1b\x44\x54\x6b\xd9\xef\xb5\x9c\xa1\x37\x40\xbb\x9e\x35\x3a\x41\xbf\x7d\xd2\x3a\x56\x77\xe1\xd8\xd7\xf0\xbe\xfe\xa8\xc1\x13\xa5\xfa\xd6\xaf\x00\x6a\x8f\xbb\xed\x56\xbd\x87\x3d\x6f\xa8\xfd\xf1\xde\xcb\x31\x3d\xca\x49\x0b\x75\x0f\x4f\x59\xef\x80\x3c\x4d\xd3\x17\x78\x7e\xfa\xc1\x91\x93\xde\xf5\x8e\x81\x57\xb6\x8d\xd7\x77\x6a\xd9\x7e\x1c\x72\x8b\x8a\x79\x0a\x9b\x55\x35\xb7\x03\x92\xfe\x13\xdf\xf7\xd5\x60\xc6\x79\xbb\xa9\xe0\xa0\x84\x41\x12\xed\x32\x26\xec\x94\x3d\x77\x64\x0d\x47\xd2\x4a\xb5\x3b\x45\x85\x0f\x07\x7e\xbe\xf6\x52\x61\x4d\x67\x5f\x47\x6c\xd8\x5c\x99\x01\x06\x71\xfe\x6e\x46\x4a\x76\x74\xf4\xfc\xc7\x57\x8d\xf1\x91\x3f\x11\x7d\x83\xdd\x7f\x74\x79\x6c\x3c\x73\xf4\x2b\x4f\x7c\xc1\xb0\x14\x62\x99\xd9\xef\x3b\xfd\xfc\x06\x07\x1c\xf8\x1d\x27\x34\x61\xdb\x22\x21\xd0\xda\x31\x39\x6b\xa0\x77\x43\x9d\x49\x6c\xbf\x3f\x9c\xc4\xf6\x13\xeb\xff\x00\xa8\xd6\x50\x4d\x73\x2d\x00\x00")
 
 func faucetHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -85,7 +85,7 @@ func faucetHtml() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "faucet.html", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc5, 0x8d, 0xb, 0x7a, 0xfd, 0x70, 0x68, 0x68, 0xd2, 0xd8, 0xf3, 0xf6, 0xac, 0x72, 0xed, 0xc2, 0x76, 0x18, 0x2d, 0x1, 0xe5, 0x3b, 0x55, 0xb, 0xce, 0xfc, 0xb6, 0xd5, 0x59, 0xc3, 0x94, 0x5b}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x31, 0xda, 0xbd, 0xef, 0x94, 0x73, 0x86, 0x20, 0x54, 0xee, 0x92, 0x26, 0xa1, 0xf7, 0x5c, 0x18, 0xd0, 0x28, 0x60, 0xc3, 0x2a, 0x53, 0xbf, 0xa1, 0x98, 0x15, 0x1a, 0xc6, 0xaf, 0x58, 0x38, 0x2c}}
 	return a, nil
 }
 
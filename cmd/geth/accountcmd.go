@@ -128,6 +128,7 @@ can then be used to prove possession of the signing key, for example to
 authorize a the signer to act as a validator in the Celo protocol.
 `,
 			},
+			accountBLSCommand,
 			{
 				Name:      "confirm-address",
 				Usage:     "Confirm the entered address appears on the hardware wallet.",
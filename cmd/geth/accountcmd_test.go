@@ -153,6 +153,29 @@ Repeat password: {{.InputLine "foobar2"}}
 `)
 }
 
+func TestAccountBLSExportVerify(t *testing.T) {
+	datadir := tmpDatadirWithKeystore(t)
+	bundle := filepath.Join(datadir, "bundle.json")
+	geth := runGeth(t, "account", "bls", "export",
+		"--datadir", datadir, "--lightkdf", "--password", "testdata/password.txt",
+		"--account", "0x993c4d601ed879b4ad36fc31f0c0214d547113eb", "--out", bundle,
+		"f466859ead1932d743d622cb74fc058882e8648a")
+	geth.WaitExit()
+
+	results := filepath.Join(datadir, "results.json")
+	geth = runGeth(t, "account", "bls", "verify", "--out", results, bundle)
+	geth.WaitExit()
+	data, err := ioutil.ReadFile(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"verified": 1`, `"signer": "0xf466859ead1932d743d622cb74fc058882e8648a"`, `"valid": true`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("verification results do not contain %s:\n%s", want, data)
+		}
+	}
+}
+
 func TestWalletImport(t *testing.T) {
 	geth := runGeth(t, "wallet", "import", "--lightkdf", "testdata/guswallet.json")
 	defer geth.ExpectExit()
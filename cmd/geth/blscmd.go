@@ -0,0 +1,392 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/celo-org/celo-blockchain/accounts"
+	"github.com/celo-org/celo-blockchain/accounts/keystore"
+	"github.com/celo-org/celo-blockchain/cmd/utils"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-bls-go/bls"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	blsAccountFlag = cli.StringFlag{
+		Name:  "account",
+		Usage: "Address of the validator account the signer key is authorized for",
+	}
+	blsMessageFlag = cli.StringFlag{
+		Name:  "message",
+		Usage: "Address the PoPs are made over, for the entries without an account",
+	}
+	blsOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the JSON output to (default = stdout)",
+	}
+
+	accountBLSCommand = cli.Command{
+		Name:  "bls",
+		Usage: "Manage the BLS keys of validator signers",
+		Description: `
+The BLS key of a validator signer is derived from its ECDSA key. These commands
+produce the keys, proofs-of-possession and authorization signatures needed to
+authorize a signer for a validator account in the Accounts contract, and verify
+them. Their output is JSON.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "rotate",
+				Usage:  "Create a new signer key and its authorization for a validator account",
+				Action: utils.MigrateFlags(accountBLSRotate),
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.KeyStoreDirFlag,
+					utils.PasswordFileFlag,
+					utils.LightKDFFlag,
+					blsAccountFlag,
+					blsOutFlag,
+				},
+				Description: `
+    geth account bls rotate --account <validator account>
+
+Creates a new signer key in the keystore and writes the bundle rotating the
+validator signer to it: the signer address, its ECDSA and BLS public keys, the
+BLS proof-of-possession and the ECDSA authorization signature, both over the
+validator account address, and the data of the authorizeValidatorSignerWithKeys
+transaction the validator account must send to the Accounts contract.`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export the authorization of an existing signer key for a validator account",
+				Action:    utils.MigrateFlags(accountBLSExport),
+				ArgsUsage: "<signer address>",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.KeyStoreDirFlag,
+					utils.PasswordFileFlag,
+					utils.LightKDFFlag,
+					blsAccountFlag,
+					blsOutFlag,
+				},
+				Description: `
+    geth account bls export --account <validator account> <signer address>
+
+Writes the same bundle as rotate, for a signer key already in the keystore.`,
+			},
+			{
+				Name:      "verify",
+				Usage:     "Verify the proofs-of-possession of a list of signers",
+				Action:    utils.MigrateFlags(accountBLSVerify),
+				ArgsUsage: "<file>",
+				Flags: []cli.Flag{
+					blsMessageFlag,
+					blsOutFlag,
+				},
+				Description: `
+    geth account bls verify [--message <address>] <file>
+
+Verifies the BLS proofs-of-possession of the signers in the given JSON file,
+which holds either a single entry or a list of them, such as the genesis PoPs
+checked by blspopchecker or the bundles written by rotate and export. Entries
+are objects with the fields:
+
+  signer (or address)   the signer address
+  account               the address the PoP is made over, --message if missing
+  blsPublicKey, blsPop  the BLS public key and its proof-of-possession
+  signature             optionally, the ECDSA authorization signature {v, r, s}
+
+The results are written as JSON, and the command fails if any entry does not
+verify.`,
+			},
+		},
+	}
+)
+
+// blsAuthorizationSignature is the ECDSA signature of a signer over the account
+// it is authorized for, in the form expected by the Accounts contract.
+type blsAuthorizationSignature struct {
+	V uint8       `json:"v"`
+	R common.Hash `json:"r"`
+	S common.Hash `json:"s"`
+}
+
+// blsKeyBundle holds what a validator account needs to authorize a signer.
+type blsKeyBundle struct {
+	Account        common.Address             `json:"account"`
+	Signer         common.Address             `json:"signer"`
+	ECDSAPublicKey hexutil.Bytes              `json:"ecdsaPublicKey"`
+	BLSPublicKey   hexutil.Bytes              `json:"blsPublicKey"`
+	BLSPoP         hexutil.Bytes              `json:"blsPop"`
+	Signature      *blsAuthorizationSignature `json:"signature"`
+	AuthorizeData  hexutil.Bytes              `json:"authorizeValidatorSignerWithKeys"`
+}
+
+// blsPoPEntry is an entry of the lists verified by accountBLSVerify. Hex values
+// may be given with or without the 0x prefix.
+type blsPoPEntry struct {
+	Address      string                     `json:"address"`
+	Signer       string                     `json:"signer"`
+	Account      string                     `json:"account"`
+	BLSPublicKey string                     `json:"blsPublicKey"`
+	BLSPoP       string                     `json:"blsPop"`
+	Signature    *blsAuthorizationSignature `json:"signature"`
+}
+
+// blsPoPResult is the verification result of an entry.
+type blsPoPResult struct {
+	Signer  common.Address `json:"signer"`
+	Account common.Address `json:"account"`
+	Valid   bool           `json:"valid"`
+	Error   string         `json:"error,omitempty"`
+}
+
+func blsAccountAddress(ctx *cli.Context) common.Address {
+	account := ctx.String(blsAccountFlag.Name)
+	if !common.IsHexAddress(account) {
+		utils.Fatalf("Please specify a valid validator account address with --%s", blsAccountFlag.Name)
+	}
+	return common.HexToAddress(account)
+}
+
+func accountBLSRotate(ctx *cli.Context) error {
+	account := blsAccountAddress(ctx)
+	stack, _ := makeConfigNode(ctx)
+	ks := stack.AccountManager().Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
+
+	password := utils.GetPassPhraseWithList("The new signer key is locked with a password. Please give a password. Do not forget this password.", true, 0, utils.MakePasswordList(ctx))
+	signer, err := ks.NewAccount(password)
+	if err != nil {
+		utils.Fatalf("Failed to create signer key: %v", err)
+	}
+	if err := ks.Unlock(signer, password); err != nil {
+		utils.Fatalf("Failed to unlock signer key: %v", err)
+	}
+	bundle, err := makeBLSKeyBundle(ks, signer, account)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "New signer key %s stored in %s\n", signer.Address.Hex(), signer.URL.Path)
+	return writeBLSJSON(ctx, bundle)
+}
+
+func accountBLSExport(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("Please specify the signer address to export.")
+	}
+	account := blsAccountAddress(ctx)
+	stack, _ := makeConfigNode(ctx)
+	ks := stack.AccountManager().Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
+
+	signer, _ := unlockAccount(ks, ctx.Args()[0], 0, utils.MakePasswordList(ctx))
+	bundle, err := makeBLSKeyBundle(ks, signer, account)
+	if err != nil {
+		return err
+	}
+	return writeBLSJSON(ctx, bundle)
+}
+
+// makeBLSKeyBundle creates the bundle authorizing the unlocked signer for the
+// given account.
+func makeBLSKeyBundle(ks *keystore.KeyStore, signer accounts.Account, account common.Address) (*blsKeyBundle, error) {
+	ecdsaPublicKey, sig, err := ks.GenerateProofOfPossession(signer, account)
+	if err != nil {
+		return nil, err
+	}
+	blsPublicKey, blsPoP, err := ks.GenerateProofOfPossessionBLS(signer, account)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &blsKeyBundle{
+		Account: account,
+		Signer:  signer.Address,
+		// The contract expects the 64 bytes of the key, without the 0x04 prefix
+		ECDSAPublicKey: ecdsaPublicKey[1:],
+		BLSPublicKey:   blsPublicKey,
+		BLSPoP:         blsPoP,
+		Signature: &blsAuthorizationSignature{
+			V: sig[64] + 27,
+			R: common.BytesToHash(sig[:32]),
+			S: common.BytesToHash(sig[32:64]),
+		},
+	}
+	bundle.AuthorizeData, err = abis.Accounts.Pack("authorizeValidatorSignerWithKeys", bundle.Signer,
+		bundle.Signature.V, bundle.Signature.R, bundle.Signature.S,
+		[]byte(bundle.ECDSAPublicKey), []byte(bundle.BLSPublicKey), []byte(bundle.BLSPoP))
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func accountBLSVerify(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		utils.Fatalf("Please specify the file of the PoPs to verify.")
+	}
+	data, err := ioutil.ReadFile(ctx.Args()[0])
+	if err != nil {
+		utils.Fatalf("Failed to read PoPs: %v", err)
+	}
+	entries, err := parseBLSPoPEntries(data)
+	if err != nil {
+		utils.Fatalf("Failed to parse PoPs: %v", err)
+	}
+	var message *common.Address
+	if ctx.IsSet(blsMessageFlag.Name) {
+		if !common.IsHexAddress(ctx.String(blsMessageFlag.Name)) {
+			utils.Fatalf("Please specify a valid address with --%s", blsMessageFlag.Name)
+		}
+		address := common.HexToAddress(ctx.String(blsMessageFlag.Name))
+		message = &address
+	}
+
+	var output struct {
+		Verified int            `json:"verified"`
+		Failed   int            `json:"failed"`
+		Results  []blsPoPResult `json:"results"`
+	}
+	for _, entry := range entries {
+		result := verifyBLSPoPEntry(entry, message)
+		if result.Valid {
+			output.Verified++
+		} else {
+			output.Failed++
+		}
+		output.Results = append(output.Results, result)
+	}
+	if err := writeBLSJSON(ctx, output); err != nil {
+		return err
+	}
+	if output.Failed > 0 {
+		return fmt.Errorf("%d of %d PoPs failed to verify", output.Failed, len(entries))
+	}
+	return nil
+}
+
+// parseBLSPoPEntries parses a single entry or a list of entries.
+func parseBLSPoPEntries(data []byte) ([]blsPoPEntry, error) {
+	var entries []blsPoPEntry
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var entry blsPoPEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, err
+		}
+		return append(entries, entry), nil
+	}
+	err := json.Unmarshal(data, &entries)
+	return entries, err
+}
+
+// verifyBLSPoPEntry verifies the BLS proof-of-possession of the entry, and its
+// ECDSA authorization signature if present. The PoP is made over the account of
+// the entry, or over the given message if it has none.
+func verifyBLSPoPEntry(entry blsPoPEntry, message *common.Address) (result blsPoPResult) {
+	fail := func(err error) blsPoPResult {
+		result.Error = err.Error()
+		return result
+	}
+	signer := entry.Signer
+	if signer == "" {
+		signer = entry.Address
+	}
+	if !common.IsHexAddress(signer) {
+		return fail(fmt.Errorf("invalid signer address %q", signer))
+	}
+	result.Signer = common.HexToAddress(signer)
+
+	switch {
+	case entry.Account != "":
+		if !common.IsHexAddress(entry.Account) {
+			return fail(fmt.Errorf("invalid account address %q", entry.Account))
+		}
+		result.Account = common.HexToAddress(entry.Account)
+	case message != nil:
+		result.Account = *message
+	default:
+		return fail(errors.New("no account to verify the PoP over"))
+	}
+
+	blsPublicKey, err := hexutil.Decode(ensureHexPrefix(entry.BLSPublicKey))
+	if err != nil {
+		return fail(fmt.Errorf("invalid BLS public key: %v", err))
+	}
+	blsPoP, err := hexutil.Decode(ensureHexPrefix(entry.BLSPoP))
+	if err != nil {
+		return fail(fmt.Errorf("invalid BLS PoP: %v", err))
+	}
+	publicKey, err := bls.DeserializePublicKey(blsPublicKey)
+	if err != nil {
+		return fail(fmt.Errorf("invalid BLS public key: %v", err))
+	}
+	defer publicKey.Destroy()
+	signature, err := bls.DeserializeSignature(blsPoP)
+	if err != nil {
+		return fail(fmt.Errorf("invalid BLS PoP: %v", err))
+	}
+	defer signature.Destroy()
+	if err := publicKey.VerifyPoP(result.Account.Bytes(), signature); err != nil {
+		return fail(fmt.Errorf("BLS PoP verification failed: %v", err))
+	}
+
+	if entry.Signature != nil {
+		if entry.Signature.V < 27 {
+			return fail(fmt.Errorf("invalid authorization signature v %d", entry.Signature.V))
+		}
+		sig := append(append(entry.Signature.R.Bytes(), entry.Signature.S.Bytes()...), entry.Signature.V-27)
+		pub, err := crypto.SigToPub(accounts.TextHash(crypto.Keccak256(result.Account.Bytes())), sig)
+		if err != nil {
+			return fail(fmt.Errorf("invalid authorization signature: %v", err))
+		}
+		if recovered := crypto.PubkeyToAddress(*pub); recovered != result.Signer {
+			return fail(fmt.Errorf("authorization signed by %s instead of the signer", recovered.Hex()))
+		}
+	}
+	result.Valid = true
+	return result
+}
+
+func ensureHexPrefix(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return s
+	}
+	return "0x" + s
+}
+
+// writeBLSJSON writes the indented JSON of v to the file of the --out flag, or
+// to stdout.
+func writeBLSJSON(ctx *cli.Context, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if out := ctx.String(blsOutFlag.Name); out != "" {
+		return ioutil.WriteFile(out, data, 0644)
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
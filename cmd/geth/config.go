@@ -163,6 +163,10 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
 	}
+	// Enforce the on-chain minimum client version unless disabled
+	if !ctx.GlobalBool(utils.VersionCheckFlag.Name) {
+		utils.RegisterVersionCheckService(ctx, stack, backend)
+	}
 	return stack, backend
 }
 
@@ -62,6 +62,8 @@ Remove blockchain and state databases`,
 			dbPutCmd,
 			dbGetSlotsCmd,
 			dbDumpFreezerIndex,
+			dbIstanbulSnapshotsCmd,
+			dbUptimeCmd,
 		},
 	}
 	dbInspectCmd = cli.Command{
@@ -263,13 +265,16 @@ func inspect(ctx *cli.Context) error {
 			start = d
 		}
 	}
-	stack, _ := makeConfigNode(ctx)
+	stack, config := makeConfigNode(ctx)
 	defer stack.Close()
 
 	db := utils.MakeChainDatabase(ctx, stack, true)
 	defer db.Close()
 
-	return rawdb.InspectDatabase(db, prefix, start)
+	if err := rawdb.InspectDatabase(db, prefix, start); err != nil {
+		return err
+	}
+	return inspectIstanbulDatabases(&config.Eth.Istanbul)
 }
 
 func showLeveldbStats(db ethdb.Stater) {
@@ -286,13 +291,14 @@ func showLeveldbStats(db ethdb.Stater) {
 }
 
 func dbStats(ctx *cli.Context) error {
-	stack, _ := makeConfigNode(ctx)
+	stack, config := makeConfigNode(ctx)
 	defer stack.Close()
 
 	db := utils.MakeChainDatabase(ctx, stack, true)
 	defer db.Close()
 
 	showLeveldbStats(db)
+	showIstanbulDatabaseStats(&config.Eth.Istanbul)
 	return nil
 }
 
@@ -0,0 +1,417 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/celo-org/celo-blockchain/cmd/utils"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	dbPruneKeepFlag = cli.Uint64Flag{
+		Name:  "keep",
+		Usage: "Number of most recent entries to keep",
+	}
+	dbPruneBeforeFlag = cli.Uint64Flag{
+		Name:  "before",
+		Usage: "Remove the entries before this block number (snapshots) or epoch (uptime)",
+	}
+
+	istanbulDBFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.SyncModeFlag,
+		utils.MainnetFlag,
+		utils.BaklavaFlag,
+		utils.AlfajoresFlag,
+	}
+
+	dbIstanbulSnapshotsCmd = cli.Command{
+		Name:  "istanbul-snapshots",
+		Usage: "List, dump and prune the istanbul validator snapshots",
+		Subcommands: []cli.Command{
+			{
+				Action: utils.MigrateFlags(listIstanbulSnapshots),
+				Name:   "list",
+				Usage:  "List the stored istanbul snapshots and their sizes",
+				Flags:  istanbulDBFlags,
+			},
+			{
+				Action:    utils.MigrateFlags(dumpIstanbulSnapshot),
+				Name:      "dump",
+				Usage:     "Dump the istanbul snapshot of a block",
+				ArgsUsage: "<hash | number>",
+				Flags:     istanbulDBFlags,
+			},
+			{
+				Action: utils.MigrateFlags(pruneIstanbulSnapshots),
+				Name:   "prune",
+				Usage:  "Remove old istanbul snapshots",
+				Flags:  append([]cli.Flag{dbPruneKeepFlag, dbPruneBeforeFlag}, istanbulDBFlags...),
+				Description: `
+Removes the snapshots that are not among the --keep most recent ones, or that
+were created before the --before block. Missing snapshots are recomputed from
+the block headers when needed, which may take a long time.`,
+			},
+		},
+	}
+	dbUptimeCmd = cli.Command{
+		Name:  "uptime",
+		Usage: "List, dump and prune the accumulated validator uptimes",
+		Subcommands: []cli.Command{
+			{
+				Action: utils.MigrateFlags(listUptimes),
+				Name:   "list",
+				Usage:  "List the epochs with accumulated uptimes and their sizes",
+				Flags:  istanbulDBFlags,
+			},
+			{
+				Action:    utils.MigrateFlags(dumpUptime),
+				Name:      "dump",
+				Usage:     "Dump the accumulated uptime of an epoch",
+				ArgsUsage: "<epoch>",
+				Flags:     istanbulDBFlags,
+			},
+			{
+				Action: utils.MigrateFlags(pruneUptimes),
+				Name:   "prune",
+				Usage:  "Remove the accumulated uptimes of old epochs",
+				Flags:  append([]cli.Flag{dbPruneKeepFlag, dbPruneBeforeFlag}, istanbulDBFlags...),
+				Description: `
+Removes the uptimes that are not among the --keep most recent epochs, or that
+were accumulated for epochs before the --before epoch. The uptime of the epoch of
+the chain head is always kept, as it is needed to compute the validator scores at
+the end of the epoch.`,
+			},
+		},
+	}
+)
+
+// istanbulDatabases returns the names and the paths of the istanbul databases
+// stored out of the chain database which exist on disk.
+func istanbulDatabases(config *istanbul.Config) [][2]string {
+	var dbs [][2]string
+	for _, db := range [][2]string{
+		{"Replica state", config.ReplicaStateDBPath},
+		{"Validator enodes", config.ValidatorEnodeDBPath},
+		{"Version certificates", config.VersionCertificateDBPath},
+		{"Round states", config.RoundStateDBPath},
+	} {
+		if db[1] != "" && common.FileExist(db[1]) {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs
+}
+
+// inspectIstanbulDatabases iterates the istanbul databases stored out of the
+// chain database and displays their sizes.
+func inspectIstanbulDatabases(config *istanbul.Config) error {
+	var (
+		stats [][]string
+		total common.StorageSize
+	)
+	for _, entry := range istanbulDatabases(config) {
+		db, err := rawdb.NewLevelDBDatabase(entry[1], 0, 0, "", true)
+		if err != nil {
+			return fmt.Errorf("failed to open %s database: %v", entry[0], err)
+		}
+		var (
+			size  common.StorageSize
+			count int
+		)
+		it := db.NewIterator(nil, nil)
+		for it.Next() {
+			size += common.StorageSize(len(it.Key()) + len(it.Value()))
+			count++
+		}
+		it.Release()
+		db.Close()
+
+		total += size
+		stats = append(stats, []string{"Istanbul store", entry[0], size.String(), strconv.Itoa(count)})
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Database", "Category", "Size", "Items"})
+	table.SetFooter([]string{"", "Total", total.String(), " "})
+	table.AppendBulk(stats)
+	table.Render()
+	return nil
+}
+
+// showIstanbulDatabaseStats prints the leveldb statistics of the istanbul
+// databases stored out of the chain database.
+func showIstanbulDatabaseStats(config *istanbul.Config) {
+	for _, entry := range istanbulDatabases(config) {
+		db, err := rawdb.NewLevelDBDatabase(entry[1], 0, 0, "", true)
+		if err != nil {
+			log.Warn("Failed to open database", "database", entry[0], "error", err)
+			continue
+		}
+		fmt.Printf("%s database (%s)\n", entry[0], entry[1])
+		showLeveldbStats(db)
+		db.Close()
+	}
+}
+
+func listIstanbulSnapshots(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	var (
+		stats [][]string
+		total common.StorageSize
+	)
+	infos := rawdb.ReadIstanbulSnapshotInfos(db)
+	for _, info := range infos {
+		total += info.Size
+		stats = append(stats, []string{
+			strconv.FormatUint(info.Number, 10), strconv.FormatUint(info.Epoch, 10),
+			info.Hash.Hex(), strconv.Itoa(info.Validators), info.Size.String(),
+		})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Number", "Epoch size", "Hash", "Validators", "Size"})
+	table.SetFooter([]string{"", "", "Total", strconv.Itoa(len(infos)), total.String()})
+	table.AppendBulk(stats)
+	table.Render()
+	return nil
+}
+
+func dumpIstanbulSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	arg := ctx.Args().Get(0)
+	var hash common.Hash
+	if number, err := strconv.ParseUint(arg, 10, 64); err == nil {
+		found := false
+		for _, info := range rawdb.ReadIstanbulSnapshotInfos(db) {
+			if info.Number == number {
+				hash, found = info.Hash, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("no istanbul snapshot stored for block %d", number)
+		}
+	} else if len(arg) == 2+2*common.HashLength {
+		hash = common.HexToHash(arg)
+	} else {
+		return fmt.Errorf("invalid block hash or number %q", arg)
+	}
+	blob := rawdb.ReadIstanbulSnapshotJSON(db, hash)
+	if blob == nil {
+		return fmt.Errorf("no istanbul snapshot stored for block %x", hash)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, blob, "", "  "); err != nil {
+		return fmt.Errorf("invalid istanbul snapshot: %v", err)
+	}
+	fmt.Println(out.String())
+	return nil
+}
+
+// prunedEntries returns the number of leading entries of a list sorted from
+// oldest to newest to prune, given the --keep and --before flags and the
+// positions of the entries.
+func prunedEntries(ctx *cli.Context, positions []uint64) (int, error) {
+	keep, before := ctx.Uint64(dbPruneKeepFlag.Name), ctx.Uint64(dbPruneBeforeFlag.Name)
+	if !ctx.IsSet(dbPruneKeepFlag.Name) && !ctx.IsSet(dbPruneBeforeFlag.Name) {
+		return 0, errors.New("--keep or --before is required")
+	}
+	pruned := 0
+	if ctx.IsSet(dbPruneKeepFlag.Name) && uint64(len(positions)) > keep {
+		pruned = len(positions) - int(keep)
+	}
+	for pruned < len(positions) && positions[pruned] < before {
+		pruned++
+	}
+	return pruned, nil
+}
+
+// deleteEntries deletes the entries in batches and reports the freed size.
+func deleteEntries(db ethdb.Database, count int, size common.StorageSize, remove func(batch ethdb.Batch, i int)) error {
+	batch := db.NewBatch()
+	for i := 0; i < count; i++ {
+		remove(batch, i)
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Pruned database entries", "count", count, "size", size)
+	return nil
+}
+
+func pruneIstanbulSnapshots(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	infos := rawdb.ReadIstanbulSnapshotInfos(db)
+	numbers := make([]uint64, len(infos))
+	for i, info := range infos {
+		numbers[i] = info.Number
+	}
+	pruned, err := prunedEntries(ctx, numbers)
+	if err != nil {
+		return err
+	}
+	var size common.StorageSize
+	for _, info := range infos[:pruned] {
+		size += info.Size
+	}
+	return deleteEntries(db, pruned, size, func(batch ethdb.Batch, i int) {
+		rawdb.DeleteIstanbulSnapshot(batch, infos[i].Hash)
+	})
+}
+
+func listUptimes(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	var (
+		stats [][]string
+		total common.StorageSize
+	)
+	infos := rawdb.ReadUptimeInfos(db)
+	for _, info := range infos {
+		total += info.Size
+		stats = append(stats, []string{
+			strconv.FormatUint(info.Epoch, 10), strconv.FormatUint(info.LatestBlock, 10),
+			strconv.Itoa(info.Validators), info.Size.String(),
+		})
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Epoch", "Latest block", "Validators", "Size"})
+	table.SetFooter([]string{"", "Total", strconv.Itoa(len(infos)), total.String()})
+	table.AppendBulk(stats)
+	table.Render()
+	return nil
+}
+
+func dumpUptime(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	epoch, err := strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid epoch: %v", err)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	uptime := rawdb.ReadAccumulatedEpochUptime(db, epoch)
+	if uptime == nil {
+		return fmt.Errorf("no uptime stored for epoch %d", epoch)
+	}
+	out, err := json.MarshalIndent(uptime, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+// headEpoch returns the epoch of the chain head, whose uptime is accumulated
+// until the end of the epoch.
+func headEpoch(db ethdb.Database) (uint64, error) {
+	head := rawdb.ReadHeadHeaderHash(db)
+	number := rawdb.ReadHeaderNumber(db, head)
+	if number == nil {
+		return 0, errors.New("chain head not found")
+	}
+	config := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if config == nil || config.Istanbul == nil || config.Istanbul.Epoch == 0 {
+		return 0, errors.New("istanbul epoch size not found in the chain config")
+	}
+	return istanbul.GetEpochNumber(*number, config.Istanbul.Epoch), nil
+}
+
+func pruneUptimes(ctx *cli.Context) error {
+	if ctx.IsSet(dbPruneKeepFlag.Name) && ctx.Uint64(dbPruneKeepFlag.Name) == 0 {
+		return errors.New("--keep must be at least 1, the uptime of the current epoch is always kept")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	current, err := headEpoch(db)
+	if err != nil {
+		return err
+	}
+	infos := rawdb.ReadUptimeInfos(db)
+	epochs := make([]uint64, len(infos))
+	for i, info := range infos {
+		epochs[i] = info.Epoch
+	}
+	pruned, err := prunedEntries(ctx, epochs)
+	if err != nil {
+		return err
+	}
+	// The uptime of the current epoch is still being accumulated
+	for pruned > 0 && epochs[pruned-1] >= current {
+		pruned--
+	}
+	var size common.StorageSize
+	for _, info := range infos[:pruned] {
+		size += info.Size
+	}
+	return deleteEntries(db, pruned, size, func(batch ethdb.Batch, i int) {
+		rawdb.DeleteAccumulatedEpochUptime(batch, infos[i].Epoch)
+	})
+}
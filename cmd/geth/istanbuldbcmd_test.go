@@ -0,0 +1,101 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/uptime"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+// writeUptimeDatadir writes a chain database whose head is in the third epoch,
+// with the accumulated uptimes of the first three epochs.
+func writeUptimeDatadir(t *testing.T, datadir string) {
+	chaindata := filepath.Join(datadir, "celo", "chaindata")
+	db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 16, 16, filepath.Join(chaindata, "ancient"), "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var parent common.Hash
+	for number := uint64(0); number <= 250; number++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent, Extra: make([]byte, types.IstanbulExtraVanity)}
+		rawdb.WriteHeader(db, header)
+		rawdb.WriteCanonicalHash(db, header.Hash(), number)
+		if number == 0 {
+			rawdb.WriteChainConfig(db, header.Hash(), &params.ChainConfig{Istanbul: &params.IstanbulConfig{Epoch: 100}})
+		}
+		parent = header.Hash()
+	}
+	rawdb.WriteHeadHeaderHash(db, parent)
+
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		rawdb.WriteAccumulatedEpochUptime(db, epoch, &uptime.Uptime{
+			LatestBlock: epoch * 100,
+			Entries:     []uptime.UptimeEntry{{UpBlocks: epoch, LastSignedBlock: epoch * 100}},
+		})
+	}
+}
+
+func storedUptimeEpochs(t *testing.T, datadir string) []uint64 {
+	chaindata := filepath.Join(datadir, "celo", "chaindata")
+	db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 16, 16, filepath.Join(chaindata, "ancient"), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var epochs []uint64
+	for _, info := range rawdb.ReadUptimeInfos(db) {
+		epochs = append(epochs, info.Epoch)
+	}
+	return epochs
+}
+
+func TestPruneUptimesKeepsCurrentEpoch(t *testing.T) {
+	datadir := tmpdir(t)
+	defer os.RemoveAll(datadir)
+	writeUptimeDatadir(t, datadir)
+
+	// Pruning past the head removes the previous epochs only
+	runGeth(t, "--datadir", datadir, "db", "uptime", "prune", "--before", "10").WaitExit()
+	if epochs := storedUptimeEpochs(t, datadir); len(epochs) != 1 || epochs[0] != 3 {
+		t.Fatalf("stored uptime epochs %v, want [3]", epochs)
+	}
+
+	// Keeping no epoch is rejected
+	geth := runGeth(t, "--datadir", datadir, "db", "uptime", "prune", "--keep", "0")
+	geth.WaitExit()
+	if status := geth.ExitStatus(); status == 0 {
+		t.Fatalf("pruning with --keep 0 succeeded")
+	}
+	if stderr := geth.StderrText(); !strings.Contains(stderr, "--keep must be at least 1") {
+		t.Fatalf("unexpected error output: %s", stderr)
+	}
+	if epochs := storedUptimeEpochs(t, datadir); len(epochs) != 1 || epochs[0] != 3 {
+		t.Fatalf("stored uptime epochs %v after --keep 0, want [3]", epochs)
+	}
+}
@@ -18,7 +18,6 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"sort"
@@ -31,8 +30,6 @@ import (
 	"github.com/celo-org/celo-blockchain/cmd/utils"
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/console/prompt"
-	"github.com/celo-org/celo-blockchain/contracts/blockchain_parameters"
-	"github.com/celo-org/celo-blockchain/core/vm"
 	"github.com/celo-org/celo-blockchain/eth"
 	"github.com/celo-org/celo-blockchain/eth/downloader"
 	"github.com/celo-org/celo-blockchain/ethclient"
@@ -42,7 +39,6 @@ import (
 	"github.com/celo-org/celo-blockchain/log"
 	"github.com/celo-org/celo-blockchain/metrics"
 	"github.com/celo-org/celo-blockchain/node"
-	"github.com/celo-org/celo-blockchain/rpc"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -114,6 +110,7 @@ var (
 		utils.CachePreimagesFlag,
 		utils.ListenPortFlag,
 		utils.MaxPeersFlag,
+		utils.ReservedPeersFlag,
 		utils.MaxPendingPeersFlag,
 		utils.MiningEnabledFlag,
 		utils.MinerValidatorFlag,
@@ -152,6 +149,8 @@ var (
 		utils.PingIPFromPacketFlag,
 		utils.UseInMemoryDiscoverTableFlag,
 		utils.VersionCheckFlag,
+		utils.VersionCheckPolicyFlag,
+		utils.VersionCheckGraceBlocksFlag,
 		utils.ProxyFlag,
 		utils.ProxyInternalFacingEndpointFlag,
 		utils.ProxiedValidatorAddressFlag,
@@ -181,6 +180,11 @@ var (
 		utils.HTTPRequestReadTimeout,
 		utils.HTTPRequestWriteTimeout,
 		utils.HTTPRequestIdleTimeout,
+		utils.HealthMinPeersFlag,
+		utils.HealthMaxSyncLagFlag,
+		utils.HealthSealWindowFlag,
+		utils.HealthProxyLinkFlag,
+		utils.HealthReplicaStateFlag,
 		utils.WSEnabledFlag,
 		utils.WSListenAddrFlag,
 		utils.LegacyWSListenAddrFlag,
@@ -445,18 +449,6 @@ func startNode(ctx *cli.Context, stack *node.Node, backend ethapi.Backend) {
 			utils.Fatalf("Failed to start mining: %v", err)
 		}
 	}
-	if !ctx.GlobalBool(utils.VersionCheckFlag.Name) {
-		runnerFactory := func() (vm.EVMRunner, error) {
-			header := backend.CurrentHeader()
-			stateDB, _, err := backend.StateAndHeaderByNumberOrHash(context.Background(), rpc.BlockNumberOrHashWithHash(header.Hash(), true))
-			if err != nil {
-				return nil, err
-			}
-			return backend.NewEVMRunner(header, stateDB), nil
-		}
-
-		blockchain_parameters.SpawnCheck(runnerFactory)
-	}
 }
 
 // unlockAccounts unlocks any account specifically requested.
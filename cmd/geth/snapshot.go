@@ -21,10 +21,12 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/celo-org/celo-blockchain/cmd/utils"
 	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core"
 	"github.com/celo-org/celo-blockchain/core/rawdb"
 	"github.com/celo-org/celo-blockchain/core/state"
 	"github.com/celo-org/celo-blockchain/core/state/pruner"
@@ -164,6 +166,49 @@ as the backend data source, making this command a lot faster.
 
 The argument is interpreted as block number or hash. If none is provided, the latest
 block is used.
+`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export the state of a block into a verifiable snapshot archive",
+				ArgsUsage: "<filename> [<blockNum>]",
+				Action:    utils.MigrateFlags(exportSnapshot),
+				Category:  "MISCELLANEOUS COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.AncientFlag,
+					utils.BaklavaFlag,
+					utils.AlfajoresFlag,
+				},
+				Description: `
+geth snapshot export <filename> [<blockNum>]
+will export the state of the given block, read from the snapshot, together with
+the epoch headers and blocks needed to verify it against the Istanbul aggregated
+seals. The archive is checksummed, and compressed if the filename ends in .gz.
+
+The default export target is the HEAD block.
+`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Bootstrap an empty node from a snapshot archive",
+				ArgsUsage: "<filename> <blockHash>",
+				Action:    utils.MigrateFlags(importSnapshot),
+				Category:  "MISCELLANEOUS COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.AncientFlag,
+					utils.BaklavaFlag,
+					utils.AlfajoresFlag,
+				},
+				Description: `
+geth snapshot import <filename> <blockHash>
+will import a snapshot archive written by 'geth snapshot export' into a node
+holding nothing but the genesis block. The exported block must match the given
+trusted block hash; its header chain is verified from the genesis validator set
+through the epoch headers, and the imported state is checked against its root.
+
+The state snapshot is regenerated from the imported state once the node starts.
 `,
 			},
 		},
@@ -445,6 +490,64 @@ func traverseRawState(ctx *cli.Context) error {
 	return nil
 }
 
+func exportSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() < 1 || ctx.NArg() > 2 {
+		utils.Fatalf("This command requires one or two arguments.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, true)
+	headBlock := rawdb.ReadHeadBlock(chaindb)
+	if headBlock == nil {
+		log.Error("Failed to load head block")
+		return errors.New("no head block")
+	}
+	number := headBlock.NumberU64()
+	if ctx.NArg() == 2 {
+		n, err := strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		if err != nil {
+			log.Error("Failed to parse block number", "err", err)
+			return err
+		}
+		number = n
+	}
+	snaptree, err := snapshot.New(chaindb, trie.NewDatabase(chaindb), 256, headBlock.Root(), false, false, false)
+	if err != nil {
+		log.Error("Failed to open snapshot tree", "err", err)
+		return err
+	}
+	if err := utils.ExportSnapshot(chaindb, snaptree, number, ctx.Args().First()); err != nil {
+		log.Error("Failed to export snapshot", "err", err)
+		return err
+	}
+	return nil
+}
+
+func importSnapshot(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		utils.Fatalf("This command requires two arguments.")
+	}
+	trusted, err := parseRoot(ctx.Args().Get(1))
+	if err != nil {
+		log.Error("Failed to parse trusted block hash", "err", err)
+		return err
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, false)
+	if _, _, err := core.SetupGenesisBlock(chaindb, utils.MakeGenesis(ctx)); err != nil {
+		log.Error("Failed to set up genesis block", "err", err)
+		return err
+	}
+	if err := utils.ImportSnapshot(chaindb, ctx.Args().First(), trusted); err != nil {
+		log.Error("Failed to import snapshot", "err", err)
+		return err
+	}
+	return nil
+}
+
 func parseRoot(input string) (common.Hash, error) {
 	var h common.Hash
 	if err := h.UnmarshalText([]byte(input)); err != nil {
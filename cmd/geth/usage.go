@@ -130,6 +130,11 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			utils.HTTPRequestReadTimeout,
 			utils.HTTPRequestWriteTimeout,
 			utils.HTTPRequestIdleTimeout,
+			utils.HealthMinPeersFlag,
+			utils.HealthMaxSyncLagFlag,
+			utils.HealthSealWindowFlag,
+			utils.HealthProxyLinkFlag,
+			utils.HealthReplicaStateFlag,
 			utils.WSEnabledFlag,
 			utils.WSListenAddrFlag,
 			utils.WSPortFlag,
@@ -153,6 +158,7 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 			utils.DNSDiscoveryFlag,
 			utils.ListenPortFlag,
 			utils.MaxPeersFlag,
+			utils.ReservedPeersFlag,
 			utils.MaxPendingPeersFlag,
 			utils.NATFlag,
 			utils.NoDiscoverFlag,
@@ -242,6 +248,8 @@ var AppHelpFlagGroups = []flags.FlagGroup{
 		Name: "MISC",
 		Flags: []cli.Flag{
 			utils.VersionCheckFlag,
+			utils.VersionCheckPolicyFlag,
+			utils.VersionCheckGraceBlocksFlag,
 			utils.SnapshotFlag,
 			utils.BloomFilterSizeFlag,
 			cli.HelpFlag,
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 
+	"github.com/celo-org/celo-blockchain/core/rawdb"
 	"github.com/celo-org/celo-blockchain/internal/fileutils"
 	"github.com/celo-org/celo-blockchain/log"
 	"github.com/celo-org/celo-blockchain/mycelo/env"
@@ -41,6 +42,26 @@ var templateFlags = []cli.Flag{
 		Name:  "mnemonic",
 		Usage: "Mnemonic to generate accounts",
 	},
+	cli.IntFlag{
+		Name:  "proxies",
+		Usage: "Number of proxies per validator",
+	},
+	cli.IntFlag{
+		Name:  "replicas",
+		Usage: "Number of hot-standby replicas per validator",
+	},
+	cli.IntFlag{
+		Name:  "fullnodes",
+		Usage: "Number of full nodes, serving light clients",
+	},
+	cli.IntFlag{
+		Name:  "lightclients",
+		Usage: "Number of light clients",
+	},
+	cli.IntFlag{
+		Name:  "lightestclients",
+		Usage: "Number of lightest clients",
+	},
 }
 
 var buildpathFlag = cli.StringFlag{
@@ -79,6 +100,40 @@ var createGenesisFromConfigCommand = cli.Command{
 	Flags:     []cli.Flag{buildpathFlag},
 }
 
+var sourceDatadirFlag = cli.StringFlag{
+	Name:  "datadir",
+	Usage: "Data directory of the node whose state to fork",
+}
+
+var sourceBlockFlag = cli.Int64Flag{
+	Name:  "block",
+	Usage: "Block number whose state to fork (default: head block)",
+	Value: -1,
+}
+
+var createGenesisFromDatadirCommand = cli.Command{
+	Name:      "genesis-from-datadir",
+	Usage:     "Creates genesis.json and env.json forking the state of an existing chain",
+	ArgsUsage: "",
+	Action:    createGenesisFromDatadir,
+	Flags: append(
+		[]cli.Flag{sourceDatadirFlag, sourceBlockFlag, newEnvFlag},
+		templateFlags...),
+	Description: `
+Reads the state at the given block from the chaindata of an existing node, and
+writes a genesis holding it. The validators the election contract currently
+elects get local validator accounts authorized as signers, so that the number
+of validators in env.json matches the elected set, and the developer accounts
+are funded. The resulting environment can be started with validator-run.
+
+Account nonces are kept. The contract calls above run at the number of the
+forked block, but the new chain starts again at block 0, so block numbers and
+epochs stored by the contracts are ahead of the new chain.
+
+The source node must have stored the preimages of the state (--cache.preimages),
+since the genesis allocation is keyed by addresses and storage keys.`,
+}
+
 func readBuildPath(ctx *cli.Context) (string, error) {
 	buildpath := ctx.String(buildpathFlag.Name)
 	if buildpath == "" {
@@ -109,6 +164,21 @@ func envFromTemplate(ctx *cli.Context, workdir string) (*env.Environment, *genes
 	if ctx.IsSet("mnemonic") {
 		env.Accounts().Mnemonic = ctx.String("mnemonic")
 	}
+	if ctx.IsSet("proxies") {
+		env.Topology().ProxiesPerValidator = ctx.Int("proxies")
+	}
+	if ctx.IsSet("replicas") {
+		env.Topology().ReplicasPerValidator = ctx.Int("replicas")
+	}
+	if ctx.IsSet("fullnodes") {
+		env.Topology().NumFullNodes = ctx.Int("fullnodes")
+	}
+	if ctx.IsSet("lightclients") {
+		env.Topology().NumLightClients = ctx.Int("lightclients")
+	}
+	if ctx.IsSet("lightestclients") {
+		env.Topology().NumLightestClients = ctx.Int("lightestclients")
+	}
 
 	// Genesis config
 	genesisConfig, err := template.createGenesisConfig(env)
@@ -169,6 +239,52 @@ func createGenesis(ctx *cli.Context) error {
 	return env.SaveGenesis(generatedGenesis)
 }
 
+func createGenesisFromDatadir(ctx *cli.Context) error {
+	datadir := ctx.String(sourceDatadirFlag.Name)
+	if datadir == "" {
+		return fmt.Errorf("Missing --datadir flag")
+	}
+
+	var workdir string
+	var err error
+	if ctx.IsSet(newEnvFlag.Name) {
+		workdir = ctx.String(newEnvFlag.Name)
+	} else {
+		workdir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+
+	env, genesisConfig, err := envFromTemplate(ctx, workdir)
+	if err != nil {
+		return err
+	}
+
+	chaindata := path.Join(datadir, "celo", "chaindata")
+	db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 256, 256, path.Join(chaindata, "ancient"), "", true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	statedb, header, err := genesis.OpenForkState(db, ctx.Int64(sourceBlockFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open state in %s: %v", chaindata, err)
+	}
+	log.Info("Forking chain state", "number", header.Number, "hash", header.Hash(), "root", header.Root)
+
+	generatedGenesis, err := genesis.GenerateGenesisFromState(env.Accounts(), genesisConfig, statedb, header.Number)
+	if err != nil {
+		return err
+	}
+
+	if err = env.Save(); err != nil {
+		return err
+	}
+	return env.SaveGenesis(generatedGenesis)
+}
+
 func createGenesisConfig(ctx *cli.Context) error {
 	workdir, err := readWorkdir(ctx)
 	if err != nil {
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/mycelo/governance"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var governanceCommand = cli.Command{
+	Name:  "governance",
+	Usage: "Governance utility commands",
+	Subcommands: []cli.Command{
+		governanceSimulateCommand,
+	},
+}
+
+var governanceSimulateCommand = cli.Command{
+	Name:      "simulate",
+	Usage:     "Rehearses a governance proposal end to end on top of the env's genesis",
+	ArgsUsage: "[envdir]",
+	Description: `
+Runs a proposal through propose, upvote, approve, referendum and execute, on top of
+the genesis.json of the environment, using its developer, validator and validator
+group accounts. Time is warped forward between the proposal stages.
+
+The proposal is a JSON list of transactions, as used by celocli:
+
+  [{"contract": "Governance", "function": "setMinDeposit", "args": ["1000"], "value": "0"},
+   {"address": "0x...", "data": "0x...", "value": "0"}]
+
+The report holds every step, the referendum results, the state changes made by the
+execution and, if it fails, which of the proposal's transactions revert.`,
+	Action: governanceSimulate,
+	Flags: []cli.Flag{
+		proposalFlag,
+		descriptionURLFlag,
+		reportOutputFlag,
+	},
+}
+
+var (
+	proposalFlag = cli.StringFlag{
+		Name:  "proposal",
+		Usage: "JSON file with the transactions of the proposal",
+	}
+	descriptionURLFlag = cli.StringFlag{
+		Name:  "description",
+		Usage: "Description URL of the proposal",
+		Value: "https://github.com/celo-org/governance",
+	}
+	reportOutputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "File to write the JSON report to (default: stdout)",
+	}
+)
+
+func governanceSimulate(ctx *cli.Context) error {
+	if !ctx.IsSet(proposalFlag.Name) {
+		return fmt.Errorf("missing --%s flag", proposalFlag.Name)
+	}
+	env, err := readEnv(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := env.LoadGenesis()
+	if err != nil {
+		return fmt.Errorf("error reading the env genesis: %w", err)
+	}
+	txs, err := governance.LoadProposal(ctx.String(proposalFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	report, err := governance.Simulate(env.Accounts(), genesis, txs, ctx.String(descriptionURLFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output := ctx.String(reportOutputFlag.Name); output != "" {
+		if err := ioutil.WriteFile(output, raw, 0644); err != nil {
+			return err
+		}
+		log.Info("Governance simulation report written", "file", output)
+	} else {
+		fmt.Println(string(raw))
+	}
+
+	if !report.Executed {
+		return fmt.Errorf("proposal was not executed: %s", report.Error)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/celo-org/celo-blockchain/ethclient"
 	"github.com/celo-org/celo-blockchain/internal/fileutils"
@@ -55,11 +56,13 @@ func init() {
 		createGenesisCommand,
 		createGenesisConfigCommand,
 		createGenesisFromConfigCommand,
+		createGenesisFromDatadirCommand,
 		initValidatorsCommand,
 		runValidatorsCommand,
 		// initNodesCommand,
 		// runNodesCommand,
 		loadBotCommand,
+		governanceCommand,
 		envCommand,
 	}
 	cli.CommandHelpTemplate = flags.OriginCommandHelpTemplate
@@ -101,9 +104,25 @@ var loadTestMixFeeCurrencyFlag = cli.BoolFlag{
 	Usage: "Switches between paying for gas in cUSD and CELO",
 }
 
+var loadTestWorkloadFlag = cli.StringFlag{
+	Name:  "workload",
+	Usage: "Weighted mix of transactions to send, e.g. \"transfer-cusd=3,native=1,deploy=1\". Workloads: native, transfer-celo, transfer-cusd, transfer-ceur, deploy, storage, gatewayfee, dynamicfee (requires the espresso hardfork)",
+	Value: "transfer-cusd",
+}
+
+var loadTestDurationFlag = cli.DurationFlag{
+	Name:  "duration",
+	Usage: "How long to run the load test for. Set to 0 to run until interrupted.",
+}
+
+var loadTestReportFlag = cli.StringFlag{
+	Name:  "report",
+	Usage: "Path prefix of the report files (<report>.json and <report>.csv), defaults to loadbot-report in the env folder",
+}
+
 var initValidatorsCommand = cli.Command{
 	Name:      "validator-init",
-	Usage:     "Setup all validators nodes",
+	Usage:     "Setup all nodes of the environment (validators, proxies, replicas, full and light nodes)",
 	ArgsUsage: "[envdir]",
 	Action:    validatorInit,
 	Flags:     []cli.Flag{gethPathFlag},
@@ -111,7 +130,7 @@ var initValidatorsCommand = cli.Command{
 
 var runValidatorsCommand = cli.Command{
 	Name:      "validator-run",
-	Usage:     "Runs the testnet",
+	Usage:     "Runs all nodes of the testnet",
 	ArgsUsage: "[envdir]",
 	Action:    validatorRun,
 	Flags: []cli.Flag{
@@ -146,7 +165,10 @@ var loadBotCommand = cli.Command{
 		loadTestTPSFlag,
 		loadTestMaxPendingFlag,
 		loadTestSkipGasEstimationFlag,
-		loadTestMixFeeCurrencyFlag},
+		loadTestMixFeeCurrencyFlag,
+		loadTestWorkloadFlag,
+		loadTestDurationFlag,
+		loadTestReportFlag},
 }
 
 func readWorkdir(ctx *cli.Context) (string, error) {
@@ -273,11 +295,28 @@ func loadBot(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	workloads, err := loadbot.ParseWorkloads(ctx.String(loadTestWorkloadFlag.Name))
+	if err != nil {
+		return err
+	}
+	reportPath := ctx.String(loadTestReportFlag.Name)
+	if reportPath == "" {
+		workdir, err := readWorkdir(ctx)
+		if err != nil {
+			return err
+		}
+		reportPath = filepath.Join(workdir, "loadbot-report")
+	}
 
 	verbosityLevel := ctx.GlobalInt("verbosity")
 	verbose := verbosityLevel >= 4
 
-	runCtx := context.Background()
+	runCtx := withExitSignals(context.Background())
+	if duration := ctx.Duration(loadTestDurationFlag.Name); duration > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(runCtx, duration)
+		defer cancel()
+	}
 
 	var clients []*ethclient.Client
 	for i := 0; i < env.Accounts().NumValidators; i++ {
@@ -289,7 +328,7 @@ func loadBot(ctx *cli.Context) error {
 		clients = append(clients, client)
 	}
 
-	return loadbot.Start(runCtx, &loadbot.Config{
+	report, err := loadbot.Start(runCtx, &loadbot.Config{
 		ChainID:               env.Config.ChainID,
 		Accounts:              env.Accounts().DeveloperAccounts(),
 		Amount:                big.NewInt(10000000),
@@ -299,5 +338,18 @@ func loadBot(ctx *cli.Context) error {
 		MaxPending:            ctx.Uint64(loadTestMaxPendingFlag.Name),
 		SkipGasEstimation:     ctx.GlobalBool(loadTestSkipGasEstimationFlag.Name),
 		MixFeeCurrency:        ctx.GlobalBool(loadTestMixFeeCurrencyFlag.Name),
+		Workloads:             workloads,
+		DrainTimeout:          30 * time.Second,
 	})
+	if err != nil {
+		return err
+	}
+	if err := report.WriteJSON(reportPath + ".json"); err != nil {
+		return err
+	}
+	if err := report.WriteCSV(reportPath + ".csv"); err != nil {
+		return err
+	}
+	log.Info("Load bot report written", "json", reportPath+".json", "csv", reportPath+".csv")
+	return nil
 }
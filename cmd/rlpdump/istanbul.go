@@ -0,0 +1,480 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	istanbulCore "github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/validator"
+	"github.com/celo-org/celo-blockchain/core/types"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+// loadValidators reads a validator set from a JSON file, either an istanbul
+// snapshot as returned by istanbul.getSnapshot, a list of validators with their
+// BLS public keys, or a list of addresses. An empty path loads no validator set.
+func loadValidators(path string) (istanbul.ValidatorSet, error) {
+	if path == "" {
+		return nil, nil
+	}
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot struct {
+		Validators []istanbul.ValidatorData `json:"validators"`
+	}
+	if err := json.Unmarshal(blob, &snapshot); err == nil && snapshot.Validators != nil {
+		return validator.NewSet(snapshot.Validators), nil
+	}
+	var validators []istanbul.ValidatorData
+	if err := json.Unmarshal(blob, &validators); err == nil {
+		return validator.NewSet(validators), nil
+	}
+	var addresses []common.Address
+	if err := json.Unmarshal(blob, &addresses); err != nil {
+		return nil, fmt.Errorf("%s is neither an istanbul snapshot nor a list of validators", path)
+	}
+	validators = make([]istanbul.ValidatorData, len(addresses))
+	for i, address := range addresses {
+		validators[i].Address = address
+	}
+	return validator.NewSet(validators), nil
+}
+
+// hasBLSKeys reports whether the BLS public keys of the validators are known,
+// which is required to verify their seals.
+func hasBLSKeys(valSet istanbul.ValidatorSet) bool {
+	for _, v := range valSet.List() {
+		if v.BLSPublicKey() == (blscrypto.SerializedPublicKey{}) {
+			return false
+		}
+	}
+	return valSet.Size() > 0
+}
+
+// verification is the result of a signature verification, which is omitted
+// from the output when it could not be attempted.
+type verification struct {
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+func newVerification(err error) *verification {
+	if err != nil {
+		return &verification{Error: err.Error()}
+	}
+	return &verification{Valid: true}
+}
+
+type viewJSON struct {
+	Round    *big.Int `json:"round"`
+	Sequence *big.Int `json:"sequence"`
+}
+
+func newViewJSON(view *istanbul.View) *viewJSON {
+	if view == nil {
+		return nil
+	}
+	return &viewJSON{Round: view.Round, Sequence: view.Sequence}
+}
+
+type aggregatedSealJSON struct {
+	Bitmap       *hexutil.Big     `json:"bitmap"`
+	Signers      []int            `json:"signers"`
+	SignerAddrs  []common.Address `json:"signerAddresses,omitempty"`
+	Signature    hexutil.Bytes    `json:"signature"`
+	Round        *big.Int         `json:"round"`
+	Verification *verification    `json:"verification,omitempty"`
+}
+
+// bitmapIndexes returns the indexes of the bits set in a validator bitmap.
+func bitmapIndexes(bitmap *big.Int) []int {
+	indexes := []int{}
+	if bitmap == nil {
+		return indexes
+	}
+	for i := 0; i < bitmap.BitLen(); i++ {
+		if bitmap.Bit(i) == 1 {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// bitmapAddresses returns the addresses of the validators of the given indexes,
+// or nil if the validator set is unknown or doesn't match the indexes.
+func bitmapAddresses(indexes []int, valSet istanbul.ValidatorSet) []common.Address {
+	if valSet == nil {
+		return nil
+	}
+	addresses := make([]common.Address, len(indexes))
+	for i, index := range indexes {
+		if index >= valSet.Size() {
+			return nil
+		}
+		addresses[i] = valSet.GetByIndex(uint64(index)).Address()
+	}
+	return addresses
+}
+
+// newAggregatedSealJSON decodes an aggregated seal, and verifies it over the
+// given block hash when the BLS keys of the validator set are known.
+func newAggregatedSealJSON(seal *types.IstanbulAggregatedSeal, hash *common.Hash, valSet istanbul.ValidatorSet) *aggregatedSealJSON {
+	indexes := bitmapIndexes(seal.Bitmap)
+	out := &aggregatedSealJSON{
+		Bitmap:      (*hexutil.Big)(seal.Bitmap),
+		Signers:     indexes,
+		SignerAddrs: bitmapAddresses(indexes, valSet),
+		Signature:   seal.Signature,
+		Round:       seal.Round,
+	}
+	if hash != nil && len(seal.Signature) > 0 && valSet != nil && hasBLSKeys(valSet) {
+		out.Verification = newVerification(verifyAggregatedSeal(seal, *hash, indexes, valSet))
+	}
+	return out
+}
+
+func verifyAggregatedSeal(seal *types.IstanbulAggregatedSeal, hash common.Hash, indexes []int, valSet istanbul.ValidatorSet) error {
+	if seal.Round == nil {
+		return fmt.Errorf("missing round")
+	}
+	publicKeys := make([]blscrypto.SerializedPublicKey, 0, len(indexes))
+	for _, index := range indexes {
+		if index >= valSet.Size() {
+			return fmt.Errorf("signer %d out of the validator set of %d", index, valSet.Size())
+		}
+		publicKeys = append(publicKeys, valSet.GetByIndex(uint64(index)).BLSPublicKey())
+	}
+	if len(publicKeys) < valSet.MinQuorumSize() {
+		return fmt.Errorf("%d signers, below the quorum of %d", len(publicKeys), valSet.MinQuorumSize())
+	}
+	return blscrypto.VerifyAggregatedSignature(publicKeys, istanbulCore.PrepareCommittedSeal(hash, seal.Round), []byte{}, seal.Signature, false, false)
+}
+
+type istanbulExtraJSON struct {
+	Vanity                    hexutil.Bytes                   `json:"vanity"`
+	AddedValidators           []common.Address                `json:"addedValidators"`
+	AddedValidatorsPublicKeys []blscrypto.SerializedPublicKey `json:"addedValidatorsPublicKeys"`
+	RemovedValidators         *hexutil.Big                    `json:"removedValidators"`
+	RemovedIndexes            []int                           `json:"removedValidatorIndexes"`
+	RemovedAddrs              []common.Address                `json:"removedValidatorAddresses,omitempty"`
+	Seal                      hexutil.Bytes                   `json:"seal"`
+	AggregatedSeal            *aggregatedSealJSON             `json:"aggregatedSeal"`
+	ParentAggregatedSeal      *aggregatedSealJSON             `json:"parentAggregatedSeal"`
+}
+
+// newIstanbulExtraJSON decodes the extra data of a header. The aggregated seals
+// are verified when the header is known, the aggregated seal with the validator
+// set of the block and the parent aggregated seal with the one of its parent.
+func newIstanbulExtraJSON(extra []byte, header *types.Header, valSet, parentValSet istanbul.ValidatorSet) (*istanbulExtraJSON, error) {
+	if len(extra) < types.IstanbulExtraVanity {
+		return nil, types.ErrInvalidIstanbulHeaderExtra
+	}
+	var ist types.IstanbulExtra
+	if err := rlp.DecodeBytes(extra[types.IstanbulExtraVanity:], &ist); err != nil {
+		return nil, err
+	}
+	var hash, parentHash *common.Hash
+	if header != nil {
+		hash, parentHash = new(common.Hash), &header.ParentHash
+		*hash = header.Hash()
+	}
+	removed := bitmapIndexes(ist.RemovedValidators)
+	return &istanbulExtraJSON{
+		Vanity:                    extra[:types.IstanbulExtraVanity],
+		AddedValidators:           ist.AddedValidators,
+		AddedValidatorsPublicKeys: ist.AddedValidatorsPublicKeys,
+		RemovedValidators:         (*hexutil.Big)(ist.RemovedValidators),
+		RemovedIndexes:            removed,
+		RemovedAddrs:              bitmapAddresses(removed, parentValSet),
+		Seal:                      ist.Seal,
+		AggregatedSeal:            newAggregatedSealJSON(&ist.AggregatedSeal, hash, valSet),
+		ParentAggregatedSeal:      newAggregatedSealJSON(&ist.ParentAggregatedSeal, parentHash, parentValSet),
+	}, nil
+}
+
+type headerJSON struct {
+	Hash          common.Hash        `json:"hash"`
+	Header        *types.Header      `json:"header"`
+	IstanbulExtra *istanbulExtraJSON `json:"istanbulExtra"`
+	Proposer      *common.Address    `json:"proposer,omitempty"`
+	ProposerError string             `json:"proposerError,omitempty"`
+	InValidators  *bool              `json:"proposerInValidatorSet,omitempty"`
+}
+
+// decodeHeader decodes an RLP encoded header, recovers its proposer from the
+// seal and verifies its aggregated seals.
+func decodeHeader(data []byte, valSet, parentValSet istanbul.ValidatorSet) (interface{}, error) {
+	var header types.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return nil, err
+	}
+	extra, err := newIstanbulExtraJSON(header.Extra, &header, valSet, parentValSet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid istanbul extra: %v", err)
+	}
+	out := &headerJSON{Hash: header.Hash(), Header: &header, IstanbulExtra: extra}
+
+	// The proposer seal signs the header without seals, as in the istanbul engine
+	var sigHash common.Hash
+	hasher := sha3.NewLegacyKeccak256()
+	rlp.Encode(hasher, types.IstanbulFilteredHeader(&header, false))
+	hasher.Sum(sigHash[:0])
+	if proposer, err := istanbul.GetSignatureAddress(sigHash.Bytes(), extra.Seal); err != nil {
+		out.ProposerError = err.Error()
+	} else {
+		out.Proposer = &proposer
+		if valSet != nil {
+			_, v := valSet.GetByAddress(proposer)
+			inSet := v != nil
+			out.InValidators = &inSet
+		}
+	}
+	return out, nil
+}
+
+// decodeExtra decodes the extra data of a header, including its vanity.
+func decodeExtra(data []byte, valSet, parentValSet istanbul.ValidatorSet) (interface{}, error) {
+	return newIstanbulExtraJSON(data, nil, valSet, parentValSet)
+}
+
+// messageCodes are the names of the istanbul message codes.
+var messageCodes = map[uint64]string{
+	istanbul.MsgPreprepare:          "preprepare",
+	istanbul.MsgPrepare:             "prepare",
+	istanbul.MsgCommit:              "commit",
+	istanbul.MsgRoundChange:         "roundChange",
+	istanbul.QueryEnodeMsg:          "queryEnode",
+	istanbul.ValEnodesShareMsg:      "valEnodesShare",
+	istanbul.FwdMsg:                 "forward",
+	istanbul.DelegateSignMsg:        "delegateSign",
+	istanbul.ConsensusMsg:           "consensus",
+	istanbul.EnodeCertificateMsg:    "enodeCertificate",
+	istanbul.ValidatorHandshakeMsg:  "validatorHandshake",
+	istanbul.VersionCertificatesMsg: "versionCertificates",
+}
+
+type messageJSON struct {
+	Code         uint64          `json:"code"`
+	Type         string          `json:"type"`
+	Address      common.Address  `json:"address"`
+	Signature    hexutil.Bytes   `json:"signature"`
+	Signer       *common.Address `json:"signer,omitempty"`
+	Verification *verification   `json:"verification"`
+	InValidators *bool           `json:"senderInValidatorSet,omitempty"`
+	Payload      interface{}     `json:"payload"`
+}
+
+type proposalJSON struct {
+	Hash         common.Hash `json:"hash"`
+	Number       *big.Int    `json:"number"`
+	ParentHash   common.Hash `json:"parentHash"`
+	Transactions int         `json:"transactions"`
+}
+
+func newProposalJSON(proposal istanbul.Proposal) *proposalJSON {
+	block, ok := proposal.(*types.Block)
+	if !ok || block == nil {
+		return nil
+	}
+	return &proposalJSON{
+		Hash:         block.Hash(),
+		Number:       block.Number(),
+		ParentHash:   block.ParentHash(),
+		Transactions: len(block.Transactions()),
+	}
+}
+
+type subjectJSON struct {
+	View   *viewJSON   `json:"view"`
+	Digest common.Hash `json:"digest"`
+}
+
+func newSubjectJSON(subject *istanbul.Subject) *subjectJSON {
+	if subject == nil {
+		return nil
+	}
+	return &subjectJSON{View: newViewJSON(subject.View), Digest: subject.Digest}
+}
+
+// newMessageJSON decodes an istanbul message, verifies the signature of its
+// sender and, for commits, its committed seal when the BLS keys of the validator
+// set are known. The messages embedded in certificates are decoded recursively.
+func newMessageJSON(msg *istanbul.Message, valSet istanbul.ValidatorSet) *messageJSON {
+	out := &messageJSON{
+		Code:      msg.Code,
+		Type:      messageCodes[msg.Code],
+		Address:   msg.Address,
+		Signature: msg.Signature,
+	}
+	if payload, err := msg.PayloadNoSig(); err != nil {
+		out.Verification = newVerification(err)
+	} else if signer, err := istanbul.GetSignatureAddress(payload, msg.Signature); err != nil {
+		out.Verification = newVerification(err)
+	} else {
+		out.Signer = &signer
+		if signer != msg.Address {
+			out.Verification = newVerification(istanbul.ErrInvalidSigner)
+		} else {
+			out.Verification = newVerification(nil)
+		}
+	}
+	var sender istanbul.Validator
+	if valSet != nil {
+		_, sender = valSet.GetByAddress(msg.Address)
+		inSet := sender != nil
+		out.InValidators = &inSet
+	}
+
+	switch msg.Code {
+	case istanbul.MsgPreprepare:
+		if pp := msg.Preprepare(); pp != nil {
+			certificate := make([]*messageJSON, len(pp.RoundChangeCertificate.RoundChangeMessages))
+			for i := range pp.RoundChangeCertificate.RoundChangeMessages {
+				certificate[i] = newMessageJSON(&pp.RoundChangeCertificate.RoundChangeMessages[i], valSet)
+			}
+			out.Payload = map[string]interface{}{
+				"view":                   newViewJSON(pp.View),
+				"proposal":               newProposalJSON(pp.Proposal),
+				"roundChangeCertificate": certificate,
+			}
+		}
+	case istanbul.MsgPrepare:
+		out.Payload = newSubjectJSON(msg.Prepare())
+	case istanbul.MsgCommit:
+		if commit := msg.Commit(); commit != nil {
+			payload := map[string]interface{}{
+				"subject":               newSubjectJSON(commit.Subject),
+				"committedSeal":         hexutil.Bytes(commit.CommittedSeal),
+				"epochValidatorSetSeal": hexutil.Bytes(commit.EpochValidatorSetSeal),
+			}
+			if sender != nil && sender.BLSPublicKey() != (blscrypto.SerializedPublicKey{}) && commit.Subject != nil && commit.Subject.View != nil {
+				seal := istanbulCore.PrepareCommittedSeal(commit.Subject.Digest, commit.Subject.View.Round)
+				err := blscrypto.VerifySignature(sender.BLSPublicKey(), seal, []byte{}, commit.CommittedSeal, false, false)
+				payload["committedSealVerification"] = newVerification(err)
+			}
+			out.Payload = payload
+		}
+	case istanbul.MsgRoundChange:
+		if rc := msg.RoundChange(); rc != nil {
+			messages := make([]*messageJSON, len(rc.PreparedCertificate.PrepareOrCommitMessages))
+			for i := range rc.PreparedCertificate.PrepareOrCommitMessages {
+				messages[i] = newMessageJSON(&rc.PreparedCertificate.PrepareOrCommitMessages[i], valSet)
+			}
+			out.Payload = map[string]interface{}{
+				"view": newViewJSON(rc.View),
+				"preparedCertificate": map[string]interface{}{
+					"proposal": newProposalJSON(rc.PreparedCertificate.Proposal),
+					"messages": messages,
+				},
+			}
+		}
+	case istanbul.EnodeCertificateMsg:
+		if cert := msg.EnodeCertificate(); cert != nil {
+			out.Payload = map[string]interface{}{"enodeURL": cert.EnodeURL, "version": cert.Version}
+		}
+	case istanbul.FwdMsg:
+		if fwd := msg.ForwardMessage(); fwd != nil {
+			payload := map[string]interface{}{"code": fwd.Code, "destAddresses": fwd.DestAddresses}
+			var inner istanbul.Message
+			if err := inner.FromPayload(fwd.Msg, nil); err == nil {
+				payload["msg"] = newMessageJSON(&inner, valSet)
+			} else {
+				payload["msg"] = hexutil.Bytes(fwd.Msg)
+			}
+			out.Payload = payload
+		}
+	}
+	if out.Payload == nil {
+		out.Payload = hexutil.Bytes(msg.Msg)
+	}
+	return out
+}
+
+// decodeMessage decodes an RLP encoded istanbul message.
+func decodeMessage(data []byte, valSet, _ istanbul.ValidatorSet) (interface{}, error) {
+	var msg istanbul.Message
+	if err := msg.FromPayload(data, nil); err != nil {
+		return nil, err
+	}
+	return newMessageJSON(&msg, valSet), nil
+}
+
+// decodeEnodeCertMsg decodes an RLP encoded enode certificate message with its
+// destination addresses.
+func decodeEnodeCertMsg(data []byte, valSet, _ istanbul.ValidatorSet) (interface{}, error) {
+	var certMsg istanbul.EnodeCertMsg
+	if err := rlp.DecodeBytes(data, &certMsg); err != nil {
+		return nil, err
+	}
+	if certMsg.Msg == nil {
+		return nil, fmt.Errorf("missing enode certificate message")
+	}
+	if certMsg.Msg.Code != istanbul.EnodeCertificateMsg {
+		return nil, fmt.Errorf("message code %d is not an enode certificate", certMsg.Msg.Code)
+	}
+	return map[string]interface{}{
+		"msg":           newMessageJSON(certMsg.Msg, valSet),
+		"destAddresses": certMsg.DestAddresses,
+	}, nil
+}
+
+// typedDecoders are the decoders of the -type flag.
+var typedDecoders = map[string]func(data []byte, valSet, parentValSet istanbul.ValidatorSet) (interface{}, error){
+	"header":    decodeHeader,
+	"extra":     decodeExtra,
+	"message":   decodeMessage,
+	"enodecert": decodeEnodeCertMsg,
+}
+
+// dumpTyped decodes data as the given type and prints it as JSON.
+func dumpTyped(typ string, data []byte) error {
+	decode, ok := typedDecoders[typ]
+	if !ok {
+		return fmt.Errorf("unknown type %q, want header, extra, message or enodecert", typ)
+	}
+	valSet, err := loadValidators(*validatorsFile)
+	if err != nil {
+		return err
+	}
+	parentValSet := valSet
+	if *parentValidatorsFile != "" {
+		if parentValSet, err = loadValidators(*parentValidatorsFile); err != nil {
+			return err
+		}
+	}
+	out, err := decode(data, valSet, parentValSet)
+	if err != nil {
+		return err
+	}
+	blob, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(blob))
+	return nil
+}
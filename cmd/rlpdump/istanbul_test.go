@@ -0,0 +1,397 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	istanbulCore "github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/validator"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"github.com/celo-org/celo-bls-go/bls"
+)
+
+// testValidators holds the keys of a validator set, indexed as in the set.
+type testValidators struct {
+	set  istanbul.ValidatorSet
+	keys []*ecdsa.PrivateKey
+}
+
+func newTestValidators(t *testing.T, n int) *testValidators {
+	byAddress := make(map[common.Address]*ecdsa.PrivateKey)
+	data := make([]istanbul.ValidatorData, n)
+	for i := range data {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		blsKey, err := blscrypto.ECDSAToBLS(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKey, err := blscrypto.PrivateToPublic(blsKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data[i] = istanbul.ValidatorData{Address: crypto.PubkeyToAddress(key.PublicKey), BLSPublicKey: publicKey}
+		byAddress[data[i].Address] = key
+	}
+	vals := &testValidators{set: validator.NewSet(data)}
+	for _, v := range vals.set.List() {
+		vals.keys = append(vals.keys, byAddress[v.Address()])
+	}
+	return vals
+}
+
+// sign signs data as istanbul.GetSignatureAddress recovers it.
+func sign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	sig, err := crypto.Sign(crypto.Keccak256(data), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func signBLS(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	blsKey, err := blscrypto.ECDSAToBLS(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey, err := bls.DeserializePrivateKey(blsKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer privateKey.Destroy()
+	signature, err := privateKey.SignMessage(data, []byte{}, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer signature.Destroy()
+	serialized, err := signature.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serialized
+}
+
+// aggregatedSeal returns the seal of the validators of the given indexes over
+// a block hash.
+func (vals *testValidators) aggregatedSeal(t *testing.T, hash common.Hash, round *big.Int, indexes ...int) types.IstanbulAggregatedSeal {
+	bitmap := new(big.Int)
+	signatures := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		bitmap.SetBit(bitmap, index, 1)
+		signatures[i] = signBLS(t, vals.keys[index], istanbulCore.PrepareCommittedSeal(hash, round))
+	}
+	signature, err := blscrypto.AggregateSignatures(signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.IstanbulAggregatedSeal{Bitmap: bitmap, Signature: signature, Round: round}
+}
+
+func setExtra(t *testing.T, header *types.Header, extra *types.IstanbulExtra) {
+	payload, err := rlp.EncodeToBytes(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.Extra = append(make([]byte, types.IstanbulExtraVanity), payload...)
+}
+
+// sealedHeader returns a header sealed by the proposer of the given index and
+// committed by the validators of the given indexes.
+func (vals *testValidators) sealedHeader(t *testing.T, proposer int, committers ...int) *types.Header {
+	header := &types.Header{Number: big.NewInt(10), ParentHash: common.HexToHash("0x01"), Time: 1000}
+	extra := &types.IstanbulExtra{RemovedValidators: new(big.Int), Seal: []byte{}}
+	setExtra(t, header, extra)
+
+	extra.Seal = sign(t, vals.keys[proposer], rlpHash(t, types.IstanbulFilteredHeader(header, false)).Bytes())
+	setExtra(t, header, extra)
+	extra.AggregatedSeal = vals.aggregatedSeal(t, header.Hash(), big.NewInt(1), committers...)
+	setExtra(t, header, extra)
+	return header
+}
+
+func rlpHash(t *testing.T, x interface{}) common.Hash {
+	blob, err := rlp.EncodeToBytes(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.Keccak256Hash(blob)
+}
+
+func encode(t *testing.T, x interface{}) []byte {
+	blob, err := rlp.EncodeToBytes(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blob
+}
+
+func signMessage(t *testing.T, msg *istanbul.Message, key *ecdsa.PrivateKey) []byte {
+	if err := msg.Sign(func(data []byte) ([]byte, error) { return sign(t, key, data), nil }); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := msg.Payload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func checkVerification(t *testing.T, name string, v *verification, valid bool) {
+	t.Helper()
+	if v == nil {
+		t.Fatalf("%s: missing verification", name)
+	}
+	if v.Valid != valid {
+		t.Errorf("%s: valid %v, want %v (error %q)", name, v.Valid, valid, v.Error)
+	}
+	if valid != (v.Error == "") {
+		t.Errorf("%s: error %q with valid %v", name, v.Error, v.Valid)
+	}
+}
+
+func TestDecodeHeader(t *testing.T) {
+	vals := newTestValidators(t, 4)
+	header := vals.sealedHeader(t, 1, 0, 2, 3)
+
+	out, err := decodeHeader(encode(t, header), vals.set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := out.(*headerJSON)
+	if decoded.Hash != header.Hash() {
+		t.Errorf("hash %x, want %x", decoded.Hash, header.Hash())
+	}
+	if decoded.Proposer == nil || *decoded.Proposer != vals.set.GetByIndex(1).Address() {
+		t.Errorf("proposer %v, want %v (error %q)", decoded.Proposer, vals.set.GetByIndex(1).Address(), decoded.ProposerError)
+	}
+	if decoded.InValidators == nil || !*decoded.InValidators {
+		t.Errorf("proposer not in the validator set")
+	}
+	seal := decoded.IstanbulExtra.AggregatedSeal
+	if want := []int{0, 2, 3}; !equalInts(seal.Signers, want) {
+		t.Errorf("signers %v, want %v", seal.Signers, want)
+	}
+	for i, index := range []int{0, 2, 3} {
+		if seal.SignerAddrs[i] != vals.set.GetByIndex(uint64(index)).Address() {
+			t.Errorf("signer %d address %x, want %x", i, seal.SignerAddrs[i], vals.set.GetByIndex(uint64(index)).Address())
+		}
+	}
+	checkVerification(t, "aggregated seal", seal.Verification, true)
+	// The parent seal is empty, so can't be verified
+	if decoded.IstanbulExtra.ParentAggregatedSeal.Verification != nil {
+		t.Errorf("verified the empty parent aggregated seal")
+	}
+
+	// A seal of another validator set doesn't verify, and the proposer isn't in it
+	others := newTestValidators(t, 4)
+	out, err = decodeHeader(encode(t, header), others.set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = out.(*headerJSON)
+	if decoded.InValidators == nil || *decoded.InValidators {
+		t.Errorf("proposer in another validator set")
+	}
+	checkVerification(t, "aggregated seal of other validators", decoded.IstanbulExtra.AggregatedSeal.Verification, false)
+
+	// Below the quorum
+	out, err = decodeHeader(encode(t, vals.sealedHeader(t, 0, 0, 1)), vals.set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkVerification(t, "aggregated seal below quorum", out.(*headerJSON).IstanbulExtra.AggregatedSeal.Verification, false)
+
+	// Without the BLS keys, the seal is decoded but not verified
+	addresses := make([]istanbul.ValidatorData, vals.set.Size())
+	for i, v := range vals.set.List() {
+		addresses[i].Address = v.Address()
+	}
+	out, err = decodeHeader(encode(t, header), validator.NewSet(addresses), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := out.(*headerJSON).IstanbulExtra.AggregatedSeal.Verification; v != nil {
+		t.Errorf("verified the aggregated seal without BLS keys: %+v", v)
+	}
+}
+
+func TestDecodeHeaderBadSeal(t *testing.T) {
+	vals := newTestValidators(t, 4)
+	header := vals.sealedHeader(t, 1, 0, 1, 2)
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra.Seal = make([]byte, len(extra.Seal))
+	setExtra(t, header, extra)
+
+	out, err := decodeHeader(encode(t, header), vals.set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := out.(*headerJSON)
+	if decoded.Proposer != nil || decoded.ProposerError == "" {
+		t.Errorf("recovered proposer %v from an invalid seal", decoded.Proposer)
+	}
+}
+
+func TestDecodeCommit(t *testing.T) {
+	vals := newTestValidators(t, 4)
+	subject := &istanbul.Subject{
+		View:   &istanbul.View{Round: big.NewInt(2), Sequence: big.NewInt(10)},
+		Digest: common.HexToHash("0xabcd"),
+	}
+	sender := vals.set.GetByIndex(2).Address()
+
+	for _, tt := range []struct {
+		name      string
+		signer    int
+		sealer    int
+		valid     bool
+		validSeal bool
+	}{
+		{name: "valid", signer: 2, sealer: 2, valid: true, validSeal: true},
+		{name: "bad signature", signer: 0, sealer: 2, valid: false, validSeal: true},
+		{name: "bad committed seal", signer: 2, sealer: 3, valid: true, validSeal: false},
+	} {
+		seal := signBLS(t, vals.keys[tt.sealer], istanbulCore.PrepareCommittedSeal(subject.Digest, subject.View.Round))
+		msg := istanbul.NewCommitMessage(&istanbul.CommittedSubject{Subject: subject, CommittedSeal: seal}, sender)
+		out, err := decodeMessage(signMessage(t, msg, vals.keys[tt.signer]), vals.set, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		decoded := out.(*messageJSON)
+		if decoded.Type != "commit" {
+			t.Errorf("%s: type %q, want commit", tt.name, decoded.Type)
+		}
+		if want := crypto.PubkeyToAddress(vals.keys[tt.signer].PublicKey); decoded.Signer == nil || *decoded.Signer != want {
+			t.Errorf("%s: signer %v, want %x", tt.name, decoded.Signer, want)
+		}
+		checkVerification(t, tt.name, decoded.Verification, tt.valid)
+		if decoded.InValidators == nil || !*decoded.InValidators {
+			t.Errorf("%s: sender not in the validator set", tt.name)
+		}
+		payload := decoded.Payload.(map[string]interface{})
+		checkVerification(t, tt.name+" committed seal", payload["committedSealVerification"].(*verification), tt.validSeal)
+	}
+}
+
+func TestDecodePreprepare(t *testing.T) {
+	vals := newTestValidators(t, 4)
+	view := &istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(10)}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10), ParentHash: common.HexToHash("0x01")})
+
+	// A round change certificate with a valid message and a forged one
+	roundChange := &istanbul.RoundChange{View: view, PreparedCertificate: istanbul.EmptyPreparedCertificate()}
+	var certificate istanbul.RoundChangeCertificate
+	for _, signer := range []int{0, 1} {
+		msg := istanbul.NewRoundChangeMessage(roundChange, vals.set.GetByIndex(0).Address())
+		signMessage(t, msg, vals.keys[signer])
+		certificate.RoundChangeMessages = append(certificate.RoundChangeMessages, *msg)
+	}
+	preprepare := istanbul.NewPreprepareMessage(&istanbul.Preprepare{
+		View:                   view,
+		Proposal:               block,
+		RoundChangeCertificate: certificate,
+	}, vals.set.GetByIndex(3).Address())
+
+	out, err := decodeMessage(signMessage(t, preprepare, vals.keys[3]), vals.set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := out.(*messageJSON)
+	if decoded.Type != "preprepare" {
+		t.Errorf("type %q, want preprepare", decoded.Type)
+	}
+	checkVerification(t, "preprepare", decoded.Verification, true)
+
+	payload := decoded.Payload.(map[string]interface{})
+	if proposal := payload["proposal"].(*proposalJSON); proposal == nil || proposal.Hash != block.Hash() || proposal.Number.Cmp(block.Number()) != 0 {
+		t.Errorf("proposal %+v, want block %x", proposal, block.Hash())
+	}
+	if v := payload["view"].(*viewJSON); v.Round.Cmp(view.Round) != 0 || v.Sequence.Cmp(view.Sequence) != 0 {
+		t.Errorf("view %+v, want %v", v, view)
+	}
+	messages := payload["roundChangeCertificate"].([]*messageJSON)
+	if len(messages) != 2 {
+		t.Fatalf("%d round change messages, want 2", len(messages))
+	}
+	for i, valid := range []bool{true, false} {
+		if messages[i].Type != "roundChange" {
+			t.Errorf("round change %d: type %q", i, messages[i].Type)
+		}
+		checkVerification(t, "round change", messages[i].Verification, valid)
+	}
+}
+
+func TestDecodeEnodeCertMsg(t *testing.T) {
+	vals := newTestValidators(t, 2)
+	sender := vals.set.GetByIndex(0).Address()
+	dest := []common.Address{vals.set.GetByIndex(1).Address()}
+
+	msg := istanbul.NewEnodeCeritifcateMessage(&istanbul.EnodeCertificate{EnodeURL: "enode://abcd@127.0.0.1:30303", Version: 7}, sender)
+	signMessage(t, msg, vals.keys[0])
+	out, err := decodeEnodeCertMsg(encode(t, &istanbul.EnodeCertMsg{Msg: msg, DestAddresses: dest}), vals.set, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := out.(map[string]interface{})
+	if got := decoded["destAddresses"].([]common.Address); len(got) != 1 || got[0] != dest[0] {
+		t.Errorf("destination addresses %v, want %v", got, dest)
+	}
+	inner := decoded["msg"].(*messageJSON)
+	if inner.Type != "enodeCertificate" {
+		t.Errorf("type %q, want enodeCertificate", inner.Type)
+	}
+	if inner.Signer == nil || *inner.Signer != sender {
+		t.Errorf("signer %v, want %x", inner.Signer, sender)
+	}
+	checkVerification(t, "enode certificate", inner.Verification, true)
+	payload := inner.Payload.(map[string]interface{})
+	if payload["enodeURL"] != "enode://abcd@127.0.0.1:30303" || payload["version"] != uint(7) {
+		t.Errorf("payload %v", payload)
+	}
+
+	// Other messages aren't enode certificates
+	prepare := istanbul.NewPrepareMessage(&istanbul.Subject{View: &istanbul.View{Round: big.NewInt(0), Sequence: big.NewInt(1)}}, sender)
+	signMessage(t, prepare, vals.keys[0])
+	if _, err := decodeEnodeCertMsg(encode(t, &istanbul.EnodeCertMsg{Msg: prepare}), vals.set, nil); err == nil {
+		t.Errorf("decoded a prepare as an enode certificate")
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
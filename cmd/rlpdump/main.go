@@ -23,6 +23,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"strings"
 
@@ -33,15 +34,25 @@ var (
 	hexMode = flag.String("hex", "", "dump given hex data")
 	noASCII = flag.Bool("noascii", false, "don't print ASCII strings readably")
 	single  = flag.Bool("single", false, "print only the first element, discard the rest")
+
+	typeMode             = flag.String("type", "", "decode the data as an istanbul type: header, extra, message or enodecert")
+	validatorsFile       = flag.String("validators", "", "JSON file of the validator set used to show signers and verify seals")
+	parentValidatorsFile = flag.String("parentvalidators", "", "JSON file of the validator set of the parent block, if different")
 )
 
 func init() {
 	flag.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-noascii] [-hex <data>] [filename]")
+		fmt.Fprintln(os.Stderr, "Usage:", os.Args[0], "[-noascii] [-hex <data>] [-type <type> [-validators <file>]] [filename]")
 		flag.PrintDefaults()
 		fmt.Fprintln(os.Stderr, `
 Dumps RLP data from the given file in readable form.
-If the filename is omitted, data is read from stdin.`)
+If the filename is omitted, data is read from stdin.
+
+With -type, the data is decoded as an istanbul header, header extra data
+(including the vanity), consensus message or enode certificate message and
+printed as JSON. Signatures are verified, and the validator set given with
+-validators, such as the output of istanbul.getSnapshot, is used to resolve
+the signers of the bitmaps and to verify the BLS seals.`)
 	}
 }
 
@@ -74,6 +85,17 @@ func main() {
 		os.Exit(2)
 	}
 
+	if *typeMode != "" {
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			die(err)
+		}
+		if err := dumpTyped(*typeMode, data); err != nil {
+			die(err)
+		}
+		return
+	}
+
 	s := rlp.NewStream(r, 0)
 	for {
 		if err := dump(s, 0); err != nil {
@@ -45,6 +45,7 @@ import (
 	"github.com/celo-org/celo-blockchain/eth/downloader"
 	"github.com/celo-org/celo-blockchain/eth/ethconfig"
 	"github.com/celo-org/celo-blockchain/eth/tracers"
+	"github.com/celo-org/celo-blockchain/eth/versioncheck"
 	"github.com/celo-org/celo-blockchain/ethdb"
 	"github.com/celo-org/celo-blockchain/ethstats"
 	"github.com/celo-org/celo-blockchain/graphql"
@@ -523,6 +524,26 @@ var (
 		Usage: "Timeout in seconds for HTTP-RPC idle connections",
 		Value: int(rpc.DefaultHTTPTimeouts.IdleTimeout / time.Second),
 	}
+	HealthMinPeersFlag = cli.StringFlag{
+		Name:  "health.minpeers",
+		Usage: "Minimum peers by purpose for /health to pass, e.g. all=10,validator=1 (purposes: all, static, trusted, validator, proxy)",
+	}
+	HealthMaxSyncLagFlag = cli.Uint64Flag{
+		Name:  "health.maxsynclag",
+		Usage: "Maximum number of blocks behind the best peer for /ready to pass (0 = disabled)",
+	}
+	HealthSealWindowFlag = cli.Uint64Flag{
+		Name:  "health.sealwindow",
+		Usage: "Number of recent parent seals in which an elected validator must have signed at least once for /health to pass (0 = disabled)",
+	}
+	HealthProxyLinkFlag = cli.BoolFlag{
+		Name:  "health.proxylink",
+		Usage: "Fail /health if a proxy is disconnected from its proxied validator, or a proxied validator from all its proxies",
+	}
+	HealthReplicaStateFlag = cli.BoolFlag{
+		Name:  "health.replicastate",
+		Usage: "Fail /health if a validator's validating state doesn't match its primary/replica state",
+	}
 	GraphQLEnabledFlag = cli.BoolFlag{
 		Name:  "graphql",
 		Usage: "Enable GraphQL on the HTTP-RPC server. Note that GraphQL can only be started if an HTTP server is started as well.",
@@ -581,6 +602,10 @@ var (
 		Usage: "Maximum number of network peers (network disabled if set to 0)",
 		Value: node.DefaultConfig.P2P.MaxPeers,
 	}
+	ReservedPeersFlag = cli.StringFlag{
+		Name:  "reservedpeers",
+		Usage: "Peer slots reserved for peers by purpose, e.g. validator=20,proxy=2 (purposes: static, trusted, validator, proxy)",
+	}
 	MaxPendingPeersFlag = cli.IntFlag{
 		Name:  "maxpendpeers",
 		Usage: "Maximum number of pending connection attempts (defaults used if set to 0)",
@@ -634,6 +659,16 @@ var (
 		Name:  "disable-version-check",
 		Usage: "Disable version check. Use if the parameter is set erroneously",
 	}
+	VersionCheckPolicyFlag = cli.StringFlag{
+		Name:  "versioncheck.policy",
+		Usage: "Action once the client is older than the on-chain minimum version (warn, stop-validating, shutdown)",
+		Value: string(versioncheck.DefaultConfig.Policy),
+	}
+	VersionCheckGraceBlocksFlag = cli.Uint64Flag{
+		Name:  "versioncheck.graceblocks",
+		Usage: "Number of blocks to keep running after the on-chain minimum version is raised above ours",
+		Value: versioncheck.DefaultConfig.GraceBlocks,
+	}
 	DNSDiscoveryFlag = cli.StringFlag{
 		Name:  "discovery.dns",
 		Usage: "Sets DNS discovery entry points (use \"\" to disable DNS)",
@@ -990,6 +1025,43 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// parsePurposeCounts parses a comma separated list of purpose=count entries.
+// The purpose names are validated by the consumers of the counts.
+func parsePurposeCounts(flag, value string) map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range SplitAndTrim(value) {
+		parts := strings.Split(entry, "=")
+		if len(parts) != 2 {
+			Fatalf("Invalid --%s entry %q, want purpose=count", flag, entry)
+		}
+		count, err := strconv.Atoi(parts[1])
+		if err != nil {
+			Fatalf("Invalid --%s count %q: %v", flag, parts[1], err)
+		}
+		counts[parts[0]] = count
+	}
+	return counts
+}
+
+// setHealth applies the health check flags to the config.
+func setHealth(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalIsSet(HealthMinPeersFlag.Name) {
+		cfg.Health.MinPeers = parsePurposeCounts(HealthMinPeersFlag.Name, ctx.GlobalString(HealthMinPeersFlag.Name))
+	}
+	if ctx.GlobalIsSet(HealthMaxSyncLagFlag.Name) {
+		cfg.Health.MaxSyncLag = ctx.GlobalUint64(HealthMaxSyncLagFlag.Name)
+	}
+	if ctx.GlobalIsSet(HealthSealWindowFlag.Name) {
+		cfg.Health.SealWindow = ctx.GlobalUint64(HealthSealWindowFlag.Name)
+	}
+	if ctx.GlobalIsSet(HealthProxyLinkFlag.Name) {
+		cfg.Health.ProxyLink = ctx.GlobalBool(HealthProxyLinkFlag.Name)
+	}
+	if ctx.GlobalIsSet(HealthReplicaStateFlag.Name) {
+		cfg.Health.ReplicaState = ctx.GlobalBool(HealthReplicaStateFlag.Name)
+	}
+}
+
 // setGraphQL creates the GraphQL listener interface string from the set
 // command line flags, returning empty if the GraphQL endpoint is disabled.
 func setGraphQL(ctx *cli.Context, cfg *node.Config) {
@@ -1261,6 +1333,10 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	}
 	log.Info("Maximum peer count", "ETH", ethPeers, "LES", lightPeers, "total", cfg.MaxPeers)
 
+	if ctx.GlobalIsSet(ReservedPeersFlag.Name) {
+		cfg.ReservedPeers = parsePurposeCounts(ReservedPeersFlag.Name, ctx.GlobalString(ReservedPeersFlag.Name))
+	}
+
 	if ctx.GlobalIsSet(MaxPendingPeersFlag.Name) {
 		cfg.MaxPendingPeers = ctx.GlobalInt(MaxPendingPeersFlag.Name)
 	}
@@ -1308,6 +1384,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	SetP2PConfig(ctx, &cfg.P2P)
 	setIPC(ctx, cfg)
 	setHTTP(ctx, cfg)
+	setHealth(ctx, cfg)
 	setGraphQL(ctx, cfg)
 	setWS(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
@@ -1889,6 +1966,21 @@ func RegisterEthStatsService(stack *node.Node, backend ethapi.Backend, url strin
 	}
 }
 
+// RegisterVersionCheckService configures the minimum client version check and adds it to the given node.
+func RegisterVersionCheckService(ctx *cli.Context, stack *node.Node, backend ethapi.Backend) {
+	policy, err := versioncheck.ParsePolicy(ctx.GlobalString(VersionCheckPolicyFlag.Name))
+	if err != nil {
+		Fatalf("Invalid --%s: %v", VersionCheckPolicyFlag.Name, err)
+	}
+	config := versioncheck.Config{
+		Policy:      policy,
+		GraceBlocks: ctx.GlobalUint64(VersionCheckGraceBlocksFlag.Name),
+	}
+	if err := versioncheck.New(stack, backend, backend.Engine(), config); err != nil {
+		Fatalf("Failed to register the version check service: %v", err)
+	}
+}
+
 // RegisterGraphQLService is a utility function to construct a new service and register it against a node.
 func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, cfg node.Config) {
 	if err := graphql.New(stack, backend, cfg.GraphQLCors, cfg.GraphQLVirtualHosts); err != nil {
@@ -0,0 +1,612 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	istanbulCore "github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/validator"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state/snapshot"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"github.com/celo-org/celo-blockchain/trie"
+)
+
+const (
+	// snapshotArchiveMagic identifies the files written by ExportSnapshot.
+	snapshotArchiveMagic = "celo-snapshot"
+
+	// snapshotArchiveVersion is the version of the archive layout.
+	snapshotArchiveVersion = 1
+)
+
+// emptyCodeHash is the known hash of the empty EVM bytecode.
+var emptyCodeHash = crypto.Keccak256(nil)
+
+// Kinds of the records following the manifest of a snapshot archive.
+const (
+	archiveEpochHeader byte = iota // Last header of an epoch, carrying a validator set diff
+	archiveBlock                   // Header and body of a block following the last epoch header
+	archiveAccount                 // Account of the exported state, in hash order
+	archiveStorage                 // Storage slot of the preceding account, in hash order
+	archiveCode                    // Contract code, preceding the first account using it
+	archiveEnd                     // Checksum of all the preceding records
+)
+
+// snapshotManifest is the first record of a snapshot archive, describing the
+// exported state and the chain it belongs to.
+type snapshotManifest struct {
+	Magic   string
+	Version uint64
+	Genesis common.Hash // Hash of the genesis block of the network
+	Epoch   uint64      // Istanbul epoch size of the network
+	Number  uint64      // Number of the block the state belongs to
+	Hash    common.Hash // Hash of the block the state belongs to
+	Root    common.Hash // State root of the block
+}
+
+// archiveRecord is a single typed entry of a snapshot archive.
+type archiveRecord struct {
+	Kind byte
+	Data []byte
+}
+
+// archiveBlockData is the payload of an archiveBlock record.
+type archiveBlockData struct {
+	Header *types.Header
+	Body   *types.Body
+}
+
+// archiveAccountData is the payload of an archiveAccount record.
+type archiveAccountData struct {
+	Hash    common.Hash
+	Account []byte // Account in the slim snapshot encoding
+}
+
+// archiveSlotData is the payload of an archiveStorage record.
+type archiveSlotData struct {
+	Hash  common.Hash
+	Value []byte
+}
+
+// archiveCodeData is the payload of an archiveCode record.
+type archiveCodeData struct {
+	Hash common.Hash
+	Code []byte
+}
+
+// archiveWriter encodes the records of a snapshot archive, keeping a running
+// checksum of everything written.
+type archiveWriter struct {
+	w      io.Writer
+	hasher crypto.KeccakState
+}
+
+func (w *archiveWriter) write(v interface{}) error {
+	blob, err := rlp.EncodeToBytes(v)
+	if err != nil {
+		return err
+	}
+	w.hasher.Write(blob)
+	_, err = w.w.Write(blob)
+	return err
+}
+
+func (w *archiveWriter) record(kind byte, data interface{}) error {
+	payload, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		return err
+	}
+	return w.write(&archiveRecord{Kind: kind, Data: payload})
+}
+
+// ExportSnapshot writes the state of the given block, read from the snapshot
+// tree, into the specified file. The state is preceded by the chain segment
+// needed to verify the block: the last header of every epoch, whose validator
+// set diffs lead up to the block's validators, and all the blocks since the
+// last epoch header. Files ending in .gz are compressed.
+func ExportSnapshot(db ethdb.Database, snaptree *snapshot.Tree, number uint64, fn string) error {
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	config := rawdb.ReadChainConfig(db, genesis)
+	if config == nil || config.Istanbul == nil {
+		return errors.New("missing istanbul chain config")
+	}
+	if number == 0 {
+		return errors.New("cannot export the genesis state")
+	}
+	hash := rawdb.ReadCanonicalHash(db, number)
+	header := rawdb.ReadHeader(db, hash, number)
+	if header == nil {
+		return fmt.Errorf("missing header #%d", number)
+	}
+	if snaptree.Snapshot(header.Root) == nil {
+		return fmt.Errorf("no snapshot available for the state of block #%d", number)
+	}
+	log.Info("Exporting state snapshot", "file", fn, "number", number, "hash", hash, "root", header.Root)
+
+	// Open the file handle and potentially wrap with a gzip stream
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var writer io.Writer = fh
+	if strings.HasSuffix(fn, ".gz") {
+		writer = gzip.NewWriter(writer)
+		defer writer.(*gzip.Writer).Close()
+	}
+	w := &archiveWriter{w: writer, hasher: crypto.NewKeccakState()}
+	if err := w.write(&snapshotManifest{
+		Magic:   snapshotArchiveMagic,
+		Version: snapshotArchiveVersion,
+		Genesis: genesis,
+		Epoch:   config.Istanbul.Epoch,
+		Number:  number,
+		Hash:    hash,
+		Root:    header.Root,
+	}); err != nil {
+		return err
+	}
+	// Export the epoch headers and the blocks of the current epoch. They are
+	// read through the accessors, which fall back to the ancient store.
+	var (
+		epoch     = config.Istanbul.Epoch
+		lastEpoch = (number - 1) / epoch * epoch
+	)
+	for n := epoch; n <= lastEpoch; n += epoch {
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, n), n)
+		if header == nil {
+			return fmt.Errorf("missing epoch header #%d", n)
+		}
+		if err := w.record(archiveEpochHeader, header); err != nil {
+			return err
+		}
+	}
+	for n := lastEpoch + 1; n <= number; n++ {
+		hash := rawdb.ReadCanonicalHash(db, n)
+		header, body := rawdb.ReadHeader(db, hash, n), rawdb.ReadBody(db, hash, n)
+		if header == nil || body == nil {
+			return fmt.Errorf("missing block #%d", n)
+		}
+		if err := w.record(archiveBlock, &archiveBlockData{Header: header, Body: body}); err != nil {
+			return err
+		}
+	}
+	// Export the state from the snapshot, storage slots following their account
+	accIt, err := snaptree.AccountIterator(header.Root, common.Hash{})
+	if err != nil {
+		return err
+	}
+	defer accIt.Release()
+
+	var (
+		start    = time.Now()
+		logged   = time.Now()
+		accounts uint64
+		slots    uint64
+		codes    = make(map[common.Hash]struct{})
+	)
+	for accIt.Next() {
+		account, err := snapshot.FullAccount(accIt.Account())
+		if err != nil {
+			return err
+		}
+		if codeHash := common.BytesToHash(account.CodeHash); !bytes.Equal(account.CodeHash, emptyCodeHash) {
+			if _, ok := codes[codeHash]; !ok {
+				code := rawdb.ReadCode(db, codeHash)
+				if len(code) == 0 {
+					return fmt.Errorf("missing code %x", codeHash)
+				}
+				if err := w.record(archiveCode, &archiveCodeData{Hash: codeHash, Code: code}); err != nil {
+					return err
+				}
+				codes[codeHash] = struct{}{}
+			}
+		}
+		if err := w.record(archiveAccount, &archiveAccountData{Hash: accIt.Hash(), Account: accIt.Account()}); err != nil {
+			return err
+		}
+		accounts++
+
+		if common.BytesToHash(account.Root) != types.EmptyRootHash {
+			stIt, err := snaptree.StorageIterator(header.Root, accIt.Hash(), common.Hash{})
+			if err != nil {
+				return err
+			}
+			for stIt.Next() {
+				if err := w.record(archiveStorage, &archiveSlotData{Hash: stIt.Hash(), Value: stIt.Slot()}); err != nil {
+					stIt.Release()
+					return err
+				}
+				slots++
+			}
+			err = stIt.Error()
+			stIt.Release()
+			if err != nil {
+				return err
+			}
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Exporting state snapshot", "at", accIt.Hash(), "accounts", accounts, "slots", slots,
+				"elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := accIt.Error(); err != nil {
+		return err
+	}
+	if err := w.record(archiveEnd, common.BytesToHash(w.hasher.Sum(nil))); err != nil {
+		return err
+	}
+	log.Info("Exported state snapshot", "file", fn, "accounts", accounts, "slots", slots, "codes", len(codes),
+		"elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// ImportSnapshot imports a snapshot archive written by ExportSnapshot into a
+// database holding nothing but the genesis block of the same network. The
+// chain segment is verified against the Istanbul aggregated seals, and the
+// state rebuilt from the archive against the root of the exported block,
+// whose hash needs to match the trusted one.
+//
+// The chain segment is only written once everything is verified, along with
+// the head markers. The state doesn't fit in memory, so its trie nodes and
+// codes are written while importing: on failure they're left unreferenced,
+// and are overwritten with the same content by a later import, as they're
+// keyed by their hash.
+func ImportSnapshot(db ethdb.Database, fn string, trusted common.Hash) error {
+	log.Info("Importing state snapshot", "file", fn, "trusted", trusted)
+
+	// Open the file handle and potentially unwrap the gzip stream
+	fh, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var reader io.Reader = fh
+	if strings.HasSuffix(fn, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			return err
+		}
+	}
+	var (
+		stream   = rlp.NewStream(reader, 0)
+		hasher   = crypto.NewKeccakState()
+		checksum common.Hash // Checksum of all the records preceding the last one read
+	)
+	// readRaw reads the next record, feeding it into the checksum
+	readRaw := func() ([]byte, error) {
+		blob, err := stream.Raw()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("truncated snapshot archive")
+			}
+			return nil, err
+		}
+		checksum = common.BytesToHash(hasher.Sum(nil))
+		hasher.Write(blob)
+		return blob, nil
+	}
+	blob, err := readRaw()
+	if err != nil {
+		return err
+	}
+	var manifest snapshotManifest
+	if err := rlp.DecodeBytes(blob, &manifest); err != nil {
+		return fmt.Errorf("invalid snapshot manifest: %v", err)
+	}
+	if manifest.Magic != snapshotArchiveMagic || manifest.Version != snapshotArchiveVersion {
+		return fmt.Errorf("unsupported snapshot archive %q version %d", manifest.Magic, manifest.Version)
+	}
+	if manifest.Hash != trusted {
+		return fmt.Errorf("snapshot of block %x does not match the trusted block %x", manifest.Hash, trusted)
+	}
+	// Ensure the database belongs to the same network and is empty
+	genesisHash := rawdb.ReadCanonicalHash(db, 0)
+	if genesisHash != manifest.Genesis {
+		return fmt.Errorf("genesis mismatch: database %x, snapshot %x", genesisHash, manifest.Genesis)
+	}
+	if head := rawdb.ReadHeadHeaderHash(db); head != genesisHash {
+		return errors.New("database already contains blocks beyond the genesis")
+	}
+	config := rawdb.ReadChainConfig(db, genesisHash)
+	if config == nil || config.Istanbul == nil || config.Istanbul.Epoch != manifest.Epoch {
+		return errors.New("snapshot epoch size does not match the chain config")
+	}
+	genesis := rawdb.ReadHeader(db, genesisHash, 0)
+	if genesis == nil {
+		return errors.New("missing genesis header")
+	}
+	validators, err := applyValidatorSetDiff(validator.NewSet(nil), genesis)
+	if err != nil {
+		return err
+	}
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		batch  = db.NewBatch() // State trie nodes and codes, flushed while importing
+		chain  = db.NewBatch() // Chain segment and head markers, written once verified
+		parent = genesis       // Last header of the chain segment imported so far
+		head   *types.Header
+
+		accountTrie = trie.NewStackTrie(batch)
+		storageTrie *trie.StackTrie
+		storageRoot common.Hash // Storage root expected for the account being imported
+		codes       = make(map[common.Hash]struct{})
+		accounts    uint64
+		slots       uint64
+		lastAccount common.Hash
+		lastSlot    common.Hash
+	)
+	// closeStorage verifies the storage of the last imported account
+	closeStorage := func() error {
+		if storageTrie == nil {
+			return nil
+		}
+		if root, err := storageTrie.Commit(); err != nil {
+			return err
+		} else if root != storageRoot {
+			return fmt.Errorf("storage root mismatch for account %x: have %x, want %x", lastAccount, root, storageRoot)
+		}
+		storageTrie = nil
+		return nil
+	}
+	flush := func(force bool) error {
+		if batch.ValueSize() < ethdb.IdealBatchSize && !force {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	}
+	for {
+		blob, err := readRaw()
+		if err != nil {
+			return err
+		}
+		var record archiveRecord
+		if err := rlp.DecodeBytes(blob, &record); err != nil {
+			return err
+		}
+		switch record.Kind {
+		case archiveEpochHeader:
+			var header types.Header
+			if err := rlp.DecodeBytes(record.Data, &header); err != nil {
+				return err
+			}
+			if head != nil || accounts > 0 {
+				return errors.New("unexpected epoch header")
+			}
+			if number := header.Number.Uint64(); number != parent.Number.Uint64()+manifest.Epoch {
+				return fmt.Errorf("unexpected epoch header #%d after #%d", number, parent.Number)
+			}
+			if err := verifyAggregatedSeal(&header, validators); err != nil {
+				return fmt.Errorf("epoch header #%d: %v", header.Number, err)
+			}
+			if validators, err = applyValidatorSetDiff(validators, &header); err != nil {
+				return fmt.Errorf("epoch header #%d: %v", header.Number, err)
+			}
+			writeArchivedHeader(chain, &header)
+			parent = &header
+
+		case archiveBlock:
+			var block archiveBlockData
+			if err := rlp.DecodeBytes(record.Data, &block); err != nil {
+				return err
+			}
+			if accounts > 0 || block.Header == nil || block.Body == nil {
+				return errors.New("unexpected block")
+			}
+			header := block.Header
+			if header.ParentHash != parent.Hash() || header.Number.Uint64() != parent.Number.Uint64()+1 {
+				return fmt.Errorf("block #%d does not extend #%d", header.Number, parent.Number)
+			}
+			if err := verifyAggregatedSeal(header, validators); err != nil {
+				return fmt.Errorf("block #%d: %v", header.Number, err)
+			}
+			if hash := types.DeriveSha(types.Transactions(block.Body.Transactions), trie.NewStackTrie(nil)); hash != header.TxHash {
+				return fmt.Errorf("block #%d: transaction root mismatch", header.Number)
+			}
+			writeArchivedHeader(chain, header)
+			rawdb.WriteBody(chain, header.Hash(), header.Number.Uint64(), block.Body)
+			parent, head = header, header
+
+		case archiveCode:
+			var code archiveCodeData
+			if err := rlp.DecodeBytes(record.Data, &code); err != nil {
+				return err
+			}
+			if crypto.Keccak256Hash(code.Code) != code.Hash {
+				return fmt.Errorf("code hash mismatch for %x", code.Hash)
+			}
+			rawdb.WriteCode(batch, code.Hash, code.Code)
+			codes[code.Hash] = struct{}{}
+
+		case archiveAccount:
+			var account archiveAccountData
+			if err := rlp.DecodeBytes(record.Data, &account); err != nil {
+				return err
+			}
+			if head == nil || head.Hash() != manifest.Hash {
+				return errors.New("state precedes the exported block")
+			}
+			if accounts > 0 && bytes.Compare(account.Hash[:], lastAccount[:]) <= 0 {
+				return fmt.Errorf("account %x out of order", account.Hash)
+			}
+			if err := closeStorage(); err != nil {
+				return err
+			}
+			full, err := snapshot.FullAccount(account.Account)
+			if err != nil {
+				return err
+			}
+			if codeHash := common.BytesToHash(full.CodeHash); !bytes.Equal(full.CodeHash, emptyCodeHash) {
+				if _, ok := codes[codeHash]; !ok && len(rawdb.ReadCode(db, codeHash)) == 0 {
+					return fmt.Errorf("missing code %x for account %x", codeHash, account.Hash)
+				}
+			}
+			leaf, err := snapshot.FullAccountRLP(account.Account)
+			if err != nil {
+				return err
+			}
+			if err := accountTrie.TryUpdate(account.Hash[:], leaf); err != nil {
+				return err
+			}
+			if storageRoot = common.BytesToHash(full.Root); storageRoot != types.EmptyRootHash {
+				storageTrie = trie.NewStackTrie(batch)
+			}
+			lastAccount, lastSlot = account.Hash, common.Hash{}
+			accounts++
+
+		case archiveStorage:
+			var slot archiveSlotData
+			if err := rlp.DecodeBytes(record.Data, &slot); err != nil {
+				return err
+			}
+			if storageTrie == nil {
+				return fmt.Errorf("unexpected storage slot %x", slot.Hash)
+			}
+			if lastSlot != (common.Hash{}) && bytes.Compare(slot.Hash[:], lastSlot[:]) <= 0 {
+				return fmt.Errorf("storage slot %x out of order", slot.Hash)
+			}
+			if err := storageTrie.TryUpdate(slot.Hash[:], slot.Value); err != nil {
+				return err
+			}
+			lastSlot = slot.Hash
+			slots++
+
+		case archiveEnd:
+			var want common.Hash
+			if err := rlp.DecodeBytes(record.Data, &want); err != nil {
+				return err
+			}
+			if checksum != want {
+				return fmt.Errorf("snapshot archive checksum mismatch: have %x, want %x", checksum, want)
+			}
+			if head == nil || head.Hash() != manifest.Hash {
+				return errors.New("snapshot archive misses the exported block")
+			}
+			if err := closeStorage(); err != nil {
+				return err
+			}
+			root, err := accountTrie.Commit()
+			if err != nil {
+				return err
+			}
+			if root != manifest.Root || root != head.Root {
+				return fmt.Errorf("state root mismatch: have %x, want %x", root, head.Root)
+			}
+			if err := flush(true); err != nil {
+				return err
+			}
+			// Everything verified, write the chain segment with the exported
+			// block as the chain head
+			rawdb.WriteHeadHeaderHash(chain, head.Hash())
+			rawdb.WriteHeadBlockHash(chain, head.Hash())
+			rawdb.WriteHeadFastBlockHash(chain, head.Hash())
+			if err := chain.Write(); err != nil {
+				return err
+			}
+
+			log.Info("Imported state snapshot", "number", head.Number, "hash", head.Hash(), "root", root,
+				"accounts", accounts, "slots", slots, "codes", len(codes), "elapsed", common.PrettyDuration(time.Since(start)))
+			return nil
+
+		default:
+			return fmt.Errorf("unknown snapshot record kind %d", record.Kind)
+		}
+		if err := flush(false); err != nil {
+			return err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Importing state snapshot", "accounts", accounts, "slots", slots, "at", lastAccount,
+				"elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+}
+
+// applyValidatorSetDiff applies the validator set diff of an epoch header to
+// a copy of the given validator set.
+func applyValidatorSetDiff(validators istanbul.ValidatorSet, header *types.Header) (istanbul.ValidatorSet, error) {
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return nil, err
+	}
+	added, err := istanbul.CombineIstanbulExtraToValidatorData(extra.AddedValidators, extra.AddedValidatorsPublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	validators = validators.Copy()
+	if !validators.RemoveValidators(extra.RemovedValidators) || !validators.AddValidators(added) {
+		return nil, errors.New("invalid validator set diff")
+	}
+	return validators, nil
+}
+
+// verifyAggregatedSeal checks that the header is sealed by a quorum of the
+// given validator set.
+func verifyAggregatedSeal(header *types.Header, validators istanbul.ValidatorSet) error {
+	extra, err := types.ExtractIstanbulExtra(header)
+	if err != nil {
+		return err
+	}
+	seal := extra.AggregatedSeal
+	if len(seal.Signature) != types.IstanbulExtraBlsSignature || seal.Bitmap == nil || seal.Round == nil {
+		return errors.New("invalid aggregated seal")
+	}
+	var publicKeys []blscrypto.SerializedPublicKey
+	for i := 0; i < validators.Size(); i++ {
+		if seal.Bitmap.Bit(i) == 1 {
+			publicKeys = append(publicKeys, validators.GetByIndex(uint64(i)).BLSPublicKey())
+		}
+	}
+	if len(publicKeys) < validators.MinQuorumSize() {
+		return fmt.Errorf("insufficient seals: have %d, want %d", len(publicKeys), validators.MinQuorumSize())
+	}
+	msg := istanbulCore.PrepareCommittedSeal(header.Hash(), seal.Round)
+	return blscrypto.VerifyAggregatedSignature(publicKeys, msg, []byte{}, seal.Signature, false, false)
+}
+
+// writeArchivedHeader writes a verified header as part of the canonical chain.
+// Istanbul blocks have a difficulty of one, so the total difficulty is derived
+// from the number as done by the chain, without the preceding headers.
+func writeArchivedHeader(db ethdb.KeyValueWriter, header *types.Header) {
+	hash, number := header.Hash(), header.Number.Uint64()
+	rawdb.WriteHeader(db, header)
+	rawdb.WriteCanonicalHash(db, hash, number)
+	rawdb.WriteTd(db, hash, number, types.NewBlockWithHeader(header).TotalDifficulty())
+}
@@ -0,0 +1,321 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/accounts"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/backend"
+	istanbulCore "github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/state/snapshot"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/params"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"github.com/celo-org/celo-blockchain/trie"
+)
+
+var (
+	snapshotTestAccount  = common.HexToAddress("0x1000")
+	snapshotTestContract = common.HexToAddress("0x2000")
+	snapshotTestCode     = []byte{0x60, 0x00, 0x60, 0x00, 0xf3}
+	snapshotTestSlot     = common.HexToHash("0x01")
+	snapshotTestValue    = common.HexToHash("0xc0ffee")
+)
+
+// newSnapshotTestGenesis creates a genesis with a small epoch, sealed by the
+// first three of the given validator keys.
+func newSnapshotTestGenesis(t *testing.T, keys []*ecdsa.PrivateKey) *core.Genesis {
+	config := *params.IstanbulTestChainConfig
+	config.Istanbul = &params.IstanbulConfig{Epoch: 4, ProposerPolicy: 0, RequestTimeout: 1000, BlockPeriod: 1}
+
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc: core.GenesisAlloc{
+			snapshotTestAccount: {Balance: big.NewInt(1000000)},
+			snapshotTestContract: {
+				Balance: big.NewInt(1),
+				Code:    snapshotTestCode,
+				Storage: map[common.Hash]common.Hash{snapshotTestSlot: snapshotTestValue},
+			},
+		},
+	}
+	backend.AppendValidatorsToGenesisBlock(genesis, snapshotTestValidators(t, keys[:3]))
+	return genesis
+}
+
+func snapshotTestValidators(t *testing.T, keys []*ecdsa.PrivateKey) []istanbul.ValidatorData {
+	var validators []istanbul.ValidatorData
+	for _, key := range keys {
+		blsKey, err := blscrypto.ECDSAToBLS(key)
+		if err != nil {
+			t.Fatalf("failed to derive BLS key: %v", err)
+		}
+		pubKey, err := blscrypto.PrivateToPublic(blsKey)
+		if err != nil {
+			t.Fatalf("failed to derive BLS public key: %v", err)
+		}
+		validators = append(validators, istanbul.ValidatorData{Address: crypto.PubkeyToAddress(key.PublicKey), BLSPublicKey: pubKey})
+	}
+	return validators
+}
+
+// sealSnapshotTestHeader creates a child header of parent carrying the given
+// validator set diff, sealed by all of the signers.
+func sealSnapshotTestHeader(t *testing.T, parent *types.Header, signers []*ecdsa.PrivateKey, added []istanbul.ValidatorData, removed *big.Int) *types.Header {
+	extra := &types.IstanbulExtra{
+		RemovedValidators:    removed,
+		Seal:                 []byte{},
+		AggregatedSeal:       types.IstanbulAggregatedSeal{},
+		ParentAggregatedSeal: types.IstanbulAggregatedSeal{},
+	}
+	for _, validator := range added {
+		extra.AddedValidators = append(extra.AddedValidators, validator.Address)
+		extra.AddedValidatorsPublicKeys = append(extra.AddedValidatorsPublicKeys, validator.BLSPublicKey)
+	}
+	header := &types.Header{
+		ParentHash:  parent.Hash(),
+		Number:      new(big.Int).Add(parent.Number, common.Big1),
+		Root:        parent.Root,
+		TxHash:      types.EmptyRootHash,
+		ReceiptHash: types.EmptyRootHash,
+		GasUsed:     0,
+		Time:        parent.Time + 1,
+	}
+	encode := func() {
+		payload, err := rlp.EncodeToBytes(extra)
+		if err != nil {
+			t.Fatalf("failed to encode istanbul extra: %v", err)
+		}
+		header.Extra = append(make([]byte, types.IstanbulExtraVanity), payload...)
+	}
+	encode()
+
+	round := big.NewInt(0)
+	msg := istanbulCore.PrepareCommittedSeal(header.Hash(), round)
+	var sigs [][]byte
+	for _, key := range signers {
+		sig, err := backend.SignBLSFn(key)(accounts.Account{}, msg, []byte{}, false, false)
+		if err != nil {
+			t.Fatalf("failed to sign header: %v", err)
+		}
+		sigs = append(sigs, sig[:])
+	}
+	aggregated, err := blscrypto.AggregateSignatures(sigs)
+	if err != nil {
+		t.Fatalf("failed to aggregate signatures: %v", err)
+	}
+	bitmap := new(big.Int)
+	for i := range signers {
+		bitmap.SetBit(bitmap, i, 1)
+	}
+	extra.AggregatedSeal = types.IstanbulAggregatedSeal{Bitmap: bitmap, Signature: aggregated, Round: round}
+	encode()
+	return header
+}
+
+// newSnapshotTestChain writes a sealed chain of empty blocks spanning an epoch
+// boundary which replaces the first validator with the fourth one, and returns
+// the database along with the snapshot of the genesis state.
+func newSnapshotTestChain(t *testing.T, genesis *core.Genesis, keys []*ecdsa.PrivateKey, blocks int) (ethdb.Database, *snapshot.Tree, []*types.Header) {
+	db := rawdb.NewMemoryDatabase()
+	parent := genesis.MustCommit(db).Header()
+
+	var (
+		headers = []*types.Header{parent}
+		signers = keys[:3]
+	)
+	for i := 1; i <= blocks; i++ {
+		var header *types.Header
+		if i == 4 {
+			header = sealSnapshotTestHeader(t, parent, signers, snapshotTestValidators(t, keys[3:4]), big.NewInt(1))
+			signers = keys[1:4]
+		} else {
+			header = sealSnapshotTestHeader(t, parent, signers, nil, new(big.Int))
+		}
+		rawdb.WriteBlock(db, types.NewBlockWithHeader(header))
+		rawdb.WriteCanonicalHash(db, header.Hash(), header.Number.Uint64())
+		rawdb.WriteHeadBlockHash(db, header.Hash())
+		rawdb.WriteHeadHeaderHash(db, header.Hash())
+
+		headers = append(headers, header)
+		parent = header
+	}
+	snaptree, err := snapshot.New(db, trie.NewDatabase(db), 256, parent.Root, false, true, false)
+	if err != nil {
+		t.Fatalf("failed to generate snapshot: %v", err)
+	}
+	return db, snaptree, headers
+}
+
+func newSnapshotTestKeys(t *testing.T) []*ecdsa.PrivateKey {
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < 4; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestSnapshotExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := newSnapshotTestKeys(t)
+	genesis := newSnapshotTestGenesis(t, keys)
+	db, snaptree, headers := newSnapshotTestChain(t, genesis, keys, 6)
+	head := headers[len(headers)-1]
+
+	for _, name := range []string{"state.snap", "state.snap.gz"} {
+		fn := filepath.Join(dir, name)
+		if err := ExportSnapshot(db, snaptree, head.Number.Uint64(), fn); err != nil {
+			t.Fatalf("%s: export failed: %v", name, err)
+		}
+		imported := rawdb.NewMemoryDatabase()
+		genesis.MustCommit(imported)
+		if err := ImportSnapshot(imported, fn, head.Hash()); err != nil {
+			t.Fatalf("%s: import failed: %v", name, err)
+		}
+		if hash := rawdb.ReadHeadBlockHash(imported); hash != head.Hash() {
+			t.Errorf("%s: head block mismatch: have %x, want %x", name, hash, head.Hash())
+		}
+		for _, header := range headers[4:] {
+			if hash := rawdb.ReadCanonicalHash(imported, header.Number.Uint64()); hash != header.Hash() {
+				t.Errorf("%s: canonical hash #%d mismatch: have %x, want %x", name, header.Number, hash, header.Hash())
+			}
+			// The total difficulty matches the one written by the chain, as every
+			// block has a difficulty of one
+			want := new(big.Int).Add(header.Number, big.NewInt(1))
+			if td := rawdb.ReadTd(imported, header.Hash(), header.Number.Uint64()); td == nil || td.Cmp(want) != 0 {
+				t.Errorf("%s: total difficulty #%d mismatch: have %v, want %v", name, header.Number, td, want)
+			}
+		}
+		statedb, err := state.New(head.Root, state.NewDatabase(imported), nil)
+		if err != nil {
+			t.Fatalf("%s: imported state unavailable: %v", name, err)
+		}
+		if balance := statedb.GetBalance(snapshotTestAccount); balance.Cmp(big.NewInt(1000000)) != 0 {
+			t.Errorf("%s: balance mismatch: have %v, want %v", name, balance, 1000000)
+		}
+		if code := statedb.GetCode(snapshotTestContract); !bytes.Equal(code, snapshotTestCode) {
+			t.Errorf("%s: code mismatch: have %x, want %x", name, code, snapshotTestCode)
+		}
+		if value := statedb.GetState(snapshotTestContract, snapshotTestSlot); value != snapshotTestValue {
+			t.Errorf("%s: storage mismatch: have %x, want %x", name, value, snapshotTestValue)
+		}
+		// A second import must be rejected, the database is no longer empty
+		if err := ImportSnapshot(imported, fn, head.Hash()); err == nil {
+			t.Errorf("%s: import into a non-empty database succeeded", name)
+		}
+	}
+}
+
+func TestSnapshotImportRejected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys := newSnapshotTestKeys(t)
+	genesis := newSnapshotTestGenesis(t, keys)
+	db, snaptree, headers := newSnapshotTestChain(t, genesis, keys, 6)
+	head := headers[len(headers)-1]
+
+	fn := filepath.Join(dir, "state.snap")
+	if err := ExportSnapshot(db, snaptree, head.Number.Uint64(), fn); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	blob, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := filepath.Join(dir, "tampered.snap")
+	blob[len(blob)-40] ^= 0xff
+	if err := ioutil.WriteFile(tampered, blob, 0600); err != nil {
+		t.Fatal(err)
+	}
+	// A chain with a forged seal must be rejected too
+	forged, forgedHeaders := rawdb.NewMemoryDatabase(), []*types.Header{headers[0]}
+	for i := 1; i < len(headers); i++ {
+		header := sealSnapshotTestHeader(t, forgedHeaders[i-1], keys[3:], nil, new(big.Int))
+		rawdb.WriteBlock(forged, types.NewBlockWithHeader(header))
+		rawdb.WriteCanonicalHash(forged, header.Hash(), header.Number.Uint64())
+		forgedHeaders = append(forgedHeaders, header)
+	}
+	genesis.MustCommit(forged)
+	forgedHead := forgedHeaders[len(forgedHeaders)-1]
+	forgedTree, err := snapshot.New(forged, trie.NewDatabase(forged), 256, forgedHead.Root, false, true, false)
+	if err != nil {
+		t.Fatalf("failed to generate snapshot: %v", err)
+	}
+	forgedFn := filepath.Join(dir, "forged.snap")
+	if err := ExportSnapshot(forged, forgedTree, forgedHead.Number.Uint64(), forgedFn); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	tests := []struct {
+		name    string
+		file    string
+		trusted common.Hash
+	}{
+		{"untrusted", fn, headers[len(headers)-2].Hash()},
+		{"tampered", tampered, head.Hash()},
+		{"forged", forgedFn, forgedHead.Hash()},
+	}
+	for _, tt := range tests {
+		imported := rawdb.NewMemoryDatabase()
+		genesis.MustCommit(imported)
+		if err := ImportSnapshot(imported, tt.file, tt.trusted); err == nil {
+			t.Errorf("%s: import succeeded", tt.name)
+		}
+		if hash := rawdb.ReadHeadHeaderHash(imported); hash != headers[0].Hash() {
+			t.Errorf("%s: head moved after failed import: %x", tt.name, hash)
+		}
+		// Nothing of the chain segment is left behind, so the import can be retried
+		for n := uint64(1); n <= head.Number.Uint64(); n++ {
+			if hash := rawdb.ReadCanonicalHash(imported, n); hash != (common.Hash{}) {
+				t.Errorf("%s: canonical hash #%d left after failed import", tt.name, n)
+			}
+			if rawdb.HasHeader(imported, headers[n].Hash(), n) {
+				t.Errorf("%s: header #%d left after failed import", tt.name, n)
+			}
+		}
+		if err := ImportSnapshot(imported, fn, head.Hash()); err != nil {
+			t.Errorf("%s: import after failed import failed: %v", tt.name, err)
+		}
+	}
+}
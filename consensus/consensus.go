@@ -19,6 +19,7 @@ package consensus
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/consensus/istanbul"
@@ -222,4 +223,7 @@ type Istanbul interface {
 
 	// GenerateRandomness will generate the random beacon randomness
 	GenerateRandomness(parentHash common.Hash) (common.Hash, common.Hash, error)
+
+	// RecordProposalBuildTime reports how long it took to build a proposal for the block number
+	RecordProposalBuildTime(number uint64, buildTime time.Duration)
 }
@@ -188,6 +188,12 @@ func (api *API) GetVersionCertificateTableInfo() (map[string]*announce.VersionCe
 	return api.istanbul.announceManager.GetVersionCertificateTableInfo()
 }
 
+// GetBlockProductionStats retrieves the time spent in each block production phase
+// for the last blocks committed by this validator.
+func (api *API) GetBlockProductionStats() *BlockProductionStats {
+	return api.istanbul.BlockProductionStats()
+}
+
 // GetCurrentRoundState retrieves the current IBFT RoundState
 func (api *API) GetCurrentRoundState() (*core.RoundStateSummary, error) {
 	api.istanbul.coreMu.RLock()
@@ -274,10 +280,13 @@ func (api *API) IsValidating() bool {
 
 // GetCurrentReplicaState retrieves the current replica state
 func (api *API) GetCurrentReplicaState() (*replica.ReplicaStateSummary, error) {
-	if api.istanbul.replicaState != nil {
-		return api.istanbul.replicaState.Summary(), nil
-	}
-	return &replica.ReplicaStateSummary{State: "Not a validator"}, nil
+	return api.istanbul.ReplicaStateSummary(), nil
+}
+
+// GetSealParticipation retrieves the presence of the validator's signature in the
+// parent seals of the given number of most recent blocks.
+func (api *API) GetSealParticipation(window uint64) (*SealParticipation, error) {
+	return api.istanbul.SealParticipation(window)
 }
 
 // GetLookbackWindow retrieves the current replica state
@@ -89,6 +89,7 @@ func New(config *istanbul.Config, db ethdb.Database) consensus.Istanbul {
 		blocksFinalizedTransactionsGauge:   metrics.NewRegisteredGauge("consensus/istanbul/blocks/transactions", nil),
 		blocksFinalizedGasUsedGauge:        metrics.NewRegisteredGauge("consensus/istanbul/blocks/gasused", nil),
 		sleepGauge:                         metrics.NewRegisteredGauge("consensus/istanbul/backend/sleep", nil),
+		blockProduction:                    newBlockProductionTracker(),
 	}
 	backend.aWallets.Store(&istanbul.Wallets{})
 	if config.LoadTestCSVFile != "" {
@@ -314,6 +315,9 @@ type Backend struct {
 	// Consensus csv recorded for load testing
 	csvRecorder *metrics.CSVRecorder
 
+	// Rolling window of the block production times of the committed blocks
+	blockProduction *blockProductionTracker
+
 	// Cache for the return values of the method RetrieveValidatorConnSet
 	cachedValidatorConnSet         map[common.Address]bool
 	cachedValidatorConnSetBlockNum uint64
@@ -377,6 +381,15 @@ func (sb *Backend) IsValidating() bool {
 	return sb.isCoreStarted()
 }
 
+// currentTime returns the current time of the engine, from the clock set in its
+// config if any.
+func (sb *Backend) currentTime() time.Time {
+	if sb.config.Now != nil {
+		return sb.config.Now()
+	}
+	return now()
+}
+
 // IsValidator return if instance is a validator (either proxied or standalone)
 func (sb *Backend) IsValidator() bool {
 	return sb.config.Validator
@@ -549,10 +562,27 @@ func (sb *Backend) Commit(proposal istanbul.Proposal, aggregatedSeal types.Istan
 			return err
 		}
 	}
+	insertStart := time.Now()
 	sb.onNewConsensusBlock(block, result.Receipts, result.Logs, result.State)
+	sb.blockProduction.recordBlock(block.NumberU64(), aggregatedSeal.Round.Uint64(), time.Since(insertStart))
 	return nil
 }
 
+// RecordConsensusTimes implements istanbul.Backend.RecordConsensusTimes
+func (sb *Backend) RecordConsensusTimes(seq *big.Int, times istanbulCore.ConsensusTimes) {
+	sb.blockProduction.recordConsensusTimes(seq.Uint64(), times)
+}
+
+// RecordProposalBuildTime records how long it took the miner to build a proposal for the block number
+func (sb *Backend) RecordProposalBuildTime(number uint64, buildTime time.Duration) {
+	sb.blockProduction.recordBuildTime(number, buildTime)
+}
+
+// BlockProductionStats returns the block production times of the last committed blocks
+func (sb *Backend) BlockProductionStats() *BlockProductionStats {
+	return sb.blockProduction.stats()
+}
+
 // EventMux implements istanbul.Backend.EventMux
 func (sb *Backend) EventMux() *event.TypeMux {
 	return sb.istanbulEventMux
@@ -594,7 +624,7 @@ func (sb *Backend) Verify(proposal istanbul.Proposal) (*istanbulCore.StateProces
 
 	if err != nil {
 		if err == consensus.ErrFutureBlock {
-			return nil, time.Unix(int64(block.Header().Time), 0).Sub(now()), consensus.ErrFutureBlock
+			return nil, time.Unix(int64(block.Header().Time), 0).Sub(sb.currentTime()), consensus.ErrFutureBlock
 		} else {
 			return nil, 0, err
 		}
@@ -0,0 +1,230 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	istanbulCore "github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+	"github.com/celo-org/celo-blockchain/metrics"
+)
+
+// blockProductionWindow is the number of blocks kept by the block production stats
+const blockProductionWindow = 256
+
+// blockProductionBuckets are the upper bounds, in seconds, of the block production histograms
+var blockProductionBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// BlockProductionTimes holds how long each phase of the production of a block took, in milliseconds.
+type BlockProductionTimes struct {
+	Number        uint64  `json:"number"`
+	Round         uint64  `json:"round"`
+	Proposer      bool    `json:"proposer"`      // Whether this node proposed the block
+	PrePrepare    float64 `json:"preprepare"`    // From the start of the sequence to accepting the preprepare
+	PrepareQuorum float64 `json:"prepareQuorum"` // From accepting the preprepare to a prepare quorum
+	CommitQuorum  float64 `json:"commitQuorum"`  // From the prepare quorum to a commit quorum
+	BlockInsert   float64 `json:"blockInsert"`   // Writing the block into the chain
+	ProposalBuild float64 `json:"proposalBuild"` // Building the proposal, only set if this node proposed the block
+}
+
+// PhaseStats summarizes the times of a block production phase, in milliseconds.
+type PhaseStats struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// BlockProductionSummary summarizes the block production phases of the blocks
+// this node proposed, or of the ones it didn't.
+type BlockProductionSummary struct {
+	Blocks        int         `json:"blocks"`
+	PrePrepare    *PhaseStats `json:"preprepare"`
+	PrepareQuorum *PhaseStats `json:"prepareQuorum"`
+	CommitQuorum  *PhaseStats `json:"commitQuorum"`
+	BlockInsert   *PhaseStats `json:"blockInsert"`
+	ProposalBuild *PhaseStats `json:"proposalBuild,omitempty"`
+}
+
+// BlockProductionStats holds the block production times of the last committed blocks.
+type BlockProductionStats struct {
+	Blocks      []BlockProductionTimes  `json:"blocks"`
+	Proposer    *BlockProductionSummary `json:"proposer"`
+	NonProposer *BlockProductionSummary `json:"nonProposer"`
+}
+
+// blockProductionTracker keeps a rolling window of the block production times of
+// the blocks committed by this validator, and reports them as histograms.
+type blockProductionTracker struct {
+	blocks     []BlockProductionTimes                 // Last committed blocks, oldest first
+	consensus  map[uint64]istanbulCore.ConsensusTimes // Consensus times of the sequences being committed
+	buildTimes map[uint64]time.Duration               // Build times of the last proposals built, by block number
+	lock       sync.Mutex
+
+	prePrepareHistogram    metrics.LabelledHistogram
+	prepareQuorumHistogram metrics.LabelledHistogram
+	commitQuorumHistogram  metrics.LabelledHistogram
+	blockInsertHistogram   metrics.LabelledHistogram
+	proposalBuildHistogram metrics.LabelledHistogram
+}
+
+func newBlockProductionTracker() *blockProductionTracker {
+	return &blockProductionTracker{
+		consensus:              make(map[uint64]istanbulCore.ConsensusTimes),
+		buildTimes:             make(map[uint64]time.Duration),
+		prePrepareHistogram:    metrics.NewRegisteredLabelledHistogram("consensus/istanbul/blockproduction/preprepare", nil, "proposer", blockProductionBuckets),
+		prepareQuorumHistogram: metrics.NewRegisteredLabelledHistogram("consensus/istanbul/blockproduction/prepare_quorum", nil, "proposer", blockProductionBuckets),
+		commitQuorumHistogram:  metrics.NewRegisteredLabelledHistogram("consensus/istanbul/blockproduction/commit_quorum", nil, "proposer", blockProductionBuckets),
+		blockInsertHistogram:   metrics.NewRegisteredLabelledHistogram("consensus/istanbul/blockproduction/block_insert", nil, "proposer", blockProductionBuckets),
+		proposalBuildHistogram: metrics.NewRegisteredLabelledHistogram("consensus/istanbul/blockproduction/proposal_build", nil, "proposer", blockProductionBuckets),
+	}
+}
+
+// recordConsensusTimes stores the consensus times of a sequence until its block is inserted.
+func (t *blockProductionTracker) recordConsensusTimes(number uint64, times istanbulCore.ConsensusTimes) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.consensus[number] = times
+}
+
+// recordBuildTime stores the time it took to build a proposal for the block number.
+func (t *blockProductionTracker) recordBuildTime(number uint64, buildTime time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.buildTimes[number] = buildTime
+}
+
+// recordBlock records the production times of a committed block, once inserted.
+func (t *blockProductionTracker) recordBlock(number, round uint64, insertTime time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	consensus := t.consensus[number]
+	times := BlockProductionTimes{
+		Number:        number,
+		Round:         round,
+		Proposer:      consensus.Proposer,
+		PrePrepare:    milliseconds(consensus.PrePrepare),
+		PrepareQuorum: milliseconds(consensus.Prepare),
+		CommitQuorum:  milliseconds(consensus.Commit),
+		BlockInsert:   milliseconds(insertTime),
+	}
+	label := strconv.FormatBool(consensus.Proposer)
+	if buildTime, ok := t.buildTimes[number]; ok && consensus.Proposer {
+		times.ProposalBuild = milliseconds(buildTime)
+		t.proposalBuildHistogram.Observe(label, buildTime.Seconds())
+	}
+	t.prePrepareHistogram.Observe(label, consensus.PrePrepare.Seconds())
+	t.prepareQuorumHistogram.Observe(label, consensus.Prepare.Seconds())
+	t.commitQuorumHistogram.Observe(label, consensus.Commit.Seconds())
+	t.blockInsertHistogram.Observe(label, insertTime.Seconds())
+
+	t.blocks = append(t.blocks, times)
+	if len(t.blocks) > blockProductionWindow {
+		t.blocks = t.blocks[len(t.blocks)-blockProductionWindow:]
+	}
+	// Drop the times of this and older sequences, they won't be committed anymore
+	for n := range t.consensus {
+		if n <= number {
+			delete(t.consensus, n)
+		}
+	}
+	for n := range t.buildTimes {
+		if n <= number {
+			delete(t.buildTimes, n)
+		}
+	}
+}
+
+// stats returns the times of the blocks in the window, with a summary of each phase.
+func (t *blockProductionTracker) stats() *BlockProductionStats {
+	t.lock.Lock()
+	blocks := append([]BlockProductionTimes{}, t.blocks...)
+	t.lock.Unlock()
+
+	var proposed, notProposed []BlockProductionTimes
+	for _, block := range blocks {
+		if block.Proposer {
+			proposed = append(proposed, block)
+		} else {
+			notProposed = append(notProposed, block)
+		}
+	}
+	return &BlockProductionStats{
+		Blocks:      blocks,
+		Proposer:    summarize(proposed, true),
+		NonProposer: summarize(notProposed, false),
+	}
+}
+
+// summarize returns the summary of the phases of the blocks, or nil if there are none.
+func summarize(blocks []BlockProductionTimes, proposer bool) *BlockProductionSummary {
+	if len(blocks) == 0 {
+		return nil
+	}
+	phase := func(get func(BlockProductionTimes) float64) *PhaseStats {
+		values := make([]float64, len(blocks))
+		for i, block := range blocks {
+			values[i] = get(block)
+		}
+		return newPhaseStats(values)
+	}
+	summary := &BlockProductionSummary{
+		Blocks:        len(blocks),
+		PrePrepare:    phase(func(b BlockProductionTimes) float64 { return b.PrePrepare }),
+		PrepareQuorum: phase(func(b BlockProductionTimes) float64 { return b.PrepareQuorum }),
+		CommitQuorum:  phase(func(b BlockProductionTimes) float64 { return b.CommitQuorum }),
+		BlockInsert:   phase(func(b BlockProductionTimes) float64 { return b.BlockInsert }),
+	}
+	if proposer {
+		summary.ProposalBuild = phase(func(b BlockProductionTimes) float64 { return b.ProposalBuild })
+	}
+	return summary
+}
+
+// newPhaseStats computes the stats of a non-empty list of values. Percentiles use the nearest-rank method.
+func newPhaseStats(values []float64) *PhaseStats {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return sorted[rank]
+	}
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	return &PhaseStats{
+		Mean: sum / float64(len(sorted)),
+		P50:  percentile(0.5),
+		P90:  percentile(0.9),
+		P99:  percentile(0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
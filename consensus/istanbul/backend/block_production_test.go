@@ -0,0 +1,58 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	istanbulCore "github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+)
+
+func TestBlockProductionTracker(t *testing.T) {
+	tracker := newBlockProductionTracker()
+
+	tracker.recordBuildTime(1, 40*time.Millisecond)
+	tracker.recordConsensusTimes(1, istanbulCore.ConsensusTimes{Proposer: true, PrePrepare: 100 * time.Millisecond, Prepare: 20 * time.Millisecond, Commit: 10 * time.Millisecond})
+	tracker.recordBlock(1, 0, 5*time.Millisecond)
+
+	tracker.recordBuildTime(2, 50*time.Millisecond)
+	tracker.recordConsensusTimes(2, istanbulCore.ConsensusTimes{PrePrepare: 200 * time.Millisecond, Prepare: 30 * time.Millisecond, Commit: 20 * time.Millisecond})
+	tracker.recordBlock(2, 1, 6*time.Millisecond)
+
+	stats := tracker.stats()
+	if len(stats.Blocks) != 2 {
+		t.Fatalf("block count mismatch: have %d, want 2", len(stats.Blocks))
+	}
+	want := BlockProductionTimes{Number: 1, Proposer: true, PrePrepare: 100, PrepareQuorum: 20, CommitQuorum: 10, BlockInsert: 5, ProposalBuild: 40}
+	if stats.Blocks[0] != want {
+		t.Errorf("proposed block mismatch: have %+v, want %+v", stats.Blocks[0], want)
+	}
+	// The proposal built for block 2 was not used, as this node wasn't its proposer
+	want = BlockProductionTimes{Number: 2, Round: 1, PrePrepare: 200, PrepareQuorum: 30, CommitQuorum: 20, BlockInsert: 6}
+	if stats.Blocks[1] != want {
+		t.Errorf("validated block mismatch: have %+v, want %+v", stats.Blocks[1], want)
+	}
+	if stats.Proposer.Blocks != 1 || stats.Proposer.ProposalBuild.Max != 40 {
+		t.Errorf("proposer summary mismatch: %+v", stats.Proposer)
+	}
+	if stats.NonProposer.Blocks != 1 || stats.NonProposer.ProposalBuild != nil || stats.NonProposer.PrePrepare.Mean != 200 {
+		t.Errorf("non proposer summary mismatch: %+v", stats.NonProposer)
+	}
+	if len(tracker.consensus) != 0 || len(tracker.buildTimes) != 0 {
+		t.Errorf("times of committed sequences not dropped")
+	}
+
+	for i := uint64(3); i < 3+blockProductionWindow; i++ {
+		tracker.recordBlock(i, 0, time.Millisecond)
+	}
+	if stats = tracker.stats(); len(stats.Blocks) != blockProductionWindow || stats.Blocks[0].Number != 3 {
+		t.Errorf("window not rolled over: %d blocks, first %d", len(stats.Blocks), stats.Blocks[0].Number)
+	}
+}
+
+func TestPhaseStats(t *testing.T) {
+	stats := newPhaseStats([]float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10})
+	want := PhaseStats{Mean: 5.5, P50: 5, P90: 9, P99: 10, Max: 10}
+	if *stats != want {
+		t.Errorf("stats mismatch: have %+v, want %+v", *stats, want)
+	}
+}
@@ -127,7 +127,7 @@ func (sb *Backend) verifyHeader(chain consensus.ChainHeaderReader, header *types
 
 	// If the full chain isn't available (as on mobile devices), don't reject future blocks
 	// This is due to potential clock skew
-	allowedFutureBlockTime := uint64(now().Unix())
+	allowedFutureBlockTime := uint64(sb.currentTime().Unix())
 	if !chain.Config().FullHeaderChainAvailable {
 		allowedFutureBlockTime = allowedFutureBlockTime + mobileAllowedClockSkew
 	}
@@ -401,7 +401,7 @@ func (sb *Backend) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 
 	// set header's timestamp
 	header.Time = parent.Time + sb.config.BlockPeriod
-	nowTime := uint64(now().Unix())
+	nowTime := uint64(sb.currentTime().Unix())
 	if header.Time < nowTime {
 		header.Time = nowTime
 	}
@@ -410,7 +410,7 @@ func (sb *Backend) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 	// TODO(victor): Sleep here was previously removed and added to the miner instead, that change
 	// has been temporarily reverted until it can be reimplemented without causing fewer signatures
 	// to be included by the block producer.
-	delay := time.Until(time.Unix(int64(header.Time), 0))
+	delay := time.Unix(int64(header.Time), 0).Sub(sb.currentTime())
 	if delay < 0 {
 		sb.sleepGauge.Update(0)
 	} else {
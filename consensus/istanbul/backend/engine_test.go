@@ -141,6 +141,12 @@ func TestVerifyHeader(t *testing.T) {
 	header.Time = uint64(now().Unix() + 10)
 	err = engine.VerifyHeader(chain, header, false)
 	g.Expect(err).Should(BeIdenticalTo(consensus.ErrFutureBlock))
+
+	// not a future block for the clock of the config
+	engine.config.Now = func() time.Time { return now().Add(time.Minute) }
+	defer func() { engine.config.Now = nil }()
+	err = engine.VerifyHeader(chain, header, false)
+	g.Expect(err).ShouldNot(BeIdenticalTo(consensus.ErrFutureBlock))
 }
 
 func TestVerifySeal(t *testing.T) {
@@ -0,0 +1,74 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/backend/internal/replica"
+	"github.com/celo-org/celo-blockchain/core/types"
+)
+
+// SealParticipation summarizes the presence of the validator's signature in the
+// ParentAggregatedSeal of recent blocks.
+type SealParticipation struct {
+	Blocks     uint64 `json:"blocks"`     // Parent seals checked
+	Elected    uint64 `json:"elected"`    // Parent seals of blocks the validator was elected for
+	Signed     uint64 `json:"signed"`     // Parent seals that include the validator's signature
+	LastSigned uint64 `json:"lastSigned"` // Number of the last block signed, 0 if none in the window
+}
+
+// SealParticipation checks the parent seals of the last window blocks for the
+// signature of the validator. On a proxy the proxied validator is checked.
+func (sb *Backend) SealParticipation(window uint64) (*SealParticipation, error) {
+	participation := &SealParticipation{}
+	address := sb.ValidatorAddress()
+
+	header := sb.chain.CurrentHeader()
+	for participation.Blocks < window && header.Number.Uint64() > 1 {
+		// The parent seal of a block signs its parent, which was validated by
+		// the validator set resulting from the grandparent.
+		parent := sb.chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+		if parent == nil {
+			break
+		}
+		extra, err := types.ExtractIstanbulExtra(header)
+		if err != nil {
+			return nil, err
+		}
+		participation.Blocks++
+
+		valSet := sb.getValidators(parent.Number.Uint64()-1, parent.ParentHash)
+		if index, _ := valSet.GetByAddress(address); index >= 0 {
+			participation.Elected++
+			if extra.ParentAggregatedSeal.Bitmap.Bit(index) == 1 {
+				participation.Signed++
+				if participation.LastSigned == 0 {
+					participation.LastSigned = parent.Number.Uint64()
+				}
+			}
+		}
+		header = parent
+	}
+	return participation, nil
+}
+
+// ReplicaStateSummary returns the replica state of the validator.
+func (sb *Backend) ReplicaStateSummary() *replica.ReplicaStateSummary {
+	if sb.replicaState != nil {
+		return sb.replicaState.Summary()
+	}
+	return &replica.ReplicaStateSummary{State: "Not a validator"}
+}
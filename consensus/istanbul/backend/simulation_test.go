@@ -0,0 +1,112 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulationIdealNetwork(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 1))
+	sim.start()
+	defer sim.stop()
+
+	// Cross two epoch boundaries
+	if !sim.runUntil(2*simEpoch+5, 2*time.Minute) {
+		t.Errorf("validators did not reach height %d, stuck at %d", 2*simEpoch+5, sim.height())
+	}
+	sim.checkSafety()
+	sim.checkImport()
+	sim.logStats()
+}
+
+func TestSimulationLossyNetwork(t *testing.T) {
+	config := newSimConfig(7, 2)
+	config.maxLatency = 100 * time.Millisecond
+	config.dropRate = 0.1
+	sim := newSimulator(t, config)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(simEpoch+2, 3*time.Minute) {
+		t.Errorf("validators did not reach height %d, stuck at %d", simEpoch+2, sim.height())
+	}
+	sim.checkSafety()
+	sim.checkImport()
+	sim.logStats()
+}
+
+func TestSimulationPartitionHeal(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 3))
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(3, time.Minute) {
+		t.Fatalf("validators did not reach height 3, stuck at %d", sim.height())
+	}
+	sim.partition([]int{0, 1}, []int{2, 3})
+	time.Sleep(3 * time.Second)
+	height := sim.height()
+	for _, n := range sim.nodes {
+		if n.height() > height+1 {
+			t.Errorf("validator %d made progress without a quorum: height %d", n.index, n.height())
+		}
+	}
+
+	sim.heal()
+	if !sim.runUntil(height+5, 2*time.Minute) {
+		t.Errorf("validators did not recover from the partition, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	sim.checkImport()
+	if max, _ := sim.maxRound(); max == 0 {
+		t.Errorf("no block committed after a round change")
+	}
+	sim.logStats()
+}
+
+func TestSimulationSilentValidator(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 4))
+	sim.silence(0)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(simEpoch+2, 2*time.Minute) {
+		t.Errorf("validators did not reach height %d, stuck at %d", simEpoch+2, sim.height())
+	}
+	sim.checkSafety()
+	sim.checkImport()
+	if max, _ := sim.maxRound(); max == 0 {
+		t.Errorf("blocks proposed by the silent validator were committed")
+	}
+	sim.logStats()
+}
+
+func TestSimulationEquivocatingProposer(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 5))
+	sim.equivocate(0)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(simEpoch+2, 2*time.Minute) {
+		t.Errorf("validators did not reach height %d, stuck at %d", simEpoch+2, sim.height())
+	}
+	sim.checkSafety()
+	sim.checkImport()
+	sim.logStats()
+}
@@ -0,0 +1,781 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus"
+	"github.com/celo-org/celo-blockchain/consensus/consensustest"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/p2p"
+	"github.com/celo-org/celo-blockchain/p2p/enode"
+	"github.com/celo-org/celo-blockchain/params"
+	"github.com/celo-org/celo-blockchain/rlp"
+)
+
+// The simulator runs a network of validators, each driving a Backend on top of
+// its own in-memory blockchain, connected by a simulated transport. Nothing of
+// the backend is replaced: consensus messages go through Multicast and
+// HandleMsg, proposals are built on the genesis contracts and verified with
+// state processing, and committed blocks go through the insert callback, the
+// way the miner sets it up. Validators that missed a commit import the blocks
+// from their peers with InsertChain, which verifies the headers and seals, and
+// the epochs are short enough for the runs to cross epoch boundaries.
+//
+// Before validating, the validators gossip their enode certificates over the
+// transport, which fills the validator enode tables Multicast relies on. The
+// announce protocol then runs on its own.
+//
+// The simulation runs in real time and the backends handle messages on their
+// own goroutines, so unlike the simulator of the core, a run can't be
+// reproduced from its seed. The validator set stays the genesis one across
+// epochs, so validator set changes are not covered.
+
+// simEpoch is the epoch size of the simulated chains.
+const simEpoch = 10
+
+type simConfig struct {
+	validators int
+	seed       int64
+
+	// Messages and block announcements take a uniformly distributed latency
+	// between minLatency and maxLatency, so they can arrive reordered.
+	minLatency time.Duration
+	maxLatency time.Duration
+	// dropRate is the probability of a consensus message getting lost.
+	dropRate float64
+	// syncInterval is the period at which validators sync from their peers.
+	syncInterval time.Duration
+}
+
+func newSimConfig(validators int, seed int64) simConfig {
+	return simConfig{
+		validators:   validators,
+		seed:         seed,
+		minLatency:   5 * time.Millisecond,
+		maxLatency:   20 * time.Millisecond,
+		syncInterval: time.Second,
+	}
+}
+
+// simPacket is a message in flight between two validators.
+type simPacket struct {
+	from, to int
+	code     uint64
+	payload  []byte
+}
+
+// simFilter inspects the consensus messages sent over the network. It returns
+// the packet to deliver, possibly modified, or nil to drop it.
+type simFilter func(p *simPacket) *simPacket
+
+type simulator struct {
+	t       *testing.T
+	config  simConfig
+	genesis *core.Genesis
+	nodes   []*simNode
+
+	// faulty marks the validators with a byzantine behaviour, which are left
+	// out of the liveness checks.
+	faulty []bool
+
+	mu      sync.Mutex // Protects the fields below
+	rand    *rand.Rand
+	filters []simFilter
+	// groups holds the partition each validator is in, nil when the network
+	// isn't partitioned.
+	groups []int
+	closed bool
+
+	// wg tracks the goroutines of the simulation and the deliveries in flight.
+	wg   sync.WaitGroup
+	quit chan struct{}
+}
+
+// simNode is a validator of the simulation. It is both the broadcaster and
+// the p2p server of its backend, connected to every other validator.
+type simNode struct {
+	sim     *simulator
+	index   int
+	address common.Address
+	self    *enode.Node
+	backend *Backend
+	chain   *core.BlockChain
+	peers   map[enode.ID]consensus.Peer
+
+	syncMu sync.Mutex
+}
+
+// simPeer is the connection of a validator to one of its peers.
+type simPeer struct {
+	local, remote *simNode
+}
+
+// newSimulator creates a network of validators whose keys are derived from the
+// seed. The validators are started by start.
+func newSimulator(t *testing.T, config simConfig) *simulator {
+	s := &simulator{
+		t:      t,
+		config: config,
+		faulty: make([]bool, config.validators),
+		rand:   rand.New(rand.NewSource(config.seed)),
+		quit:   make(chan struct{}),
+	}
+
+	keys := make([]*ecdsa.PrivateKey, config.validators)
+	validators := make([]istanbul.ValidatorData, config.validators)
+	for i := range keys {
+		key, err := crypto.ToECDSA(crypto.Keccak256([]byte(fmt.Sprintf("istanbul-backend-simulator-%d-%d", config.seed, i))))
+		if err != nil {
+			t.Fatalf("could not derive key of validator %d: %v", i, err)
+		}
+		blsKey, err := blscrypto.ECDSAToBLS(key)
+		if err != nil {
+			t.Fatalf("could not derive BLS key of validator %d: %v", i, err)
+		}
+		blsPublicKey, err := blscrypto.PrivateToPublic(blsKey)
+		if err != nil {
+			t.Fatalf("could not derive BLS public key of validator %d: %v", i, err)
+		}
+		keys[i] = key
+		validators[i] = istanbul.ValidatorData{
+			Address:      crypto.PubkeyToAddress(key.PublicKey),
+			BLSPublicKey: blsPublicKey,
+		}
+	}
+
+	chainConfig := *params.IstanbulTestChainConfig
+	chainConfig.Istanbul = &params.IstanbulConfig{
+		Epoch:          simEpoch,
+		ProposerPolicy: uint64(istanbul.RoundRobin),
+		LookbackWindow: 3,
+	}
+	genesis := core.MainnetGenesisBlock()
+	genesis.Config = &chainConfig
+	AppendValidatorsToGenesisBlock(genesis, validators)
+	s.genesis = genesis
+
+	for i, key := range keys {
+		s.nodes = append(s.nodes, s.newNode(i, key))
+	}
+	for _, n := range s.nodes {
+		n.peers = make(map[enode.ID]consensus.Peer)
+		for _, peer := range s.nodes {
+			if peer != n {
+				n.peers[peer.self.ID()] = &simPeer{local: n, remote: peer}
+			}
+		}
+	}
+	return s
+}
+
+// newNode creates a validator with its own database and chain, set up the way
+// the eth service and the miner set up the backend.
+func (s *simulator) newNode(index int, key *ecdsa.PrivateKey) *simNode {
+	db := rawdb.NewMemoryDatabase()
+	config := *istanbul.DefaultConfig
+	config.ReplicaStateDBPath = ""
+	config.ValidatorEnodeDBPath = ""
+	config.VersionCertificateDBPath = ""
+	config.RoundStateDBPath = ""
+	config.Validator = true
+	if err := istanbul.ApplyParamsChainConfigToConfig(s.genesis.Config, &config); err != nil {
+		s.t.Fatalf("invalid istanbul config: %v", err)
+	}
+	// Same timeouts as the end to end tests, to keep the runs short
+	config.BlockPeriod = 0
+	config.RequestTimeout = 200
+	config.TimeoutBackoffFactor = 200
+	config.MinResendRoundChangeTimeout = 200
+	config.MaxResendRoundChangeTimeout = 10000
+
+	b := New(&config, db).(*Backend)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	b.Authorize(address, address, &key.PublicKey, DecryptFn(key), SignFn(key), SignBLSFn(key), SignHashFn(key))
+
+	s.genesis.MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, s.genesis.Config, b, vm.Config{}, nil, nil)
+	if err != nil {
+		s.t.Fatalf("could not create chain of validator %d: %v", index, err)
+	}
+	n := &simNode{
+		sim:     s,
+		index:   index,
+		address: address,
+		self:    enode.NewV4(&key.PublicKey, net.IP{127, 0, 0, 1}, 30303+index, 30303+index),
+		backend: b,
+		chain:   chain,
+	}
+	b.SetChain(chain, chain.CurrentBlock, func(hash common.Hash) (*state.StateDB, error) {
+		return chain.StateAt(chain.GetHeaderByHash(hash).Root)
+	})
+	b.SetBroadcaster(n)
+	b.SetP2PServer(n)
+	b.SetCallBacks(chain.HasBadBlock,
+		func(block *types.Block, state *state.StateDB) (types.Receipts, []*types.Log, uint64, error) {
+			return chain.Processor().Process(block, state, *chain.GetVMConfig())
+		},
+		chain.Validator().ValidateState,
+		func(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB) {
+			if err := chain.InsertPreprocessedBlock(block, receipts, logs, state); err != nil {
+				s.t.Errorf("validator %d could not insert committed block %d: %v", index, block.NumberU64(), err)
+			}
+		})
+	return n
+}
+
+// start connects the validators, has them exchange their enode certificates
+// and starts them validating and proposing blocks.
+func (s *simulator) start() {
+	for _, n := range s.nodes {
+		if err := n.backend.StartAnnouncing(); err != nil {
+			s.t.Fatalf("could not start announcing on validator %d: %v", n.index, err)
+		}
+		for _, peer := range n.peers {
+			if err := n.backend.RegisterPeer(peer, false); err != nil {
+				s.t.Fatalf("could not register peer of validator %d: %v", n.index, err)
+			}
+		}
+	}
+	for _, n := range s.nodes {
+		if err := n.gossipEnodeCertificate(); err != nil {
+			s.t.Fatalf("could not gossip enode certificate of validator %d: %v", n.index, err)
+		}
+	}
+	s.waitForEnodeTables(10 * time.Second)
+
+	for _, n := range s.nodes {
+		if err := n.backend.StartValidating(); err != nil {
+			s.t.Fatalf("could not start validating on validator %d: %v", n.index, err)
+		}
+	}
+	for _, n := range s.nodes {
+		s.wg.Add(1)
+		go n.mine()
+	}
+	s.wg.Add(1)
+	go s.syncLoop()
+}
+
+// stop stops the validators, after the deliveries in flight.
+func (s *simulator) stop() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	close(s.quit)
+	s.wg.Wait()
+
+	for _, n := range s.nodes {
+		n.backend.StopValidating()
+		n.backend.StopAnnouncing()
+		n.chain.Stop()
+		n.backend.Close()
+	}
+}
+
+// waitForEnodeTables waits until every validator knows the enode of all the
+// others.
+func (s *simulator) waitForEnodeTables(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for _, n := range s.nodes {
+		for _, peer := range s.nodes {
+			if peer == n {
+				continue
+			}
+			for {
+				if node, err := n.backend.valEnodeTable.GetNodeFromAddress(peer.address); err == nil && node != nil {
+					break
+				}
+				if time.Now().After(deadline) {
+					s.t.Fatalf("validator %d did not receive the enode certificate of validator %d", n.index, peer.index)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	}
+}
+
+// runUntil waits until every validator that isn't faulty has the given
+// height, for at most the given duration. It reports whether the height was
+// reached.
+func (s *simulator) runUntil(height uint64, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !s.reached(height) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return true
+}
+
+func (s *simulator) reached(height uint64) bool {
+	for _, n := range s.nodes {
+		if !s.faulty[n.index] && n.height() < height {
+			return false
+		}
+	}
+	return true
+}
+
+// height returns the lowest height of the validators that aren't faulty.
+func (s *simulator) height() uint64 {
+	var height *uint64
+	for _, n := range s.nodes {
+		if h := n.height(); !s.faulty[n.index] && (height == nil || h < *height) {
+			height = &h
+		}
+	}
+	return *height
+}
+
+// checkSafety fails the test if the validators ended up with diverging chains.
+func (s *simulator) checkSafety() {
+	for _, n := range s.nodes[1:] {
+		height := n.height()
+		if h := s.nodes[0].height(); h < height {
+			height = h
+		}
+		for number := uint64(1); number <= height; number++ {
+			if n.chain.GetCanonicalHash(number) != s.nodes[0].chain.GetCanonicalHash(number) {
+				s.t.Errorf("validators 0 and %d diverge at height %d", n.index, number)
+				break
+			}
+		}
+	}
+}
+
+// checkImport imports the chain of a validator that isn't faulty into a new
+// chain, which verifies every header and seal, epoch blocks included.
+func (s *simulator) checkImport() {
+	source := s.correctNode()
+	db := rawdb.NewMemoryDatabase()
+	config := *source.backend.config
+	config.Validator = false
+	b := New(&config, db).(*Backend)
+	defer b.Close()
+
+	s.genesis.MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, s.genesis.Config, b, vm.Config{}, nil, nil)
+	if err != nil {
+		s.t.Fatalf("could not create chain: %v", err)
+	}
+	defer chain.Stop()
+	b.SetChain(chain, chain.CurrentBlock, func(hash common.Hash) (*state.StateDB, error) {
+		return chain.StateAt(chain.GetHeaderByHash(hash).Root)
+	})
+	b.SetBroadcaster(&consensustest.MockBroadcaster{})
+	b.SetP2PServer(consensustest.NewMockP2PServer(nil))
+
+	var blocks types.Blocks
+	for number := uint64(1); number <= source.height(); number++ {
+		blocks = append(blocks, source.chain.GetBlockByNumber(number))
+	}
+	if index, err := chain.InsertChain(blocks); err != nil {
+		s.t.Errorf("could not import block %d of validator %d: %v", blocks[index].NumberU64(), source.index, err)
+	}
+}
+
+// correctNode returns the first validator that isn't faulty.
+func (s *simulator) correctNode() *simNode {
+	for _, n := range s.nodes {
+		if !s.faulty[n.index] {
+			return n
+		}
+	}
+	s.t.Fatalf("all validators are faulty")
+	return nil
+}
+
+// maxRound returns the highest round in which a block of a validator that
+// isn't faulty was committed, and the number of blocks committed after a
+// round change.
+func (s *simulator) maxRound() (uint64, int) {
+	source := s.correctNode()
+	var max uint64
+	var changed int
+	for number := uint64(1); number <= source.height(); number++ {
+		extra, err := types.ExtractIstanbulExtra(source.chain.GetHeaderByNumber(number))
+		if err != nil {
+			s.t.Fatalf("could not extract istanbul extra of block %d: %v", number, err)
+		}
+		if round := extra.AggregatedSeal.Round.Uint64(); round > 0 {
+			changed++
+			if round > max {
+				max = round
+			}
+		}
+	}
+	return max, changed
+}
+
+func (s *simulator) logStats() {
+	max, changed := s.maxRound()
+	s.t.Logf("height %d, %d blocks committed after a round change, max committed round %d", s.height(), changed, max)
+}
+
+// ----------------------------------------------------------------------------
+// Network
+
+func (s *simulator) connected(from, to int) bool {
+	return s.groups == nil || s.groups[from] == s.groups[to]
+}
+
+func (s *simulator) latency() time.Duration {
+	spread := int64(s.config.maxLatency - s.config.minLatency)
+	if spread <= 0 {
+		return s.config.minLatency
+	}
+	return s.config.minLatency + time.Duration(s.rand.Int63n(spread+1))
+}
+
+// after runs f after the given delay, unless the simulation stopped by then.
+// The caller must hold mu.
+func (s *simulator) after(d time.Duration, f func()) {
+	if s.closed {
+		return
+	}
+	s.wg.Add(1)
+	time.AfterFunc(d, func() {
+		defer s.wg.Done()
+		s.mu.Lock()
+		closed := s.closed
+		s.mu.Unlock()
+		if !closed {
+			f()
+		}
+	})
+}
+
+// send passes a message through the network. Consensus messages go through
+// the filters first and can get lost.
+func (s *simulator) send(from, to *simNode, code uint64, data interface{}) error {
+	payload, ok := data.([]byte)
+	if !ok {
+		return errors.New("istanbul messages are sent as bytes")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := &simPacket{from: from.index, to: to.index, code: code, payload: payload}
+	if code == istanbul.ConsensusMsg {
+		for _, filter := range s.filters {
+			if p = filter(p); p == nil {
+				return nil
+			}
+		}
+		if s.rand.Float64() < s.config.dropRate {
+			return nil
+		}
+	}
+	if !s.connected(p.from, p.to) {
+		return nil
+	}
+	s.after(s.latency(), func() { to.receive(from, p.code, p.payload) })
+	return nil
+}
+
+// announce sends a new head to the other validators, which import it along
+// with any block they're missing.
+func (s *simulator) announce(from *simNode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, to := range s.nodes {
+		if to == from || !s.connected(from.index, to.index) {
+			continue
+		}
+		to := to
+		s.after(s.latency(), func() { to.syncFrom(from) })
+	}
+}
+
+// syncLoop periodically has every validator import the blocks it's missing
+// from the first reachable peer with a longer chain.
+func (s *simulator) syncLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.config.syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, n := range s.nodes {
+				for _, peer := range s.nodes {
+					if peer != n && s.reachable(peer, n) && peer.height() > n.height() {
+						n.syncFrom(peer)
+						break
+					}
+				}
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func (s *simulator) reachable(from, to *simNode) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connected(from.index, to.index)
+}
+
+// partition splits the network into the given groups of validators. Those
+// left out form a group of their own.
+func (s *simulator) partition(groups ...[]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups = make([]int, len(s.nodes))
+	for i, group := range groups {
+		for _, index := range group {
+			s.groups[index] = i + 1
+		}
+	}
+}
+
+// heal removes the partitions of the network.
+func (s *simulator) heal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups = nil
+}
+
+// ----------------------------------------------------------------------------
+// Byzantine behaviours
+
+// silence drops every consensus message sent by the validator.
+func (s *simulator) silence(index int) {
+	s.faulty[index] = true
+	s.filters = append(s.filters, func(p *simPacket) *simPacket {
+		if p.from == index {
+			return nil
+		}
+		return p
+	})
+}
+
+// equivocate makes the validator send a different, properly signed, proposal
+// to the validators with an odd index whenever it proposes a block.
+func (s *simulator) equivocate(index int) {
+	s.faulty[index] = true
+	n := s.nodes[index]
+	s.filters = append(s.filters, func(p *simPacket) *simPacket {
+		if p.from != index || p.to%2 == 0 {
+			return p
+		}
+		msg := new(istanbul.Message)
+		if err := msg.FromPayload(p.payload, nil); err != nil || msg.Code != istanbul.MsgPreprepare {
+			return p
+		}
+		preprepare := msg.Preprepare()
+		header := preprepare.Proposal.(*types.Block).Header()
+		extra := make([]byte, len(header.Extra))
+		copy(extra, header.Extra)
+		copy(extra[:types.IstanbulExtraVanity], "equivocation")
+		header.Extra = extra
+		block, err := n.backend.signBlock(preprepare.Proposal.(*types.Block).WithHeader(header))
+		if err != nil {
+			s.t.Errorf("could not sign conflicting block: %v", err)
+			return p
+		}
+		conflicting := istanbul.NewPreprepareMessage(&istanbul.Preprepare{
+			View:                   preprepare.View,
+			RoundChangeCertificate: preprepare.RoundChangeCertificate,
+			Proposal:               block,
+		}, n.address)
+		if err := conflicting.Sign(n.backend.Sign); err != nil {
+			s.t.Errorf("could not sign conflicting preprepare: %v", err)
+			return p
+		}
+		payload, err := conflicting.Payload()
+		if err != nil {
+			s.t.Errorf("could not encode conflicting preprepare: %v", err)
+			return p
+		}
+		return &simPacket{from: p.from, to: p.to, code: p.code, payload: payload}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Node
+
+func (n *simNode) height() uint64 {
+	return n.chain.CurrentBlock().NumberU64()
+}
+
+// mine hands the backend a block on top of every new head, like the miner
+// does, and announces the head to the peers.
+func (n *simNode) mine() {
+	defer n.sim.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, 10)
+	sub := n.chain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	n.propose()
+	for {
+		select {
+		case <-heads:
+			// Only the latest head matters
+			for len(heads) > 0 {
+				<-heads
+			}
+			n.sim.announce(n)
+			n.propose()
+		case <-n.sim.quit:
+			return
+		}
+	}
+}
+
+func (n *simNode) propose() {
+	if err := n.backend.NewWork(); err != nil {
+		n.sim.t.Errorf("validator %d could not start new work: %v", n.index, err)
+		return
+	}
+	block := makeBlockWithoutSeal(n.chain, n.backend, n.chain.CurrentBlock())
+	if err := n.backend.Seal(n.chain, block); err != nil {
+		n.sim.t.Errorf("validator %d could not seal block %d: %v", n.index, block.NumberU64(), err)
+	}
+}
+
+// syncFrom imports the blocks the validator is missing from the chain of the
+// peer, the way the downloader does.
+func (n *simNode) syncFrom(peer *simNode) {
+	n.syncMu.Lock()
+	defer n.syncMu.Unlock()
+
+	var blocks types.Blocks
+	for number := n.height() + 1; number <= peer.height(); number++ {
+		block := peer.chain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	if index, err := n.chain.InsertChain(blocks); err != nil {
+		n.sim.t.Errorf("validator %d could not import block %d from validator %d: %v", n.index, blocks[index].NumberU64(), peer.index, err)
+	}
+}
+
+// receive hands a message from the peer to the backend, as the eth protocol
+// handler does.
+func (n *simNode) receive(from *simNode, code uint64, payload []byte) {
+	size, r, err := rlp.EncodeToReader(payload)
+	if err != nil {
+		n.sim.t.Errorf("could not encode message: %v", err)
+		return
+	}
+	msg := p2p.Msg{Code: code, Size: uint32(size), Payload: r}
+	if _, err := n.backend.HandleMsg(from.address, msg, n.peers[from.self.ID()]); err != nil {
+		n.sim.t.Errorf("validator %d could not handle message %d from validator %d: %v", n.index, code, from.index, err)
+	}
+}
+
+// gossipEnodeCertificate shares the enode of the validator with its peers.
+func (n *simNode) gossipEnodeCertificate() error {
+	enodeCertificate := &istanbul.EnodeCertificate{
+		EnodeURL: n.self.URLv4(),
+		Version:  uint(time.Now().Unix()),
+	}
+	enodeCertificateBytes, err := rlp.EncodeToBytes(enodeCertificate)
+	if err != nil {
+		return err
+	}
+	msg := &istanbul.Message{
+		Code:    istanbul.EnodeCertificateMsg,
+		Address: n.address,
+		Msg:     enodeCertificateBytes,
+	}
+	if err := msg.Sign(n.backend.Sign); err != nil {
+		return err
+	}
+	payload, err := msg.Payload()
+	if err != nil {
+		return err
+	}
+	return n.backend.Gossip(payload, istanbul.EnodeCertificateMsg)
+}
+
+// FindPeers implements consensus.Broadcaster.FindPeers
+func (n *simNode) FindPeers(targets map[enode.ID]bool, purpose p2p.PurposeFlag) map[enode.ID]consensus.Peer {
+	m := make(map[enode.ID]consensus.Peer)
+	for id, peer := range n.peers {
+		if targets == nil || targets[id] {
+			m[id] = peer
+		}
+	}
+	return m
+}
+
+// Self implements consensus.P2PServer.Self
+func (n *simNode) Self() *enode.Node {
+	return n.self
+}
+
+// The validators are always connected to each other
+func (n *simNode) AddPeer(node *enode.Node, purpose p2p.PurposeFlag)           {}
+func (n *simNode) RemovePeer(node *enode.Node, purpose p2p.PurposeFlag)        {}
+func (n *simNode) AddTrustedPeer(node *enode.Node, purpose p2p.PurposeFlag)    {}
+func (n *simNode) RemoveTrustedPeer(node *enode.Node, purpose p2p.PurposeFlag) {}
+
+// Send implements consensus.Peer.Send
+func (p *simPeer) Send(msgcode uint64, data interface{}) error {
+	return p.local.sim.send(p.local, p.remote, msgcode, data)
+}
+
+// Node implements consensus.Peer.Node
+func (p *simPeer) Node() *enode.Node {
+	return p.remote.self
+}
+
+// Version implements consensus.Peer.Version
+func (p *simPeer) Version() uint {
+	return istanbul.Celo67
+}
+
+// ReadMsg implements consensus.Peer.ReadMsg
+func (p *simPeer) ReadMsg() (p2p.Msg, error) {
+	return p2p.Msg{}, errors.New("handshakes are not simulated")
+}
+
+// Inbound implements consensus.Peer.Inbound
+func (p *simPeer) Inbound() bool {
+	return false
+}
+
+// PurposeIsSet implements consensus.Peer.PurposeIsSet
+func (p *simPeer) PurposeIsSet(purpose p2p.PurposeFlag) bool {
+	return true
+}
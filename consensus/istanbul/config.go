@@ -18,6 +18,7 @@ package istanbul
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/p2p/enode"
@@ -70,6 +71,10 @@ type Config struct {
 
 	// Load test config
 	LoadTestCSVFile string `toml:",omitempty"` // If non-empty, specifies the file to write out csv metrics about the block production cycle to.
+
+	// Now returns the current time used to prepare and verify block timestamps.
+	// Defaults to the system time if nil.
+	Now func() time.Time `toml:"-" json:"-"`
 }
 
 // ProxyConfig represents the configuration for validator's proxies
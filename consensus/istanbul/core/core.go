@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/mclock"
 	"github.com/celo-org/celo-blockchain/common/prque"
 	"github.com/celo-org/celo-blockchain/consensus"
 	"github.com/celo-org/celo-blockchain/consensus/istanbul"
@@ -61,6 +62,10 @@ type CoreBackend interface {
 	// If sendToSelf is set to true, then the function will send an event to self via a message event
 	Multicast(addresses []common.Address, payload []byte, ethMsgCode uint64, sendToSelf bool) error
 
+	// RecordConsensusTimes reports how long the consensus phases of a sequence took,
+	// right before its proposal is committed.
+	RecordConsensusTimes(seq *big.Int, times ConsensusTimes)
+
 	// Commit delivers an approved proposal to backend.
 	// The delivered proposal will be put into blockchain.
 	Commit(proposal istanbul.Proposal, aggregatedSeal types.IstanbulAggregatedSeal, aggregatedEpochValidatorSetSeal types.IstanbulEpochValidatorSetSeal, stateProcessResult *StateProcessResult) error
@@ -115,11 +120,16 @@ type core struct {
 	finalCommittedSub *event.TypeMuxSubscription
 	timeoutSub        *event.TypeMuxSubscription
 
-	futurePreprepareTimer           *time.Timer
-	resendRoundChangeMessageTimer   *time.Timer
+	// clock drives the consensus timers and async runs work that must not block
+	// the event loop. Tests replace both to run the core on virtual time.
+	clock mclock.Clock
+	async func(func())
+
+	futurePreprepareTimer           mclock.Timer
+	resendRoundChangeMessageTimer   mclock.Timer
 	resendRoundChangeMessageTimerMu sync.Mutex
 
-	roundChangeTimer   *time.Timer
+	roundChangeTimer   mclock.Timer
 	roundChangeTimerMu sync.RWMutex
 
 	validateFn func([]byte, []byte) (common.Address, error)
@@ -137,6 +147,9 @@ type core struct {
 	pendingRequestsMu *sync.Mutex
 
 	consensusTimestamp time.Time
+	// Start of the current sequence and time of reaching a prepare quorum, reported through RecordConsensusTimes
+	sequenceStart     time.Time
+	preparedTimestamp time.Time
 
 	// Time from accepting a pre-prepare (after block verifcation) to preparing or committing
 	consensusPrepareTimeGauge metrics.Gauge
@@ -161,6 +174,8 @@ func New(backend CoreBackend, config *istanbul.Config) Engine {
 		address:                   backend.Address(),
 		logger:                    log.New(),
 		selectProposer:            validator.GetProposerSelector(config.ProposerPolicy),
+		clock:                     mclock.System{},
+		async:                     func(f func()) { go f() },
 		handlerWg:                 new(sync.WaitGroup),
 		backend:                   backend,
 		pendingRequests:           prque.New(nil),
@@ -366,10 +381,12 @@ func (c *core) commit() error {
 	}
 
 	// Update metrics.
+	times := c.consensusTimes(time.Now())
 	if !c.consensusTimestamp.IsZero() {
 		c.consensusCommitTimeGauge.Update(time.Since(c.consensusTimestamp).Nanoseconds())
 		c.consensusTimestamp = time.Time{}
 	}
+	c.preparedTimestamp = time.Time{}
 
 	// Process Backlog Messages
 	c.backlog.updateState(c.current.View(), c.current.State())
@@ -391,6 +408,8 @@ func (c *core) commit() error {
 			return nil
 		}
 
+		c.backend.RecordConsensusTimes(proposal.Number(), times)
+
 		// Query the StateProcessResult cache, nil if it's cache miss
 		result := c.current.GetStateProcessResult(proposal.Hash())
 		if err := c.backend.Commit(proposal, aggregatedSeal, aggregatedEpochValidatorSetSeal, result); err != nil {
@@ -405,6 +424,29 @@ func (c *core) commit() error {
 	return nil
 }
 
+// consensusTimes returns how long each consensus phase of the current sequence took,
+// when committing at the given time. Phases that were skipped (e.g. reaching a commit
+// quorum before a prepare quorum) are reported as taking no time.
+func (c *core) consensusTimes(committed time.Time) ConsensusTimes {
+	preprepared, prepared := c.consensusTimestamp, c.preparedTimestamp
+	if preprepared.IsZero() || preprepared.After(committed) {
+		preprepared = committed
+	}
+	if prepared.IsZero() || prepared.Before(preprepared) || prepared.After(committed) {
+		prepared = committed
+	}
+	start := c.sequenceStart
+	if start.IsZero() || start.After(preprepared) {
+		start = preprepared
+	}
+	return ConsensusTimes{
+		Proposer:   c.isProposer(),
+		PrePrepare: preprepared.Sub(start),
+		Prepare:    prepared.Sub(preprepared),
+		Commit:     committed.Sub(prepared),
+	}
+}
+
 // GetAggregatedEpochValidatorSetSeal aggregates all the given seals for the SNARK-friendly epoch encoding
 // to a bls aggregated signature. Returns an empty signature on a non-epoch block.
 func GetAggregatedEpochValidatorSetSeal(blockNumber, epoch uint64, seals MessageSet) (types.IstanbulEpochValidatorSetSeal, error) {
@@ -535,6 +577,7 @@ func (c *core) startNewSequence() error {
 		// This function is called on a final committed event which should occur once the block is inserted into the chain.
 		return nil
 	}
+	c.sequenceStart = time.Now()
 
 	// Generate next view and preprepare
 	newView := &istanbul.View{
@@ -551,7 +594,7 @@ func (c *core) startNewSequence() error {
 		// function. We unsubscribe from events to stop the core from processing more events
 		// prior to being fully shut down.
 		c.unsubscribeEvents()
-		go c.backend.UpdateReplicaState(newView.Sequence)
+		c.async(func() { c.backend.UpdateReplicaState(newView.Sequence) })
 		return nil
 	}
 
@@ -757,7 +800,7 @@ func (c *core) resetRoundChangeTimer() {
 	view := &istanbul.View{Sequence: c.current.Sequence(), Round: c.current.DesiredRound()}
 	timeout := c.getRoundChangeTimeout()
 	c.roundChangeTimerMu.Lock()
-	c.roundChangeTimer = time.AfterFunc(timeout, func() {
+	c.roundChangeTimer = c.clock.AfterFunc(timeout, func() {
 		c.sendEvent(timeoutAndMoveToNextRoundEvent{view})
 	})
 	c.roundChangeTimerMu.Unlock()
@@ -787,7 +830,7 @@ func (c *core) resetResendRoundChangeTimer() {
 		view := &istanbul.View{Sequence: c.current.Sequence(), Round: c.current.DesiredRound()}
 		c.resendRoundChangeMessageTimerMu.Lock()
 		defer c.resendRoundChangeMessageTimerMu.Unlock()
-		c.resendRoundChangeMessageTimer = time.AfterFunc(resendTimeout, func() {
+		c.resendRoundChangeMessageTimer = c.clock.AfterFunc(resendTimeout, func() {
 			c.sendEvent(resendRoundChangeEvent{view})
 		})
 
@@ -26,7 +26,23 @@ import (
 
 // Start implements core.Engine.Start
 func (c *core) Start() error {
+	if err := c.start(); err != nil {
+		return err
+	}
+
+	// Tests will handle events itself, so we have to make subscribeEvents()
+	// be able to call in test.
+	c.subscribeEvents()
+
+	c.handlerWg.Add(1)
+	go c.handleEvents()
+
+	return nil
+}
 
+// start restores the round state and arms the round change timer, leaving the
+// delivery of events to the caller.
+func (c *core) start() error {
 	roundState, err := c.createRoundState()
 	if err != nil {
 		return err
@@ -44,13 +60,6 @@ func (c *core) Start() error {
 	c.processPendingRequests()
 	c.backlog.updateState(c.CurrentView(), c.current.State())
 
-	// Tests will handle events itself, so we have to make subscribeEvents()
-	// be able to call in test.
-	c.subscribeEvents()
-
-	c.handlerWg.Add(1)
-	go c.handleEvents()
-
 	return nil
 }
 
@@ -100,60 +109,63 @@ func (c *core) handleEvents() {
 	defer c.handlerWg.Done()
 
 	for {
-		logger := c.newLogger("func", "handleEvents")
 		select {
 		case event, ok := <-c.events.Chan():
 			if !ok {
 				return
 			}
 			// A real event arrived, process interesting content
-			switch ev := event.Data.(type) {
-			case istanbul.RequestEvent:
-				r := &istanbul.Request{
-					Proposal: ev.Proposal,
-				}
-				err := c.handleRequest(r)
-				if err == errFutureMessage {
-					c.storeRequestMsg(r)
-				}
-			case istanbul.MessageEvent:
-				if err := c.handleMsg(ev.Payload); err != nil && err != errFutureMessage && err != errOldMessage {
-					logger.Warn("Error in handling istanbul message", "err", err)
-				}
-			case backlogEvent:
-				if payload, err := ev.msg.Payload(); err != nil {
-					logger.Error("Error in retrieving payload from istanbul message that was sent from a backlog event", "err", err)
-				} else {
-					if err := c.handleMsg(payload); err != nil && err != errFutureMessage && err != errOldMessage {
-						logger.Warn("Error in handling istanbul message that was sent from a backlog event", "err", err)
-					}
-				}
-			}
+			c.handleEvent(event.Data)
 		case event, ok := <-c.timeoutSub.Chan():
 			if !ok {
 				return
 			}
-			switch ev := event.Data.(type) {
-			case timeoutAndMoveToNextRoundEvent:
-				if err := c.handleTimeoutAndMoveToNextRound(ev.view); err != nil {
-					logger.Error("Error on handleTimeoutAndMoveToNextRound", "err", err)
-				}
-			case resendRoundChangeEvent:
-				if err := c.handleResendRoundChangeEvent(ev.view); err != nil {
-					logger.Error("Error on handleResendRoundChangeEvent", "err", err)
-				}
-			}
+			c.handleEvent(event.Data)
 		case event, ok := <-c.finalCommittedSub.Chan():
 			if !ok {
 				return
 			}
-			switch event.Data.(type) {
-			case istanbul.FinalCommittedEvent:
-				if err := c.handleFinalCommitted(); err != nil {
-					logger.Error("Error on handleFinalCommit", "err", err)
-				}
+			c.handleEvent(event.Data)
+		}
+	}
+}
+
+// handleEvent processes a single event received from any of the subscriptions.
+func (c *core) handleEvent(data interface{}) {
+	logger := c.newLogger("func", "handleEvent")
+	switch ev := data.(type) {
+	case istanbul.RequestEvent:
+		r := &istanbul.Request{
+			Proposal: ev.Proposal,
+		}
+		err := c.handleRequest(r)
+		if err == errFutureMessage {
+			c.storeRequestMsg(r)
+		}
+	case istanbul.MessageEvent:
+		if err := c.handleMsg(ev.Payload); err != nil && err != errFutureMessage && err != errOldMessage {
+			logger.Warn("Error in handling istanbul message", "err", err)
+		}
+	case backlogEvent:
+		if payload, err := ev.msg.Payload(); err != nil {
+			logger.Error("Error in retrieving payload from istanbul message that was sent from a backlog event", "err", err)
+		} else {
+			if err := c.handleMsg(payload); err != nil && err != errFutureMessage && err != errOldMessage {
+				logger.Warn("Error in handling istanbul message that was sent from a backlog event", "err", err)
 			}
 		}
+	case timeoutAndMoveToNextRoundEvent:
+		if err := c.handleTimeoutAndMoveToNextRound(ev.view); err != nil {
+			logger.Error("Error on handleTimeoutAndMoveToNextRound", "err", err)
+		}
+	case resendRoundChangeEvent:
+		if err := c.handleResendRoundChangeEvent(ev.view); err != nil {
+			logger.Error("Error on handleResendRoundChangeEvent", "err", err)
+		}
+	case istanbul.FinalCommittedEvent:
+		if err := c.handleFinalCommitted(); err != nil {
+			logger.Error("Error on handleFinalCommit", "err", err)
+		}
 	}
 }
 
@@ -175,6 +175,7 @@ func (c *core) handlePrepare(msg *istanbul.Message) error {
 		}
 		logger.Trace("Got quorum prepares or commits", "tag", "stateTransition")
 		// Update metrics.
+		c.preparedTimestamp = time.Now()
 		if !c.consensusTimestamp.IsZero() {
 			c.consensusPrepareTimeGauge.Update(time.Since(c.consensusTimestamp).Nanoseconds())
 		}
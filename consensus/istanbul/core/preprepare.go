@@ -113,7 +113,7 @@ func (c *core) handlePreprepare(msg *istanbul.Message) error {
 		// if it's a future block, we will handle it again after the duration
 		if err == consensus.ErrFutureBlock {
 			c.stopFuturePreprepareTimer()
-			c.futurePreprepareTimer = time.AfterFunc(duration, func() {
+			c.futurePreprepareTimer = c.clock.AfterFunc(duration, func() {
 				c.sendEvent(backlogEvent{
 					msg: msg,
 				})
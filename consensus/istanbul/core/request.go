@@ -103,8 +103,11 @@ func (c *core) processPendingRequests() {
 		if err == nil {
 			c.logger.Trace("Post pending request", "number", r.Proposal.Number(), "hash", r.Proposal.Hash())
 
-			go c.sendEvent(istanbul.RequestEvent{
-				Proposal: r.Proposal,
+			proposal := r.Proposal
+			c.async(func() {
+				c.sendEvent(istanbul.RequestEvent{
+					Proposal: proposal,
+				})
 			})
 		} else if err == errFutureMessage {
 			c.logger.Trace("Stop processing request", "number", r.Proposal.Number(), "hash", r.Proposal.Hash())
@@ -94,6 +94,7 @@ func TestStoreRequestMsg(t *testing.T) {
 		}, valSet, valSet.GetByIndex(0)),
 		pendingRequests:   prque.New(nil),
 		pendingRequestsMu: new(sync.Mutex),
+		async:             func(f func()) { go f() },
 	}
 	requests := []istanbul.Request{
 		{
@@ -0,0 +1,152 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulationIdealNetwork(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 1))
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(20, time.Minute) {
+		t.Errorf("validators did not reach height 20, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	if sim.maxRound != 0 || sim.roundChanges != 0 {
+		t.Errorf("unexpected round changes on an ideal network: %d round changes, max round %d", sim.roundChanges, sim.maxRound)
+	}
+	sim.logStats()
+}
+
+func TestSimulationLossyNetwork(t *testing.T) {
+	config := newSimConfig(7, 2)
+	config.maxLatency = 800 * time.Millisecond
+	config.dropRate = 0.1
+	sim := newSimulator(t, config)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(10, 10*time.Minute) {
+		t.Errorf("validators did not reach height 10, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	sim.logStats()
+}
+
+// Reproduces a round change storm: no group of validators has a quorum while the
+// network is partitioned, so they keep changing rounds with growing timeouts and
+// have to agree on a round again once it heals.
+func TestSimulationPartitionHeal(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 3))
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(3, time.Minute) {
+		t.Fatalf("validators did not reach height 3, stuck at %d", sim.height())
+	}
+	sim.partition([]int{0, 1}, []int{2, 3})
+	sim.run(3 * time.Minute)
+	height := sim.height()
+	for _, n := range sim.nodes {
+		if n.height() > height+1 {
+			t.Errorf("validator %d made progress without a quorum: height %d", n.index, n.height())
+		}
+	}
+	if sim.roundChanges == 0 {
+		t.Errorf("no round changes while partitioned")
+	}
+
+	sim.heal()
+	if !sim.runUntil(height+5, 10*time.Minute) {
+		t.Errorf("validators did not recover from the partition, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	sim.logStats()
+}
+
+func TestSimulationSilentValidator(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 4))
+	// The validator is the first proposer
+	sim.silence(0)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(10, 10*time.Minute) {
+		t.Errorf("validators did not reach height 10, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	if sim.maxRound == 0 {
+		t.Errorf("blocks proposed by the silent validator were committed")
+	}
+	sim.logStats()
+}
+
+func TestSimulationEquivocatingProposer(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(4, 5))
+	sim.equivocate(0)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(10, 10*time.Minute) {
+		t.Errorf("validators did not reach height 10, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	sim.logStats()
+}
+
+func TestSimulationStaleRoundChangeSpam(t *testing.T) {
+	sim := newSimulator(t, newSimConfig(7, 6))
+	// A silent validator forces round changes, which leave stale rounds to spam
+	sim.silence(0)
+	sim.spamStaleRoundChanges(6, 100*time.Millisecond)
+	sim.start()
+	defer sim.stop()
+
+	if !sim.runUntil(10, 10*time.Minute) {
+		t.Errorf("validators did not reach height 10, stuck at %d", sim.height())
+	}
+	sim.checkSafety()
+	sim.logStats()
+}
+
+func TestSimulationDeterminism(t *testing.T) {
+	run := func() []string {
+		config := newSimConfig(4, 7)
+		config.maxLatency = 500 * time.Millisecond
+		config.dropRate = 0.05
+		sim := newSimulator(t, config)
+		sim.silence(3)
+		sim.start()
+		defer sim.stop()
+
+		sim.runUntil(5, 5*time.Minute)
+		return sim.trace
+	}
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Fatalf("runs committed a different number of blocks: %d != %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("runs diverged:\n%s\n%s", first[i], second[i])
+		}
+	}
+}
@@ -0,0 +1,764 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/mclock"
+	"github.com/celo-org/celo-blockchain/consensus"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/validator"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/crypto"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/event"
+	elog "github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/params"
+	"github.com/celo-org/celo-bls-go/bls"
+)
+
+// The simulator runs a network of validators, each driving a real Istanbul core
+// on top of an in-memory chain. The nodes share a virtual clock and all events
+// are handled on the goroutine running the simulation, in a fixed order, so a
+// run is fully determined by its configuration and seed.
+//
+// Only the core is under test: simNode implements CoreBackend itself in place
+// of backend.Backend. Proposals are empty blocks, only checked to extend the
+// head, and are committed to the in-memory chain without state processing. The
+// validator set is fixed. The simulator of the backend package runs the same
+// scenarios on real backends, which covers block verification, epochs and the
+// enode certificate exchange.
+//
+// Consensus messages travel over a simulated network which delays, drops,
+// reorders and partitions them. Filters on the network implement byzantine
+// behaviours. Blocks reach the validators that missed a commit the way they do
+// through eth sync: by block announcements and by periodically syncing from
+// reachable peers with a longer chain.
+
+// simTick is the step by which the virtual clock advances.
+const simTick = 10 * time.Millisecond
+
+type simConfig struct {
+	validators int
+	seed       int64
+
+	// Consensus messages and block announcements take a uniformly distributed
+	// latency between minLatency and maxLatency, so they can arrive reordered.
+	minLatency time.Duration
+	maxLatency time.Duration
+	// dropRate is the probability of a consensus message getting lost.
+	dropRate float64
+	// syncInterval is the period at which validators sync from their peers.
+	syncInterval time.Duration
+}
+
+func newSimConfig(validators int, seed int64) simConfig {
+	return simConfig{
+		validators:   validators,
+		seed:         seed,
+		minLatency:   10 * time.Millisecond,
+		maxLatency:   50 * time.Millisecond,
+		syncInterval: time.Second,
+	}
+}
+
+// simPacket is a consensus message in flight between two validators.
+type simPacket struct {
+	from, to int
+	payload  []byte
+}
+
+// simFilter inspects the consensus messages sent over the network. It returns
+// the packet to deliver, possibly modified, or nil to drop it.
+type simFilter func(p *simPacket) *simPacket
+
+// simBarrier is posted to a node's event mux to wait for the delivery of all
+// the events posted before it.
+type simBarrier struct{}
+
+type simulator struct {
+	t      *testing.T
+	config simConfig
+	clock  *mclock.Simulated
+	rand   *rand.Rand
+	nodes  []*simNode
+
+	// faulty marks the validators with a byzantine behaviour, which are left
+	// out of the liveness checks.
+	faulty  []bool
+	filters []simFilter
+	// groups holds the partition each validator is in, nil when the network
+	// isn't partitioned.
+	groups []int
+
+	// commits holds the hashes of the blocks committed by the cores and the
+	// validators which committed them, by height.
+	commits map[uint64]map[common.Hash][]int
+	// trace records every commit, to compare runs.
+	trace []string
+
+	roundChanges int
+	maxRound     uint64
+}
+
+// simNode is a validator of the simulation. It is the CoreBackend of its core,
+// standing in for backend.Backend.
+type simNode struct {
+	sim     *simulator
+	index   int
+	key     *ecdsa.PrivateKey
+	blsKey  []byte
+	address common.Address
+	valSet  istanbul.ValidatorSet
+	core    *core
+
+	mux     *event.TypeMux
+	sub     *event.TypeMuxSubscription
+	queueMu sync.Mutex
+	queue   []interface{}
+
+	// backlogged holds the messages released by the backlog of the core,
+	// which hands each of them over on its own goroutine. backlogWg counts
+	// the messages being handed over.
+	backlogWg  sync.WaitGroup
+	backlogMu  sync.Mutex
+	backlogged []*istanbul.Message
+
+	// chain holds the blocks of the node and rounds the round in which each
+	// of them was committed.
+	chain  []*types.Block
+	rounds []*big.Int
+}
+
+// newSimulator creates a network of validators whose keys are derived from the
+// seed. The cores are started by start.
+func newSimulator(t *testing.T, config simConfig) *simulator {
+	s := &simulator{
+		t:       t,
+		config:  config,
+		clock:   new(mclock.Simulated),
+		rand:    rand.New(rand.NewSource(config.seed)),
+		faulty:  make([]bool, config.validators),
+		commits: make(map[uint64]map[common.Hash][]int),
+	}
+
+	keys := make([]*ecdsa.PrivateKey, config.validators)
+	blsKeys := make([][]byte, config.validators)
+	validators := make([]istanbul.ValidatorData, config.validators)
+	for i := range keys {
+		key, err := crypto.ToECDSA(crypto.Keccak256([]byte(fmt.Sprintf("istanbul-simulator-%d-%d", config.seed, i))))
+		if err != nil {
+			t.Fatalf("could not derive key of validator %d: %v", i, err)
+		}
+		blsKey, err := blscrypto.ECDSAToBLS(key)
+		if err != nil {
+			t.Fatalf("could not derive BLS key of validator %d: %v", i, err)
+		}
+		blsPublicKey, err := blscrypto.PrivateToPublic(blsKey)
+		if err != nil {
+			t.Fatalf("could not derive BLS public key of validator %d: %v", i, err)
+		}
+		keys[i], blsKeys[i] = key, blsKey
+		validators[i] = istanbul.ValidatorData{
+			Address:      crypto.PubkeyToAddress(key.PublicKey),
+			BLSPublicKey: blsPublicKey,
+		}
+	}
+
+	istanbulConfig := *istanbul.DefaultConfig
+	istanbulConfig.ProposerPolicy = istanbul.RoundRobin
+	istanbulConfig.RoundStateDBPath = ""
+
+	genesis := types.NewBlockWithHeader(&types.Header{Number: common.Big0})
+	for i := range keys {
+		n := &simNode{
+			sim:     s,
+			index:   i,
+			key:     keys[i],
+			blsKey:  blsKeys[i],
+			address: validators[i].Address,
+			valSet:  validator.NewSet(validators),
+			mux:     new(event.TypeMux),
+			chain:   []*types.Block{genesis},
+			rounds:  []*big.Int{common.Big0},
+		}
+		n.sub = n.mux.Subscribe(
+			istanbul.RequestEvent{},
+			istanbul.MessageEvent{},
+			istanbul.FinalCommittedEvent{},
+			backlogEvent{},
+			timeoutAndMoveToNextRoundEvent{},
+			resendRoundChangeEvent{},
+			simBarrier{},
+		)
+		go n.pump()
+
+		logger := elog.New("validator", i)
+		logger.SetHandler(elog.DiscardHandler())
+		n.core = New(n, &istanbulConfig).(*core)
+		n.core.logger = logger
+		n.core.clock = s.clock
+		n.core.async = func(f func()) { f() }
+		n.core.backlog = newMsgBacklog(n.release, func(msgCode uint64, msgView *istanbul.View) error {
+			err := n.core.checkMessage(msgCode, msgView)
+			if err == nil {
+				// The backlog releases the message
+				n.backlogWg.Add(1)
+			}
+			return err
+		})
+		s.nodes = append(s.nodes, n)
+	}
+	return s
+}
+
+// start starts the cores and has every validator propose a block for the
+// first height.
+func (s *simulator) start() {
+	for _, n := range s.nodes {
+		if err := n.core.start(); err != nil {
+			s.t.Fatalf("could not start core of validator %d: %v", n.index, err)
+		}
+	}
+	for _, n := range s.nodes {
+		n.propose()
+	}
+	s.clock.AfterFunc(s.config.syncInterval, s.sync)
+	s.drain()
+}
+
+// stop stops the timers of the cores and the event delivery.
+func (s *simulator) stop() {
+	for _, n := range s.nodes {
+		n.core.stopAllTimers()
+		n.core.rsdb.Close()
+		n.mux.Stop()
+	}
+}
+
+// step advances the clock by one tick and handles the resulting events.
+func (s *simulator) step() {
+	s.clock.Run(simTick)
+	s.drain()
+}
+
+// drain handles the pending events of the nodes, in order, until none is left.
+func (s *simulator) drain() {
+	for {
+		handled := false
+		for _, n := range s.nodes {
+			for _, ev := range n.take() {
+				if _, ok := ev.(timeoutAndMoveToNextRoundEvent); ok {
+					s.roundChanges++
+				}
+				n.core.handleEvent(ev)
+				handled = true
+			}
+		}
+		if !handled {
+			return
+		}
+	}
+}
+
+// run advances the simulation by the given virtual duration.
+func (s *simulator) run(d time.Duration) {
+	end := s.clock.Now().Add(d)
+	for s.clock.Now() < end {
+		s.step()
+	}
+}
+
+// runUntil advances the simulation until every validator that isn't faulty
+// has the given height, for at most the given virtual duration. It reports
+// whether the height was reached.
+func (s *simulator) runUntil(height uint64, timeout time.Duration) bool {
+	end := s.clock.Now().Add(timeout)
+	for !s.reached(height) {
+		if s.clock.Now() >= end {
+			return false
+		}
+		s.step()
+	}
+	return true
+}
+
+func (s *simulator) reached(height uint64) bool {
+	for _, n := range s.nodes {
+		if !s.faulty[n.index] && n.height() < height {
+			return false
+		}
+	}
+	return true
+}
+
+// height returns the lowest chain height among the validators that aren't faulty.
+func (s *simulator) height() uint64 {
+	var height *uint64
+	for _, n := range s.nodes {
+		if h := n.height(); !s.faulty[n.index] && (height == nil || h < *height) {
+			height = &h
+		}
+	}
+	return *height
+}
+
+// checkSafety fails the test if validators committed conflicting blocks or
+// ended up with diverging chains.
+func (s *simulator) checkSafety() {
+	for height, hashes := range s.commits {
+		if len(hashes) > 1 {
+			s.t.Errorf("conflicting blocks committed at height %d: %v", height, hashes)
+		}
+	}
+	for _, n := range s.nodes[1:] {
+		for height := range n.chain {
+			if height >= len(s.nodes[0].chain) {
+				break
+			}
+			if n.chain[height].Hash() != s.nodes[0].chain[height].Hash() {
+				s.t.Errorf("validators 0 and %d diverge at height %d", n.index, height)
+				break
+			}
+		}
+	}
+}
+
+func (s *simulator) logStats() {
+	s.t.Logf("virtual time %v, height %d, %d round changes, max committed round %d",
+		time.Duration(s.clock.Now()), s.height(), s.roundChanges, s.maxRound)
+}
+
+// recordCommit records a block committed by the core of the node.
+func (s *simulator) recordCommit(n *simNode, block *types.Block, round *big.Int) {
+	hashes, ok := s.commits[block.NumberU64()]
+	if !ok {
+		hashes = make(map[common.Hash][]int)
+		s.commits[block.NumberU64()] = hashes
+	}
+	hashes[block.Hash()] = append(hashes[block.Hash()], n.index)
+	if round.Uint64() > s.maxRound {
+		s.maxRound = round.Uint64()
+	}
+	s.trace = append(s.trace, fmt.Sprintf("%v: validator %d committed block %d (%x) in round %d",
+		time.Duration(s.clock.Now()), n.index, block.NumberU64(), block.Hash(), round))
+}
+
+// ----------------------------------------------------------------------------
+// Network
+
+func (s *simulator) nodeIndex(address common.Address) int {
+	for _, n := range s.nodes {
+		if n.address == address {
+			return n.index
+		}
+	}
+	return -1
+}
+
+func (s *simulator) connected(from, to int) bool {
+	return s.groups == nil || s.groups[from] == s.groups[to]
+}
+
+func (s *simulator) latency() time.Duration {
+	spread := int64(s.config.maxLatency - s.config.minLatency)
+	if spread <= 0 {
+		return s.config.minLatency
+	}
+	return s.config.minLatency + time.Duration(s.rand.Int63n(spread+1))
+}
+
+// send passes a consensus message through the filters and the network.
+func (s *simulator) send(from, to int, payload []byte) {
+	p := &simPacket{from: from, to: to, payload: payload}
+	for _, filter := range s.filters {
+		if p = filter(p); p == nil {
+			return
+		}
+	}
+	if !s.connected(p.from, p.to) || s.rand.Float64() < s.config.dropRate {
+		return
+	}
+	dest := s.nodes[p.to]
+	s.clock.AfterFunc(s.latency(), func() {
+		dest.mux.Post(istanbul.MessageEvent{Payload: p.payload})
+	})
+}
+
+// announce sends a newly committed block to the other validators, which
+// import it along with any block they're missing.
+func (s *simulator) announce(from *simNode, number uint64) {
+	for _, to := range s.nodes {
+		if to == from || !s.connected(from.index, to.index) {
+			continue
+		}
+		to := to
+		s.clock.AfterFunc(s.latency(), func() { to.syncFrom(from, number) })
+	}
+}
+
+// sync has every validator import the blocks it's missing from the first
+// reachable peer with a longer chain.
+func (s *simulator) sync() {
+	for _, n := range s.nodes {
+		for _, peer := range s.nodes {
+			if peer != n && s.connected(peer.index, n.index) && peer.height() > n.height() {
+				n.syncFrom(peer, peer.height())
+				break
+			}
+		}
+	}
+	s.clock.AfterFunc(s.config.syncInterval, s.sync)
+}
+
+// partition splits the network into the given groups of validators. Those
+// left out form a group of their own.
+func (s *simulator) partition(groups ...[]int) {
+	s.groups = make([]int, len(s.nodes))
+	for i, group := range groups {
+		for _, index := range group {
+			s.groups[index] = i + 1
+		}
+	}
+}
+
+// heal removes the partitions of the network.
+func (s *simulator) heal() {
+	s.groups = nil
+}
+
+// ----------------------------------------------------------------------------
+// Byzantine behaviours
+
+// silence drops every consensus message sent by the validator.
+func (s *simulator) silence(index int) {
+	s.faulty[index] = true
+	s.filters = append(s.filters, func(p *simPacket) *simPacket {
+		if p.from == index {
+			return nil
+		}
+		return p
+	})
+}
+
+// equivocate makes the validator send a different proposal to the validators
+// with an odd index whenever it proposes a block.
+func (s *simulator) equivocate(index int) {
+	s.faulty[index] = true
+	n := s.nodes[index]
+	s.filters = append(s.filters, func(p *simPacket) *simPacket {
+		if p.from != index || p.to%2 == 0 {
+			return p
+		}
+		msg := new(istanbul.Message)
+		if err := msg.FromPayload(p.payload, nil); err != nil || msg.Code != istanbul.MsgPreprepare {
+			return p
+		}
+		preprepare := msg.Preprepare()
+		header := preprepare.Proposal.(*types.Block).Header()
+		header.Extra = []byte("equivocation")
+		conflicting := istanbul.NewPreprepareMessage(&istanbul.Preprepare{
+			View:                   preprepare.View,
+			RoundChangeCertificate: preprepare.RoundChangeCertificate,
+			Proposal:               types.NewBlockWithHeader(header),
+		}, n.address)
+		if err := conflicting.Sign(n.Sign); err != nil {
+			s.t.Fatalf("could not sign conflicting preprepare: %v", err)
+		}
+		payload, err := conflicting.Payload()
+		if err != nil {
+			s.t.Fatalf("could not encode conflicting preprepare: %v", err)
+		}
+		return &simPacket{from: p.from, to: p.to, payload: payload}
+	})
+}
+
+// spamStaleRoundChanges makes the validator send, at every interval, signed
+// round change messages for the rounds of its current sequence it has left
+// and for the previous sequence.
+func (s *simulator) spamStaleRoundChanges(index int, interval time.Duration) {
+	s.faulty[index] = true
+	n := s.nodes[index]
+	var spam func()
+	spam = func() {
+		view := n.core.current.View()
+		var views []*istanbul.View
+		for round := int64(0); round < view.Round.Int64(); round++ {
+			views = append(views, &istanbul.View{Sequence: view.Sequence, Round: big.NewInt(round)})
+		}
+		if view.Sequence.Cmp(common.Big1) > 0 {
+			for round := int64(0); round < 3; round++ {
+				views = append(views, &istanbul.View{Sequence: new(big.Int).Sub(view.Sequence, common.Big1), Round: big.NewInt(round)})
+			}
+		}
+		for _, view := range views {
+			msg := istanbul.NewRoundChangeMessage(&istanbul.RoundChange{
+				View:                view,
+				PreparedCertificate: istanbul.EmptyPreparedCertificate(),
+			}, n.address)
+			if err := msg.Sign(n.Sign); err != nil {
+				s.t.Fatalf("could not sign round change: %v", err)
+			}
+			payload, err := msg.Payload()
+			if err != nil {
+				s.t.Fatalf("could not encode round change: %v", err)
+			}
+			for _, to := range s.nodes {
+				if to != n {
+					s.send(index, to.index, payload)
+				}
+			}
+		}
+		s.clock.AfterFunc(interval, spam)
+	}
+	s.clock.AfterFunc(interval, spam)
+}
+
+// ----------------------------------------------------------------------------
+// Node
+
+// pump queues the events posted to the node, for the simulation to handle.
+func (n *simNode) pump() {
+	for ev := range n.sub.Chan() {
+		if _, ok := ev.Data.(simBarrier); ok {
+			continue
+		}
+		n.queueMu.Lock()
+		n.queue = append(n.queue, ev.Data)
+		n.queueMu.Unlock()
+	}
+}
+
+// release collects a message released by the backlog of the core.
+func (n *simNode) release(msg *istanbul.Message) {
+	n.backlogMu.Lock()
+	n.backlogged = append(n.backlogged, msg)
+	n.backlogMu.Unlock()
+	n.backlogWg.Done()
+}
+
+// take returns the events posted to the node so far, followed by the messages
+// released by the backlog.
+func (n *simNode) take() []interface{} {
+	// Posting returns once the pump received the barrier, which is after it
+	// queued every earlier event.
+	n.mux.Post(simBarrier{})
+	n.queueMu.Lock()
+	queue := n.queue
+	n.queue = nil
+	n.queueMu.Unlock()
+
+	// The goroutines releasing the messages run in any order, so the messages
+	// are sorted the way the backlog orders them, then by sender and content.
+	n.backlogWg.Wait()
+	n.backlogMu.Lock()
+	backlogged := n.backlogged
+	n.backlogged = nil
+	n.backlogMu.Unlock()
+	sort.SliceStable(backlogged, func(i, j int) bool {
+		a, b := backlogged[i], backlogged[j]
+		pa, pb := toPriority(a.Code, extractMessageView(a)), toPriority(b.Code, extractMessageView(b))
+		if pa != pb {
+			return pa > pb
+		}
+		if c := bytes.Compare(a.Address[:], b.Address[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(a.Signature, b.Signature) < 0
+	})
+	for _, msg := range backlogged {
+		queue = append(queue, backlogEvent{msg: msg})
+	}
+	return queue
+}
+
+func (n *simNode) head() *types.Block {
+	return n.chain[len(n.chain)-1]
+}
+
+func (n *simNode) height() uint64 {
+	return uint64(len(n.chain) - 1)
+}
+
+// propose hands the core a block on top of the head, like the miner does.
+func (n *simNode) propose() {
+	head := n.head()
+	block := types.NewBlockWithHeader(&types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number(), common.Big1),
+		Coinbase:   n.address,
+		Time:       head.Time() + 1,
+	})
+	n.mux.Post(istanbul.RequestEvent{Proposal: block})
+}
+
+// insert appends a block committed in the given round to the chain.
+func (n *simNode) insert(block *types.Block, round *big.Int) {
+	n.chain = append(n.chain, block)
+	n.rounds = append(n.rounds, round)
+	n.mux.Post(istanbul.FinalCommittedEvent{})
+	n.propose()
+}
+
+// syncFrom imports the blocks of the peer up to the given height.
+func (n *simNode) syncFrom(peer *simNode, height uint64) {
+	for number := n.height() + 1; number <= height && number <= peer.height(); number++ {
+		block := peer.chain[number]
+		if block.ParentHash() != n.head().Hash() {
+			return
+		}
+		n.insert(block, peer.rounds[number])
+	}
+}
+
+func (n *simNode) Address() common.Address {
+	return n.address
+}
+
+func (n *simNode) ChainConfig() *params.ChainConfig {
+	return &params.ChainConfig{EspressoBlock: common.Big0}
+}
+
+func (n *simNode) Validators(proposal istanbul.Proposal) istanbul.ValidatorSet {
+	return n.valSet
+}
+
+func (n *simNode) NextBlockValidators(proposal istanbul.Proposal) (istanbul.ValidatorSet, error) {
+	return n.valSet, nil
+}
+
+func (n *simNode) EventMux() *event.TypeMux {
+	return n.mux
+}
+
+func (n *simNode) Gossip(payload []byte, ethMsgCode uint64) error {
+	return nil
+}
+
+func (n *simNode) Multicast(addresses []common.Address, payload []byte, ethMsgCode uint64, sendToSelf bool) error {
+	for _, address := range addresses {
+		if address == n.address {
+			if sendToSelf {
+				n.mux.Post(istanbul.MessageEvent{Payload: payload})
+			}
+			continue
+		}
+		if index := n.sim.nodeIndex(address); index >= 0 {
+			n.sim.send(n.index, index, payload)
+		}
+	}
+	return nil
+}
+
+func (n *simNode) RecordConsensusTimes(seq *big.Int, times ConsensusTimes) {}
+
+func (n *simNode) Commit(proposal istanbul.Proposal, aggregatedSeal types.IstanbulAggregatedSeal, aggregatedEpochValidatorSetSeal types.IstanbulEpochValidatorSetSeal, stateProcessResult *StateProcessResult) error {
+	block := proposal.(*types.Block)
+	n.sim.recordCommit(n, block, aggregatedSeal.Round)
+	// The block may have been synced from a peer already
+	if block.NumberU64() != n.height()+1 || block.ParentHash() != n.head().Hash() {
+		return nil
+	}
+	n.insert(block, aggregatedSeal.Round)
+	n.sim.announce(n, block.NumberU64())
+	return nil
+}
+
+func (n *simNode) Verify(proposal istanbul.Proposal) (*StateProcessResult, time.Duration, error) {
+	if proposal.ParentHash() != n.head().Hash() {
+		return nil, 0, consensus.ErrUnknownAncestor
+	}
+	return nil, 0, nil
+}
+
+func (n *simNode) Sign(data []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(data), n.key)
+}
+
+func (n *simNode) SignBLS(data []byte, extra []byte, useComposite, cip22 bool) (blscrypto.SerializedSignature, error) {
+	privateKey, err := bls.DeserializePrivateKey(n.blsKey)
+	if err != nil {
+		return blscrypto.SerializedSignature{}, err
+	}
+	defer privateKey.Destroy()
+
+	signature, err := privateKey.SignMessage(data, extra, useComposite, cip22)
+	if err != nil {
+		return blscrypto.SerializedSignature{}, err
+	}
+	defer signature.Destroy()
+	signatureBytes, err := signature.Serialize()
+	if err != nil {
+		return blscrypto.SerializedSignature{}, err
+	}
+	return blscrypto.SerializedSignatureFromBytes(signatureBytes)
+}
+
+func (n *simNode) CheckSignature(data []byte, address common.Address, sig []byte) error {
+	return nil
+}
+
+func (n *simNode) GetCurrentHeadBlock() istanbul.Proposal {
+	return n.head()
+}
+
+func (n *simNode) GetCurrentHeadBlockAndAuthor() (istanbul.Proposal, common.Address) {
+	return n.head(), n.head().Coinbase()
+}
+
+func (n *simNode) LastSubject() (istanbul.Subject, error) {
+	view := &istanbul.View{Sequence: n.head().Number(), Round: n.rounds[len(n.rounds)-1]}
+	return istanbul.Subject{View: view, Digest: n.head().Hash()}, nil
+}
+
+func (n *simNode) HasBlock(hash common.Hash, number *big.Int) bool {
+	return number.Uint64() <= n.height() && n.chain[number.Uint64()].Hash() == hash
+}
+
+func (n *simNode) AuthorForBlock(number uint64) common.Address {
+	if number > n.height() {
+		return common.Address{}
+	}
+	return n.chain[number].Coinbase()
+}
+
+func (n *simNode) HashForBlock(number uint64) common.Hash {
+	if number > n.height() {
+		return common.Hash{}
+	}
+	return n.chain[number].Hash()
+}
+
+func (n *simNode) ParentBlockValidators(proposal istanbul.Proposal) istanbul.ValidatorSet {
+	return n.valSet
+}
+
+func (n *simNode) IsPrimaryForSeq(seq *big.Int) bool {
+	return true
+}
+
+func (n *simNode) UpdateReplicaState(seq *big.Int) {}
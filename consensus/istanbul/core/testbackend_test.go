@@ -168,6 +168,8 @@ func (self *testSystemBackend) SignBLS(data []byte, extra []byte, useComposite,
 	return blscrypto.SerializedSignatureFromBytes(signatureBytes)
 }
 
+func (self *testSystemBackend) RecordConsensusTimes(seq *big.Int, times ConsensusTimes) {}
+
 func (self *testSystemBackend) Commit(proposal istanbul.Proposal, aggregatedSeal types.IstanbulAggregatedSeal, aggregatedEpochValidatorSetSeal types.IstanbulEpochValidatorSetSeal, stateProcessResult *StateProcessResult) error {
 	testLogger.Info("commit message", "address", self.Address())
 	self.committedMsgs = append(self.committedMsgs, testCommittedMsgs{
@@ -17,6 +17,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/consensus/istanbul"
 	"github.com/celo-org/celo-blockchain/rlp"
@@ -36,6 +38,14 @@ type Engine interface {
 	ForceRoundChange()
 }
 
+// ConsensusTimes holds how long each consensus phase of a sequence took.
+type ConsensusTimes struct {
+	Proposer   bool          // Whether this node was the proposer of the committed round
+	PrePrepare time.Duration // From the start of the sequence to accepting a preprepare
+	Prepare    time.Duration // From accepting the preprepare to a prepare quorum
+	Commit     time.Duration // From the prepare quorum to a commit quorum
+}
+
 // State represents the IBFT state
 type State uint64
 
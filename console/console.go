@@ -203,6 +203,14 @@ func (c *Console) initExtensions() error {
 			}
 		}
 	}
+	// Load the helpers once all extensions are, as they may use other modules.
+	for api := range apis {
+		if file, ok := web3ext.Helpers[api]; ok {
+			if err = c.jsre.Compile(api+"-helpers.js", file); err != nil {
+				return fmt.Errorf("%s-helpers.js: %v", api, err)
+			}
+		}
+	}
 
 	// Apply aliases.
 	c.jsre.Do(func(vm *goja.Runtime) {
@@ -193,6 +193,27 @@ func TestEvaluate(t *testing.T) {
 	}
 }
 
+// Tests that the helpers of the modules are loaded and use the raw RPCs.
+func TestIstanbulHelpers(t *testing.T) {
+	tester := newTester(t, nil)
+	defer tester.Close(t)
+
+	tester.console.Evaluate("typeof istanbul.whyNotSigning")
+	if output := tester.output.String(); !strings.Contains(output, "function") {
+		t.Fatalf("istanbul helpers not loaded: have %s", output)
+	}
+	tester.output.Reset()
+	tester.console.Evaluate("istanbul.proxyStatus().role")
+	if output := tester.output.String(); !strings.Contains(output, "none") {
+		t.Fatalf("proxy status mismatch: have %s, want none", output)
+	}
+	tester.output.Reset()
+	tester.console.Evaluate("istanbul.handover(0)")
+	if output := tester.output.String(); !strings.Contains(output, "positive block number") {
+		t.Fatalf("handover of invalid block not rejected: have %s", output)
+	}
+}
+
 // Tests that the console can be used in interactive mode.
 func TestInteractive(t *testing.T) {
 	// Create a tester and run an interactive console in the background
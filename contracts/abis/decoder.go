@@ -0,0 +1,212 @@
+package abis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	ethereum "github.com/celo-org/celo-blockchain"
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+// byContractName maps the names of the core contracts in the registry to their ABIs.
+var byContractName = map[string]*abi.ABI{
+	"Accounts":             Accounts,
+	"BlockchainParameters": BlockchainParameters,
+	"Election":             mergeAbis(Elections, mustParseAbi("ElectionVoting", ElectionVotingStr)),
+	"EpochRewards":         EpochRewards,
+	"Exchange":             Exchange,
+	"ExchangeBRL":          Exchange,
+	"ExchangeEUR":          Exchange,
+	"FeeCurrencyWhitelist": FeeCurrency,
+	"Freezer":              Freezer,
+	"GasPriceMinimum":      GasPriceMinimum,
+	"GoldToken":            GoldToken,
+	"Governance":           Governance,
+	"LockedGold":           LockedGold,
+	"Random":               Random,
+	"SortedOracles":        SortedOracles,
+	"StableToken":          StableToken,
+	"StableTokenBRL":       StableToken,
+	"StableTokenEUR":       StableToken,
+	"Validators":           Validators,
+}
+
+// mergeAbis returns an ABI with the methods of all the given ABIs, which are
+// parts of the ABI of the same contract.
+func mergeAbis(parts ...*abi.ABI) *abi.ABI {
+	merged := &abi.ABI{Methods: make(map[string]abi.Method), Events: make(map[string]abi.Event)}
+	for _, part := range parts {
+		for name, method := range part.Methods {
+			merged.Methods[name] = method
+		}
+		for name, event := range part.Events {
+			merged.Events[name] = event
+		}
+	}
+	return merged
+}
+
+// Decoder decodes the call data of calls to the celo core contracts.
+//
+// Methods are identified from the ABIs bundled in this package. The called contract
+// is only identified for addresses of core contracts resolved through the registry,
+// calls to other addresses are decoded without a contract name, as any contract can
+// have a method with the selector of a core contract method.
+type Decoder struct {
+	methods   map[string]*abi.Method      // Methods by hex selector, from the ABIs of all contracts
+	contracts map[common.Address]string   // Contract names by address, resolved through the registry
+	abis      map[common.Address]*abi.ABI // Contract ABIs by address, resolved through the registry
+}
+
+// NewDecoder creates a decoder of the bundled core contract ABIs.
+func NewDecoder() *Decoder {
+	d := &Decoder{
+		methods:   make(map[string]*abi.Method),
+		contracts: map[common.Address]string{params.RegistrySmartContractAddress: "Registry"},
+		abis:      map[common.Address]*abi.ABI{params.RegistrySmartContractAddress: Registry},
+	}
+	// Methods shared by several contracts, such as the ERC20 ones, have the same
+	// signature, so the first one found is kept.
+	for _, name := range contractNames() {
+		for _, method := range byContractName[name].Methods {
+			method := method
+			selector := common.Bytes2Hex(method.ID)
+			if _, ok := d.methods[selector]; !ok {
+				d.methods[selector] = &method
+			}
+		}
+	}
+	return d
+}
+
+// contractNames returns the names of the core contracts, sorted.
+func contractNames() []string {
+	names := make([]string, 0, len(byContractName))
+	for name := range byContractName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveRegistry resolves the addresses of the core contracts with the given
+// function, which returns the address registered for a registry id. Contracts
+// registered at the zero address are skipped.
+func (d *Decoder) ResolveRegistry(getAddress func(registryId common.Hash) (common.Address, error)) error {
+	for _, name := range contractNames() {
+		address, err := getAddress(crypto.Keccak256Hash([]byte(name)))
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %v", name, err)
+		}
+		if address == (common.Address{}) {
+			continue
+		}
+		d.contracts[address] = name
+		d.abis[address] = byContractName[name]
+	}
+	return nil
+}
+
+// ResolveRegistryAt resolves the addresses of the core contracts by calling the
+// registry contract at the given block. The block number can be nil, in which case
+// the latest block is used.
+func (d *Decoder) ResolveRegistryAt(ctx context.Context, caller ethereum.ContractCaller, blockNumber *big.Int) error {
+	return d.ResolveRegistry(func(registryId common.Hash) (common.Address, error) {
+		input, err := Registry.Pack("getAddressFor", registryId)
+		if err != nil {
+			return common.Address{}, err
+		}
+		registry := params.RegistrySmartContractAddress
+		output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &registry, Data: input}, blockNumber)
+		if err != nil {
+			return common.Address{}, err
+		}
+		// The registry is not deployed
+		if len(output) == 0 {
+			return common.Address{}, nil
+		}
+		var address common.Address
+		err = Registry.UnpackIntoInterface(&address, "getAddressFor", output)
+		return address, err
+	})
+}
+
+// ContractName returns the name of the core contract at the given address, if it
+// has been resolved through the registry.
+func (d *Decoder) ContractName(address common.Address) (string, bool) {
+	name, ok := d.contracts[address]
+	return name, ok
+}
+
+// Call is a decoded call to a core contract.
+type Call struct {
+	Contract string // Name of the called contract, empty if it could not be identified
+	Method   *abi.Method
+	Args     []interface{}
+}
+
+// String implements stringer interface, showing the contract, method and the
+// named arguments, e.g. StableToken.transfer(to: 0x..., value: 1).
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		input := c.Method.Inputs[i]
+		name := input.Name
+		if name == "" {
+			name = input.Type.String()
+		}
+		switch value := arg.(type) {
+		case string:
+			args[i] = fmt.Sprintf("%s: %q", name, value)
+		case []byte:
+			args[i] = fmt.Sprintf("%s: %#x", name, value)
+		case [32]byte:
+			args[i] = fmt.Sprintf("%s: %#x", name, value)
+		default:
+			args[i] = fmt.Sprintf("%s: %v", name, value)
+		}
+	}
+	method := fmt.Sprintf("%s(%s)", c.Method.RawName, strings.Join(args, ", "))
+	if c.Contract == "" {
+		return method
+	}
+	return c.Contract + "." + method
+}
+
+// Decode decodes the given call data of a call to the given address, which may be
+// nil if it is not known.
+func (d *Decoder) Decode(to *common.Address, data []byte) (*Call, error) {
+	if len(data) < 4 {
+		return nil, errors.New("call data without method selector")
+	}
+	call := new(Call)
+	if to != nil {
+		if contractAbi, ok := d.abis[*to]; ok {
+			method, err := contractAbi.MethodById(data[:4])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", d.contracts[*to], err)
+			}
+			call.Contract, call.Method = d.contracts[*to], method
+		}
+	}
+	if call.Method == nil {
+		method, ok := d.methods[common.Bytes2Hex(data[:4])]
+		if !ok {
+			return nil, fmt.Errorf("no core contract method with id: %#x", data[:4])
+		}
+		call.Method = method
+	}
+	args, err := call.Method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", call.Method.Sig, err)
+	}
+	call.Args = args
+	return call, nil
+}
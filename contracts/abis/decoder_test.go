@@ -0,0 +1,115 @@
+package abis
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	ethereum "github.com/celo-org/celo-blockchain"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+var (
+	stableTokenAddress = common.HexToAddress("0x765de816845861e75a25fca122bb6898b8b1282a")
+	recipient          = common.HexToAddress("0x000000000000000000000000000000000000beef")
+)
+
+func mustPack(t *testing.T, name, method string, args ...interface{}) []byte {
+	data, err := byContractName[name].Pack(method, args...)
+	if err != nil {
+		t.Fatalf("failed to pack %s.%s: %v", name, method, err)
+	}
+	return data
+}
+
+func TestDecode(t *testing.T) {
+	d := NewDecoder()
+	transfer := mustPack(t, "StableToken", "transferWithComment", recipient, big.NewInt(1000), "rent")
+
+	tests := []struct {
+		to   *common.Address
+		data []byte
+		want string
+	}{
+		// Without registry resolution, the contract is never named, even for methods of a single ABI
+		{nil, mustPack(t, "Accounts", "setName", "alice"), `setName(name: "alice")`},
+		{&stableTokenAddress, transfer, `transferWithComment(to: 0x000000000000000000000000000000000000bEEF, value: 1000, comment: "rent")`},
+		{&recipient, mustPack(t, "LockedGold", "lock"), `lock()`},
+	}
+	for i, tt := range tests {
+		call, err := d.Decode(tt.to, tt.data)
+		if err != nil {
+			t.Fatalf("test %d: decoding failed: %v", i, err)
+		}
+		if have := call.String(); have != tt.want {
+			t.Errorf("test %d: have %s, want %s", i, have, tt.want)
+		}
+	}
+
+	// Once resolved, the contract is identified from the address
+	err := d.ResolveRegistry(func(registryId common.Hash) (common.Address, error) {
+		if registryId == params.StableTokenRegistryId {
+			return stableTokenAddress, nil
+		}
+		return common.Address{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := d.ContractName(stableTokenAddress); !ok || name != "StableToken" {
+		t.Errorf("wrong contract name: have %q, want StableToken", name)
+	}
+	call, err := d.Decode(&stableTokenAddress, transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `StableToken.transferWithComment(to: 0x000000000000000000000000000000000000bEEF, value: 1000, comment: "rent")`
+	if have := call.String(); have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	// Calls to other addresses are not attributed to a core contract
+	call, err = d.Decode(&recipient, transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Contract != "" {
+		t.Errorf("call to unresolved address attributed to %s", call.Contract)
+	}
+	// Methods of other contracts are not decoded for a known contract
+	if _, err := d.Decode(&stableTokenAddress, mustPack(t, "LockedGold", "lock")); err == nil {
+		t.Error("decoded a LockedGold method of StableToken")
+	}
+	if _, err := d.Decode(&params.RegistrySmartContractAddress, mustPack(t, "Election", "activate", recipient)); err == nil {
+		t.Error("decoded an Election method of the registry")
+	}
+	if _, err := d.Decode(nil, []byte{0xde, 0xad, 0xbe, 0xef}); err == nil {
+		t.Error("decoded an unknown method")
+	}
+}
+
+// registryCaller serves getAddressFor calls of the registry.
+type registryCaller map[common.Hash]common.Address
+
+func (c registryCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args, err := Registry.Methods["getAddressFor"].Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	return Registry.Methods["getAddressFor"].Outputs.Pack(c[args[0].([32]byte)])
+}
+
+func TestResolveRegistryAt(t *testing.T) {
+	d := NewDecoder()
+	caller := registryCaller{crypto.Keccak256Hash([]byte("StableTokenEUR")): stableTokenAddress}
+	if err := d.ResolveRegistryAt(context.Background(), caller, nil); err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := d.ContractName(stableTokenAddress); !ok || name != "StableTokenEUR" {
+		t.Errorf("wrong contract name: have %q, want StableTokenEUR", name)
+	}
+	if _, ok := d.ContractName(recipient); ok {
+		t.Error("unregistered address resolved")
+	}
+}
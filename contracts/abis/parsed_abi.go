@@ -22,6 +22,11 @@ var (
 	GoldToken            *abi.ABI = mustParseAbi("GoldToken", GoldTokenStr)
 	Random               *abi.ABI = mustParseAbi("Random", RandomStr)
 	Validators           *abi.ABI = mustParseAbi("Validators", ValidatorsStr)
+	StableToken          *abi.ABI = mustParseAbi("StableToken", StableTokenStr)
+	Accounts             *abi.ABI = mustParseAbi("Accounts", AccountsStr)
+	LockedGold           *abi.ABI = mustParseAbi("LockedGold", LockedGoldStr)
+	Exchange             *abi.ABI = mustParseAbi("Exchange", ExchangeStr)
+	Governance           *abi.ABI = mustParseAbi("Governance", GovernanceStr)
 )
 
 func mustParseAbi(name, abiStr string) *abi.ABI {
@@ -17,36 +17,26 @@
 package blockchain_parameters
 
 import (
-	"math/big"
-	"time"
-
 	"github.com/celo-org/celo-blockchain/common/hexutil"
 	"github.com/celo-org/celo-blockchain/contracts"
-	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/core/vm"
 	"github.com/celo-org/celo-blockchain/log"
 	"github.com/celo-org/celo-blockchain/params"
 )
 
-var (
-	getMinimumClientVersionMethod               = contracts.NewRegisteredContractMethod(params.BlockchainParametersRegistryId, abis.BlockchainParameters, "getMinimumClientVersion", params.MaxGasForReadBlockchainParameter)
-	intrinsicGasForAlternativeFeeCurrencyMethod = contracts.NewRegisteredContractMethod(params.BlockchainParametersRegistryId, abis.BlockchainParameters, "intrinsicGasForAlternativeFeeCurrency", params.MaxGasForReadBlockchainParameter)
-	blockGasLimitMethod                         = contracts.NewRegisteredContractMethod(params.BlockchainParametersRegistryId, abis.BlockchainParameters, "blockGasLimit", params.MaxGasForReadBlockchainParameter)
-	getUptimeLookbackWindowMethod               = contracts.NewRegisteredContractMethod(params.BlockchainParametersRegistryId, abis.BlockchainParameters, "getUptimeLookbackWindow", params.MaxGasForReadBlockchainParameter)
-)
+var blockchainParametersContract = NewBlockchainParameters()
 
-// getMinimumVersion retrieves the client required minimum version
+// GetMinimumVersion retrieves the client required minimum version
 // If a node is running a version smaller than this, it should exit/stop
-func getMinimumVersion(vmRunner vm.EVMRunner) (*params.VersionInfo, error) {
-	version := [3]*big.Int{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
-	err := getMinimumClientVersionMethod.Query(vmRunner, &version)
+func GetMinimumVersion(vmRunner vm.EVMRunner) (*params.VersionInfo, error) {
+	major, minor, patch, err := blockchainParametersContract.GetMinimumClientVersion(vmRunner)
 	if err != nil {
 		return nil, err
 	}
 	return &params.VersionInfo{
-		Major: version[0].Uint64(),
-		Minor: version[1].Uint64(),
-		Patch: version[2].Uint64(),
+		Major: major.Uint64(),
+		Minor: minor.Uint64(),
+		Patch: patch.Uint64(),
 	}, nil
 }
 
@@ -66,8 +56,7 @@ func GetIntrinsicGasForAlternativeFeeCurrencyOrDefault(vmRunner vm.EVMRunner) ui
 // getIntrinsicGasForAlternativeFeeCurrency retrieves the intrisic gas for transactions that pay gas in
 // with an alternative currency (not CELO)
 func getIntrinsicGasForAlternativeFeeCurrency(vmRunner vm.EVMRunner) (uint64, error) {
-	var gas *big.Int
-	err := intrinsicGasForAlternativeFeeCurrencyMethod.Query(vmRunner, &gas)
+	gas, err := blockchainParametersContract.IntrinsicGasForAlternativeFeeCurrency(vmRunner)
 
 	if err != nil {
 		return 0, err
@@ -89,8 +78,7 @@ func GetBlockGasLimitOrDefault(vmRunner vm.EVMRunner) uint64 {
 
 // getBlockGasLimit retrieves the block max gas limit
 func getBlockGasLimit(vmRunner vm.EVMRunner) (uint64, error) {
-	var gasLimit *big.Int
-	err := blockGasLimitMethod.Query(vmRunner, &gasLimit)
+	gasLimit, err := blockchainParametersContract.BlockGasLimit(vmRunner)
 	if err != nil {
 		return 0, err
 	}
@@ -100,8 +88,7 @@ func getBlockGasLimit(vmRunner vm.EVMRunner) (uint64, error) {
 // GetLookbackWindow retrieves the lookback window parameter to be used
 // for uptime score computations
 func GetLookbackWindow(vmRunner vm.EVMRunner) (uint64, error) {
-	var lookbackWindow *big.Int
-	err := getUptimeLookbackWindowMethod.Query(vmRunner, &lookbackWindow)
+	lookbackWindow, err := blockchainParametersContract.GetUptimeLookbackWindow(vmRunner)
 
 	if err != nil {
 		logError("getUptimeLookbackWindow", err)
@@ -110,24 +97,6 @@ func GetLookbackWindow(vmRunner vm.EVMRunner) (uint64, error) {
 	return lookbackWindow.Uint64(), nil
 }
 
-// checkMinimumVersion performs a check on the client's minimum version
-// In case of not passing hte check it will exit the node
-func checkMinimumVersion(vmRunner vm.EVMRunner) {
-	version, err := getMinimumVersion(vmRunner)
-
-	if err != nil {
-		logError("getMinimumClientVersion", err)
-		return
-	}
-
-	if params.CurrentVersionInfo.Cmp(version) == -1 {
-		time.Sleep(10 * time.Second)
-		// TODO this should exist gracefully, not like this
-		log.Crit("Client version older than required", "current", params.Version, "required", version)
-	}
-
-}
-
 func logError(method string, err error) {
 	if err == contracts.ErrRegistryContractNotDeployed {
 		log.Debug("Error calling "+method, "err", err, "contract", hexutil.Encode(params.BlockchainParametersRegistryId[:]))
@@ -135,19 +104,3 @@ func logError(method string, err error) {
 		log.Warn("Error calling "+method, "err", err, "contract", hexutil.Encode(params.BlockchainParametersRegistryId[:]))
 	}
 }
-
-// SpawnCheck starts a goroutine that will periodically check the client's minimun version
-// In case of not passing hte check it will exit the node
-func SpawnCheck(runnerFactory func() (vm.EVMRunner, error)) {
-	go func() {
-		for {
-			time.Sleep(60 * time.Second)
-
-			vmRunner, err := runnerFactory()
-			if err != nil {
-				continue
-			}
-			checkMinimumVersion(vmRunner)
-		}
-	}()
-}
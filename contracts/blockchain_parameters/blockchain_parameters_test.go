@@ -11,8 +11,8 @@ import (
 )
 
 func TestGetMinimumVersion(t *testing.T) {
-	testutil.TestFailOnFailingRunner(t, getMinimumVersion)
-	testutil.TestFailsWhenContractNotDeployed(t, contracts.ErrSmartContractNotDeployed, getMinimumVersion)
+	testutil.TestFailOnFailingRunner(t, GetMinimumVersion)
+	testutil.TestFailsWhenContractNotDeployed(t, contracts.ErrSmartContractNotDeployed, GetMinimumVersion)
 
 	t.Run("should return minimum version", func(t *testing.T) {
 		g := NewGomegaWithT(t)
@@ -25,7 +25,7 @@ func TestGetMinimumVersion(t *testing.T) {
 			},
 		)
 
-		version, err := getMinimumVersion(runner)
+		version, err := GetMinimumVersion(runner)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(version).To(Equal(&params.VersionInfo{Major: 5, Minor: 4, Patch: 3}))
 	})
@@ -10,6 +10,8 @@ import (
 	"github.com/celo-org/celo-blockchain/log"
 )
 
+//go:generate go run ./internal/gen
+
 // Method represents a contract's method
 type Method struct {
 	abi    *abi.ABI
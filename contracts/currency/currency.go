@@ -21,16 +21,13 @@ import (
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/contracts"
-	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/core/vm"
 	"github.com/celo-org/celo-blockchain/log"
-	"github.com/celo-org/celo-blockchain/params"
 )
 
 var (
-	medianRateMethod   = contracts.NewRegisteredContractMethod(params.SortedOraclesRegistryId, abis.SortedOracles, "medianRate", params.MaxGasForMedianRate)
-	getWhitelistMethod = contracts.NewRegisteredContractMethod(params.FeeCurrencyWhitelistRegistryId, abis.FeeCurrency, "getWhitelist", params.MaxGasForGetWhiteList)
-	getBalanceMethod   = contracts.NewMethod(abis.ERC20, "balanceOf", params.MaxGasToReadErc20Balance)
+	sortedOraclesContract        = NewSortedOracles()
+	feeCurrencyWhitelistContract = NewFeeCurrencyWhitelist()
 )
 
 // NoopExchangeRate represents an exchange rate of 1 to 1
@@ -208,9 +205,7 @@ func GetExchangeRate(vmRunner vm.EVMRunner, currencyAddress *common.Address) (*E
 		return &NoopExchangeRate, nil
 	}
 
-	var returnArray [2]*big.Int
-
-	err := medianRateMethod.Query(vmRunner, &returnArray, currencyAddress)
+	numerator, denominator, err := sortedOraclesContract.MedianRate(vmRunner, *currencyAddress)
 
 	if err == contracts.ErrSmartContractNotDeployed {
 		log.Warn("Registry address lookup failed", "err", err)
@@ -220,15 +215,15 @@ func GetExchangeRate(vmRunner vm.EVMRunner, currencyAddress *common.Address) (*E
 		return &NoopExchangeRate, nil
 	}
 
-	log.Trace("medianRate invocation success", "feeCurrencyAddress", currencyAddress, "returnArray", returnArray)
-	return NewExchangeRate(returnArray[0], returnArray[1])
+	log.Trace("medianRate invocation success", "feeCurrencyAddress", currencyAddress, "numerator", numerator, "denominator", denominator)
+	return NewExchangeRate(numerator, denominator)
 }
 
 // GetBalanceOf returns an account's balance on a given ERC20 currency
 func GetBalanceOf(vmRunner vm.EVMRunner, accountOwner common.Address, contractAddress common.Address) (result *big.Int, err error) {
 	log.Trace("GetBalanceOf() Called", "accountOwner", accountOwner.Hex(), "contractAddress", contractAddress)
 
-	err = getBalanceMethod.Bind(contractAddress).Query(vmRunner, &result, accountOwner)
+	result, err = NewERC20(contractAddress).BalanceOf(vmRunner, accountOwner)
 
 	if err != nil {
 		log.Error("GetBalanceOf evm invocation error", "err", err)
@@ -241,9 +236,7 @@ func GetBalanceOf(vmRunner vm.EVMRunner, accountOwner common.Address, contractAd
 
 // CurrencyWhitelist retrieves the list of currencies that can be used to pay transaction fees
 func CurrencyWhitelist(vmRunner vm.EVMRunner) ([]common.Address, error) {
-	returnList := []common.Address{}
-
-	err := getWhitelistMethod.Query(vmRunner, &returnList)
+	returnList, err := feeCurrencyWhitelistContract.GetWhitelist(vmRunner)
 
 	if err == contracts.ErrSmartContractNotDeployed {
 		log.Warn("Registry address lookup failed", "err", err)
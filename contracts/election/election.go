@@ -20,26 +20,15 @@ import (
 	"sort"
 
 	"github.com/celo-org/celo-blockchain/common"
-	"github.com/celo-org/celo-blockchain/contracts"
-	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/core/vm"
 	"github.com/celo-org/celo-blockchain/log"
-	"github.com/celo-org/celo-blockchain/params"
 )
 
-var (
-	electValidatorSignersMethod                   = contracts.NewRegisteredContractMethod(params.ElectionRegistryId, abis.Elections, "electValidatorSigners", params.MaxGasForElectValidators)
-	getElectableValidatorsMethod                  = contracts.NewRegisteredContractMethod(params.ElectionRegistryId, abis.Elections, "getElectableValidators", params.MaxGasForGetElectableValidators)
-	electNValidatorSignersMethod                  = contracts.NewRegisteredContractMethod(params.ElectionRegistryId, abis.Elections, "electNValidatorSigners", params.MaxGasForElectNValidatorSigners)
-	getTotalVotesForEligibleValidatorGroupsMethod = contracts.NewRegisteredContractMethod(params.ElectionRegistryId, abis.Elections, "getTotalVotesForEligibleValidatorGroups", params.MaxGasForGetEligibleValidatorGroupsVoteTotals)
-	getGroupEpochRewardsMethod                    = contracts.NewRegisteredContractMethod(params.ElectionRegistryId, abis.Elections, "getGroupEpochRewards", params.MaxGasForGetGroupEpochRewards)
-	distributeEpochRewardsMethod                  = contracts.NewRegisteredContractMethod(params.ElectionRegistryId, abis.Elections, "distributeEpochRewards", params.MaxGasForDistributeEpochRewards)
-)
+var electionContract = NewElection()
 
 func GetElectedValidators(vmRunner vm.EVMRunner) ([]common.Address, error) {
 	// Get the new epoch's validator set
-	var newValSet []common.Address
-	err := electValidatorSignersMethod.Query(vmRunner, &newValSet)
+	newValSet, err := electionContract.ElectValidatorSigners(vmRunner)
 
 	if err != nil {
 		return nil, err
@@ -49,16 +38,13 @@ func GetElectedValidators(vmRunner vm.EVMRunner) ([]common.Address, error) {
 
 func ElectNValidatorSigners(vmRunner vm.EVMRunner, additionalAboveMaxElectable int64) ([]common.Address, error) {
 	// Get the electable min and max
-	var minElectableValidators *big.Int
-	var maxElectableValidators *big.Int
-	err := getElectableValidatorsMethod.Query(vmRunner, &[]interface{}{&minElectableValidators, &maxElectableValidators})
+	minElectableValidators, maxElectableValidators, err := electionContract.GetElectableValidators(vmRunner)
 	if err != nil {
 		return nil, err
 	}
 
 	// Run the validator election for up to maxElectable + getTotalVotesForEligibleValidatorGroup
-	var electedValidators []common.Address
-	err = electNValidatorSignersMethod.Query(vmRunner, &electedValidators, minElectableValidators, maxElectableValidators.Add(maxElectableValidators, big.NewInt(additionalAboveMaxElectable)))
+	electedValidators, err := electionContract.ElectNValidatorSigners(vmRunner, minElectableValidators, maxElectableValidators.Add(maxElectableValidators, big.NewInt(additionalAboveMaxElectable)))
 	if err != nil {
 		return nil, err
 	}
@@ -73,9 +59,7 @@ type voteTotal struct {
 }
 
 func getTotalVotesForEligibleValidatorGroups(vmRunner vm.EVMRunner) ([]voteTotal, error) {
-	var groups []common.Address
-	var values []*big.Int
-	err := getTotalVotesForEligibleValidatorGroupsMethod.Query(vmRunner, &[]interface{}{&groups, &values})
+	groups, values, err := electionContract.GetTotalVotesForEligibleValidatorGroups(vmRunner)
 	if err != nil {
 		return nil, err
 	}
@@ -90,8 +74,7 @@ func getTotalVotesForEligibleValidatorGroups(vmRunner vm.EVMRunner) ([]voteTotal
 }
 
 func getGroupEpochRewards(vmRunner vm.EVMRunner, group common.Address, maxRewards *big.Int, uptimes []*big.Int) (*big.Int, error) {
-	var groupEpochRewards *big.Int
-	err := getGroupEpochRewardsMethod.Query(vmRunner, &groupEpochRewards, group, maxRewards, uptimes)
+	groupEpochRewards, err := electionContract.GetGroupEpochRewards(vmRunner, group, maxRewards, uptimes)
 	if err != nil {
 		return nil, err
 	}
@@ -143,7 +126,7 @@ func DistributeEpochRewards(vmRunner vm.EVMRunner, groups []common.Address, maxT
 				break
 			}
 		}
-		err := distributeEpochRewardsMethod.Execute(vmRunner, nil, common.Big0, group, reward, lesser, greater)
+		err := electionContract.DistributeEpochRewards(vmRunner, group, reward, lesser, greater)
 		if err != nil {
 			return totalRewards, err
 		}
@@ -19,14 +19,10 @@ package freezer
 import (
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/contracts"
-	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/core/vm"
-	"github.com/celo-org/celo-blockchain/params"
 )
 
-var (
-	isFrozenMethod = contracts.NewRegisteredContractMethod(params.FreezerRegistryId, abis.Freezer, "isFrozen", params.MaxGasForIsFrozen)
-)
+var freezerContract = NewFreezer()
 
 func IsFrozen(vmRunner vm.EVMRunner, registryId common.Hash) (bool, error) {
 	address, err := contracts.GetRegisteredAddress(vmRunner, registryId)
@@ -34,8 +30,8 @@ func IsFrozen(vmRunner vm.EVMRunner, registryId common.Hash) (bool, error) {
 		return false, err
 	}
 
-	var isFrozen bool
-	if err := isFrozenMethod.Query(vmRunner, &isFrozen, address); err != nil {
+	isFrozen, err := freezerContract.IsFrozen(vmRunner, address)
+	if err != nil {
 		return false, err
 	}
 
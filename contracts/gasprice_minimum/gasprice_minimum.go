@@ -21,7 +21,6 @@ import (
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/contracts"
-	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/contracts/blockchain_parameters"
 	"github.com/celo-org/celo-blockchain/contracts/currency"
 	"github.com/celo-org/celo-blockchain/core/vm"
@@ -35,11 +34,7 @@ var (
 	suggestionMultiplier    *big.Int = big.NewInt(5) // The multiplier that we apply to the minimum when suggesting gas price
 )
 
-var (
-	getGasPriceMinimumMethod      = contracts.NewRegisteredContractMethod(params.GasPriceMinimumRegistryId, abis.GasPriceMinimum, "getGasPriceMinimum", params.MaxGasForGetGasPriceMinimum)
-	getGasPriceMinimumFloorMethod = contracts.NewRegisteredContractMethod(params.GasPriceMinimumRegistryId, abis.GasPriceMinimum, "gasPriceMinimumFloor", params.MaxGasForGetGasPriceMinimum)
-	updateGasPriceMinimumMethod   = contracts.NewRegisteredContractMethod(params.GasPriceMinimumRegistryId, abis.GasPriceMinimum, "updateGasPriceMinimum", params.MaxGasForUpdateGasPriceMinimum)
-)
+var gasPriceMinimumContract = NewGasPriceMinimum()
 
 // GetGasTipCapSuggestion suggests a max tip of 2GWei in the appropriate currency.
 // TODO: Switch to using a caching currency manager under high load.
@@ -76,8 +71,7 @@ func GetGasPriceMinimum(vmRunner vm.EVMRunner, currency *common.Address) (*big.I
 		currencyAddress = *currency
 	}
 
-	var gasPriceMinimum *big.Int
-	err = getGasPriceMinimumMethod.Query(vmRunner, &gasPriceMinimum, currencyAddress)
+	gasPriceMinimum, err := gasPriceMinimumContract.GetGasPriceMinimum(vmRunner, currencyAddress)
 
 	if err == contracts.ErrSmartContractNotDeployed || err == contracts.ErrRegistryContractNotDeployed {
 		return FallbackGasPriceMinimum, nil
@@ -90,10 +84,7 @@ func GetGasPriceMinimum(vmRunner vm.EVMRunner, currency *common.Address) (*big.I
 }
 
 func GetGasPriceMinimumFloor(vmRunner vm.EVMRunner) (*big.Int, error) {
-	var err error
-
-	var gasPriceMinimumFloor *big.Int
-	err = getGasPriceMinimumFloorMethod.Query(vmRunner, &gasPriceMinimumFloor)
+	gasPriceMinimumFloor, err := gasPriceMinimumContract.GasPriceMinimumFloor(vmRunner)
 
 	if err == contracts.ErrSmartContractNotDeployed || err == contracts.ErrRegistryContractNotDeployed {
 		return FallbackGasPriceMinimum, nil
@@ -106,15 +97,35 @@ func GetGasPriceMinimumFloor(vmRunner vm.EVMRunner) (*big.Int, error) {
 }
 
 func UpdateGasPriceMinimum(vmRunner vm.EVMRunner, lastUsedGas uint64) (*big.Int, error) {
-	var updatedGasPriceMinimum *big.Int
-
 	// If an error occurs, the default block gas limit will be returned and a log statement will be produced by GetBlockGasLimitOrDefault
 	gasLimit := blockchain_parameters.GetBlockGasLimitOrDefault(vmRunner)
 
-	err := updateGasPriceMinimumMethod.Execute(vmRunner, &updatedGasPriceMinimum, common.Big0, big.NewInt(int64(lastUsedGas)), big.NewInt(int64(gasLimit)))
+	updatedGasPriceMinimum, err := gasPriceMinimumContract.UpdateGasPriceMinimum(vmRunner, big.NewInt(int64(lastUsedGas)), big.NewInt(int64(gasLimit)))
 
 	if err != nil {
 		return nil, err
 	}
 	return updatedGasPriceMinimum, err
 }
+
+// ProjectGasPriceMinimum simulates the GasPriceMinimum contract's update rule over the next
+// blocksAhead blocks, assuming each of them uses the given fraction of the block gas limit.
+// It returns the gas price minimum in the given currency after each simulated block.
+// The updates are applied to the state behind vmRunner, so it must run on a throwaway copy.
+func ProjectGasPriceMinimum(vmRunner vm.EVMRunner, currency *common.Address, blocksAhead uint64, utilization float64) ([]*big.Int, error) {
+	gasLimit := blockchain_parameters.GetBlockGasLimitOrDefault(vmRunner)
+	gasUsed := uint64(float64(gasLimit) * utilization)
+
+	projection := make([]*big.Int, 0, blocksAhead)
+	for i := uint64(0); i < blocksAhead; i++ {
+		if _, err := UpdateGasPriceMinimum(vmRunner, gasUsed); err != nil {
+			return nil, err
+		}
+		gasPriceMinimum, err := GetGasPriceMinimum(vmRunner, currency)
+		if err != nil {
+			return nil, err
+		}
+		projection = append(projection, gasPriceMinimum)
+	}
+	return projection, nil
+}
@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/contracts/testutil"
 	"github.com/celo-org/celo-blockchain/params"
 )
@@ -181,3 +182,62 @@ func TestUpdateGasPriceMinimum(t *testing.T) {
 		g.Expect(newGpm.Uint64()).To(Equal(uint64(999999)))
 	})
 }
+
+// gasPriceMinimumMock doubles the minimum whenever a block is more than half full
+type gasPriceMinimumMock struct {
+	testutil.ContractMock
+	minimum *big.Int
+}
+
+func (m *gasPriceMinimumMock) GetGasPriceMinimum(currency common.Address) *big.Int {
+	return new(big.Int).Set(m.minimum)
+}
+
+func (m *gasPriceMinimumMock) UpdateGasPriceMinimum(gasUsed *big.Int, gasLimit *big.Int) *big.Int {
+	if new(big.Int).Mul(gasUsed, common.Big2).Cmp(gasLimit) > 0 {
+		m.minimum.Mul(m.minimum, common.Big2)
+	}
+	return new(big.Int).Set(m.minimum)
+}
+
+func TestProjectGasPriceMinimum(t *testing.T) {
+	var (
+		celoAddress = common.HexToAddress("0x076")
+		gpmAddress  = common.HexToAddress("0x090")
+	)
+	newRunner := func() *testutil.MockEVMRunner {
+		runner := testutil.NewMockEVMRunner()
+		registry := testutil.NewRegistryMock()
+		runner.RegisterContract(params.RegistrySmartContractAddress, registry)
+		registry.AddContract(params.GoldTokenRegistryId, celoAddress)
+
+		mock := &gasPriceMinimumMock{minimum: big.NewInt(100)}
+		mock.ContractMock = testutil.NewContractMock(abis.GasPriceMinimum, mock)
+		runner.RegisterContract(gpmAddress, mock)
+		registry.AddContract(params.GasPriceMinimumRegistryId, gpmAddress)
+		return runner
+	}
+
+	t.Run("should compound updates on congested blocks", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		projection, err := ProjectGasPriceMinimum(newRunner(), nil, 3, 0.8)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(projection).To(Equal([]*big.Int{big.NewInt(200), big.NewInt(400), big.NewInt(800)}))
+	})
+
+	t.Run("should keep the minimum on empty blocks", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		projection, err := ProjectGasPriceMinimum(newRunner(), nil, 2, 0)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(projection).To(Equal([]*big.Int{big.NewInt(100), big.NewInt(100)}))
+	})
+
+	t.Run("should fail when vmRunner is failing", func(t *testing.T) {
+		g := NewGomegaWithT(t)
+
+		_, err := ProjectGasPriceMinimum(testutil.FailingVmRunner{}, nil, 2, 0.5)
+		g.Expect(err).To(MatchError(testutil.ErrFailingRunner))
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// gen generates the vm.EVMRunner backed bindings of the core contracts used by
+// the node. It is meant to be invoked through go generate from the contracts
+// directory, adding a method to a contract only requires extending its ABI in
+// contracts/abis and its gas cap below.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi/bind"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+)
+
+// binding is a set of contracts generated into a single file.
+type binding struct {
+	pkg       string
+	out       string
+	contracts []bind.RunnerContract
+}
+
+var bindings = []binding{
+	{
+		pkg: "blockchain_parameters",
+		out: "blockchain_parameters/gen_contracts.go",
+		contracts: []bind.RunnerContract{{
+			Type:       "BlockchainParameters",
+			ABI:        abis.BlockchainParametersStr,
+			ABIVar:     "BlockchainParameters",
+			RegistryId: "BlockchainParametersRegistryId",
+			MaxGas: map[string]string{
+				"getMinimumClientVersion":               "MaxGasForReadBlockchainParameter",
+				"intrinsicGasForAlternativeFeeCurrency": "MaxGasForReadBlockchainParameter",
+				"blockGasLimit":                         "MaxGasForReadBlockchainParameter",
+				"getUptimeLookbackWindow":               "MaxGasForReadBlockchainParameter",
+			},
+		}},
+	},
+	{
+		pkg: "currency",
+		out: "currency/gen_contracts.go",
+		contracts: []bind.RunnerContract{{
+			Type:       "SortedOracles",
+			ABI:        abis.SortedOraclesStr,
+			ABIVar:     "SortedOracles",
+			RegistryId: "SortedOraclesRegistryId",
+			MaxGas: map[string]string{
+				"medianRate": "MaxGasForMedianRate",
+			},
+		}, {
+			Type:       "FeeCurrencyWhitelist",
+			ABI:        abis.FeeCurrencyStr,
+			ABIVar:     "FeeCurrency",
+			RegistryId: "FeeCurrencyWhitelistRegistryId",
+			MaxGas: map[string]string{
+				"getWhitelist": "MaxGasForGetWhiteList",
+			},
+		}, {
+			Type:   "ERC20",
+			ABI:    abis.ERC20Str,
+			ABIVar: "ERC20",
+			MaxGas: map[string]string{
+				"balanceOf": "MaxGasToReadErc20Balance",
+			},
+		}},
+	},
+	{
+		pkg: "election",
+		out: "election/gen_contracts.go",
+		contracts: []bind.RunnerContract{{
+			Type:       "Election",
+			ABI:        abis.ElectionsStr,
+			ABIVar:     "Elections",
+			RegistryId: "ElectionRegistryId",
+			MaxGas: map[string]string{
+				"electValidatorSigners":                   "MaxGasForElectValidators",
+				"getElectableValidators":                  "MaxGasForGetElectableValidators",
+				"electNValidatorSigners":                  "MaxGasForElectNValidatorSigners",
+				"getTotalVotesForEligibleValidatorGroups": "MaxGasForGetEligibleValidatorGroupsVoteTotals",
+				"getGroupEpochRewards":                    "MaxGasForGetGroupEpochRewards",
+				"distributeEpochRewards":                  "MaxGasForDistributeEpochRewards",
+			},
+		}},
+	},
+	{
+		pkg: "freezer",
+		out: "freezer/gen_contracts.go",
+		contracts: []bind.RunnerContract{{
+			Type:       "Freezer",
+			ABI:        abis.FreezerStr,
+			ABIVar:     "Freezer",
+			RegistryId: "FreezerRegistryId",
+			MaxGas: map[string]string{
+				"isFrozen": "MaxGasForIsFrozen",
+			},
+		}},
+	},
+	{
+		pkg: "gasprice_minimum",
+		out: "gasprice_minimum/gen_contracts.go",
+		contracts: []bind.RunnerContract{{
+			Type:       "GasPriceMinimum",
+			ABI:        abis.GasPriceMinimumStr,
+			ABIVar:     "GasPriceMinimum",
+			RegistryId: "GasPriceMinimumRegistryId",
+			MaxGas: map[string]string{
+				"getGasPriceMinimum":    "MaxGasForGetGasPriceMinimum",
+				"gasPriceMinimumFloor":  "MaxGasForGetGasPriceMinimum",
+				"updateGasPriceMinimum": "MaxGasForUpdateGasPriceMinimum",
+			},
+		}},
+	},
+	{
+		pkg: "random",
+		out: "random/gen_contracts.go",
+		contracts: []bind.RunnerContract{{
+			// Avoids clashing with random.Random
+			Type:       "RandomContract",
+			ABI:        abis.RandomStr,
+			ABIVar:     "Random",
+			RegistryId: "RandomRegistryId",
+			MaxGas: map[string]string{
+				"revealAndCommit":    "MaxGasForRevealAndCommit",
+				"commitments":        "MaxGasForCommitments",
+				"computeCommitment":  "MaxGasForComputeCommitment",
+				"random":             "MaxGasForBlockRandomness",
+				"getBlockRandomness": "MaxGasForBlockRandomness",
+			},
+		}},
+	},
+}
+
+// generate renders every binding, keyed by its output path relative to the
+// contracts directory.
+func generate() (map[string]string, error) {
+	files := make(map[string]string)
+	for _, b := range bindings {
+		code, err := bind.BindEVMRunner(b.contracts, b.pkg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", b.out, err)
+		}
+		files[b.out] = code
+	}
+	return files, nil
+}
+
+func main() {
+	dir := flag.String("dir", ".", "Path to the contracts directory")
+	flag.Parse()
+
+	files, err := generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for out, code := range files {
+		if err := ioutil.WriteFile(filepath.Join(*dir, out), []byte(code), 0644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
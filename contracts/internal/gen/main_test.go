@@ -0,0 +1,40 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// Tests that the committed bindings match the ABIs they were generated from.
+func TestBindingsUpToDate(t *testing.T) {
+	files, err := generate()
+	if err != nil {
+		t.Fatalf("failed to generate bindings: %v", err)
+	}
+	for out, code := range files {
+		have, err := ioutil.ReadFile(filepath.Join("..", "..", out))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", out, err)
+		}
+		if string(have) != code {
+			t.Errorf("%s is out of date, run go generate in the contracts directory", out)
+		}
+	}
+}
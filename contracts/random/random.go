@@ -6,19 +6,12 @@ import (
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/common/hexutil"
 	"github.com/celo-org/celo-blockchain/contracts"
-	"github.com/celo-org/celo-blockchain/contracts/abis"
 	"github.com/celo-org/celo-blockchain/core/vm"
 	"github.com/celo-org/celo-blockchain/log"
 	"github.com/celo-org/celo-blockchain/params"
 )
 
-var (
-	revealAndCommitMethod    = contracts.NewRegisteredContractMethod(params.RandomRegistryId, abis.Random, "revealAndCommit", params.MaxGasForRevealAndCommit)
-	commitmentsMethod        = contracts.NewRegisteredContractMethod(params.RandomRegistryId, abis.Random, "commitments", params.MaxGasForCommitments)
-	computeCommitmentMethod  = contracts.NewRegisteredContractMethod(params.RandomRegistryId, abis.Random, "computeCommitment", params.MaxGasForComputeCommitment)
-	randomMethod             = contracts.NewRegisteredContractMethod(params.RandomRegistryId, abis.Random, "random", params.MaxGasForBlockRandomness)
-	getBlockRandomnessMethod = contracts.NewRegisteredContractMethod(params.RandomRegistryId, abis.Random, "getBlockRandomness", params.MaxGasForBlockRandomness)
-)
+var randomContract = NewRandomContract()
 
 func IsRunning(vmRunner vm.EVMRunner) bool {
 	randomAddress, err := contracts.GetRegisteredAddress(vmRunner, params.RandomRegistryId)
@@ -34,8 +27,7 @@ func IsRunning(vmRunner vm.EVMRunner) bool {
 
 // GetLastCommitment returns up the last commitment in the smart contract
 func GetLastCommitment(vmRunner vm.EVMRunner, validator common.Address) (common.Hash, error) {
-	lastCommitment := common.Hash{}
-	err := commitmentsMethod.Query(vmRunner, &lastCommitment, validator)
+	lastCommitment, err := randomContract.Commitments(vmRunner, validator)
 	if err != nil {
 		log.Error("Failed to get last commitment", "err", err)
 		return lastCommitment, err
@@ -50,9 +42,8 @@ func GetLastCommitment(vmRunner vm.EVMRunner, validator common.Address) (common.
 
 // ComputeCommitment calulcates the commitment for a given randomness.
 func ComputeCommitment(vmRunner vm.EVMRunner, randomness common.Hash) (common.Hash, error) {
-	commitment := common.Hash{}
 	// TODO(asa): Make an issue to not have to do this via StaticCall
-	err := computeCommitmentMethod.Query(vmRunner, &commitment, randomness)
+	commitment, err := randomContract.ComputeCommitment(vmRunner, randomness)
 	if err != nil {
 		log.Error("Failed to call computeCommitment()", "err", err)
 		return common.Hash{}, err
@@ -67,20 +58,18 @@ func ComputeCommitment(vmRunner vm.EVMRunner, randomness common.Hash) (common.Ha
 func RevealAndCommit(vmRunner vm.EVMRunner, randomness, newCommitment common.Hash, proposer common.Address) error {
 
 	log.Trace("Revealing and committing randomness", "randomness", randomness.Hex(), "commitment", newCommitment.Hex())
-	err := revealAndCommitMethod.Execute(vmRunner, nil, common.Big0, randomness, newCommitment, proposer)
+	err := randomContract.RevealAndCommit(vmRunner, randomness, newCommitment, proposer)
 
 	return err
 }
 
 // Random performs an internal call to the EVM to retrieve the current randomness from the official Random contract.
 func Random(vmRunner vm.EVMRunner) (common.Hash, error) {
-	randomness := common.Hash{}
-	err := randomMethod.Query(vmRunner, &randomness)
+	randomness, err := randomContract.Random(vmRunner)
 	return randomness, err
 }
 
 func BlockRandomness(vmRunner vm.EVMRunner, blockNumber uint64) (common.Hash, error) {
-	randomness := common.Hash{}
-	err := getBlockRandomnessMethod.Query(vmRunner, &randomness, big.NewInt(int64(blockNumber)))
+	randomness, err := randomContract.GetBlockRandomness(vmRunner, big.NewInt(int64(blockNumber)))
 	return randomness, err
 }
@@ -695,6 +695,18 @@ func (bc *BlockChain) CurrentBlock() *types.Block {
 	return bc.currentBlock.Load().(*types.Block)
 }
 
+// WithChainLock runs fn while holding the chain mutex, so that no blocks are
+// imported, no state is flushed to disk and the chain is not rewound until fn
+// returns.
+func (bc *BlockChain) WithChainLock(fn func() error) error {
+	if !bc.chainmu.TryLock() {
+		return errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	return fn()
+}
+
 // Snapshots returns the blockchain snapshot tree.
 func (bc *BlockChain) Snapshots() *snapshot.Tree {
 	return bc.snaps
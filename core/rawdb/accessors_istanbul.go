@@ -0,0 +1,123 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/uptime"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/rlp"
+)
+
+// IstanbulSnapshotInfo is the summary of an istanbul validator snapshot stored
+// in the database.
+type IstanbulSnapshotInfo struct {
+	Hash       common.Hash        // Hash of the block the snapshot was created at
+	Number     uint64             // Number of the block the snapshot was created at
+	Epoch      uint64             // Epoch size of the snapshot
+	Validators int                // Number of validators in the snapshot
+	Size       common.StorageSize // Size of the database entry
+}
+
+// UptimeInfo is the summary of the accumulated uptime of an epoch stored in the
+// database.
+type UptimeInfo struct {
+	Epoch       uint64             // Epoch the uptime was accumulated for
+	LatestBlock uint64             // Latest block accumulated into the uptime
+	Validators  int                // Number of validators tracked
+	Size        common.StorageSize // Size of the database entry
+}
+
+// ReadIstanbulSnapshotJSON retrieves the json encoded istanbul snapshot of the
+// given block.
+func ReadIstanbulSnapshotJSON(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(istanbulSnapshotKey(hash))
+	return data
+}
+
+// DeleteIstanbulSnapshot removes the istanbul snapshot of the given block.
+func DeleteIstanbulSnapshot(db ethdb.KeyValueWriter, hash common.Hash) {
+	if err := db.Delete(istanbulSnapshotKey(hash)); err != nil {
+		log.Crit("Failed to delete istanbul snapshot", "err", err)
+	}
+}
+
+// ReadIstanbulSnapshotInfos iterates the istanbul snapshots of the database and
+// returns their summaries, sorted by block number. Entries that fail to decode
+// are reported with the hash of their key only.
+func ReadIstanbulSnapshotInfos(db ethdb.Iteratee) []IstanbulSnapshotInfo {
+	it := db.NewIterator(IstanbulSnapshotPrefix, nil)
+	defer it.Release()
+
+	var infos []IstanbulSnapshotInfo
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(IstanbulSnapshotPrefix)+common.HashLength {
+			continue
+		}
+		info := IstanbulSnapshotInfo{
+			Hash: common.BytesToHash(key[len(IstanbulSnapshotPrefix):]),
+			Size: common.StorageSize(len(key) + len(it.Value())),
+		}
+		var snap struct {
+			Epoch      uint64            `json:"epoch"`
+			Number     uint64            `json:"number"`
+			Validators []json.RawMessage `json:"validators"`
+		}
+		if err := json.Unmarshal(it.Value(), &snap); err != nil {
+			log.Warn("Invalid istanbul snapshot JSON", "hash", info.Hash, "err", err)
+		} else {
+			info.Epoch, info.Number, info.Validators = snap.Epoch, snap.Number, len(snap.Validators)
+		}
+		infos = append(infos, info)
+	}
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].Number < infos[j].Number })
+	return infos
+}
+
+// ReadUptimeInfos iterates the accumulated uptimes of the database and returns
+// their summaries, sorted by epoch.
+func ReadUptimeInfos(db ethdb.Iteratee) []UptimeInfo {
+	it := db.NewIterator(uptimePrefix, nil)
+	defer it.Release()
+
+	var infos []UptimeInfo
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(uptimePrefix)+8 {
+			continue
+		}
+		info := UptimeInfo{
+			Epoch: binary.BigEndian.Uint64(key[len(uptimePrefix):]),
+			Size:  common.StorageSize(len(key) + len(it.Value())),
+		}
+		var entry uptime.Uptime
+		if err := rlp.Decode(bytes.NewReader(it.Value()), &entry); err != nil {
+			log.Warn("Invalid uptime RLP", "epoch", info.Epoch, "err", err)
+		} else {
+			info.LatestBlock, info.Validators = entry.LatestBlock, len(entry.Entries)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/uptime"
+)
+
+// Tests the listing and removal of the istanbul snapshots.
+func TestIstanbulSnapshotInfos(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	for _, number := range []uint64{200, 100} {
+		hash := common.BytesToHash([]byte{byte(number)})
+		blob := fmt.Sprintf(`{"epoch":100,"number":%d,"hash":"%s","validators":[{},{},{}]}`, number, hash.Hex())
+		if err := db.Put(istanbulSnapshotKey(hash), []byte(blob)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Entries of other types sharing the prefix must be skipped
+	db.Put(append(IstanbulSnapshotPrefix, 0x01), []byte{0x01})
+
+	infos := ReadIstanbulSnapshotInfos(db)
+	if len(infos) != 2 {
+		t.Fatalf("snapshot count mismatch: have %d, want 2", len(infos))
+	}
+	if infos[0].Number != 100 || infos[1].Number != 200 {
+		t.Errorf("snapshots not sorted: have %d, %d", infos[0].Number, infos[1].Number)
+	}
+	if infos[0].Epoch != 100 || infos[0].Validators != 3 || infos[0].Size == 0 {
+		t.Errorf("snapshot summary mismatch: have %+v", infos[0])
+	}
+	DeleteIstanbulSnapshot(db, infos[0].Hash)
+	if blob := ReadIstanbulSnapshotJSON(db, infos[0].Hash); blob != nil {
+		t.Fatalf("deleted snapshot returned: %s", blob)
+	}
+	if infos := ReadIstanbulSnapshotInfos(db); len(infos) != 1 || infos[0].Number != 200 {
+		t.Errorf("remaining snapshots mismatch: have %+v", infos)
+	}
+}
+
+// Tests the listing of the accumulated uptimes.
+func TestUptimeInfos(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	for _, epoch := range []uint64{3, 1, 256} {
+		WriteAccumulatedEpochUptime(db, epoch, &uptime.Uptime{
+			LatestBlock: epoch * 10,
+			Entries:     []uptime.UptimeEntry{{UpBlocks: 1, LastSignedBlock: epoch * 10}, {}},
+		})
+	}
+	infos := ReadUptimeInfos(db)
+	if len(infos) != 3 {
+		t.Fatalf("uptime count mismatch: have %d, want 3", len(infos))
+	}
+	for i, epoch := range []uint64{1, 3, 256} {
+		if infos[i].Epoch != epoch || infos[i].LatestBlock != epoch*10 || infos[i].Validators != 2 {
+			t.Errorf("uptime %d mismatch: have %+v", i, infos[i])
+		}
+	}
+}
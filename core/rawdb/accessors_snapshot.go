@@ -196,6 +196,29 @@ func DeleteSnapshotRecoveryNumber(db ethdb.KeyValueWriter) {
 	}
 }
 
+// ReadOnlinePruningProgress retrieves the serialized progress of an interrupted
+// online state pruning run.
+func ReadOnlinePruningProgress(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(onlinePruningKey)
+	return data
+}
+
+// WriteOnlinePruningProgress stores the serialized progress of a running online
+// state pruning run.
+func WriteOnlinePruningProgress(db ethdb.KeyValueWriter, progress []byte) {
+	if err := db.Put(onlinePruningKey, progress); err != nil {
+		log.Crit("Failed to store online pruning progress", "err", err)
+	}
+}
+
+// DeleteOnlinePruningProgress deletes the progress marker of a finished online
+// state pruning run.
+func DeleteOnlinePruningProgress(db ethdb.KeyValueWriter) {
+	if err := db.Delete(onlinePruningKey); err != nil {
+		log.Crit("Failed to remove online pruning progress", "err", err)
+	}
+}
+
 // ReadSnapshotSyncStatus retrieves the serialized sync status saved at shutdown.
 func ReadSnapshotSyncStatus(db ethdb.KeyValueReader) []byte {
 	data, _ := db.Get(snapshotSyncStatusKey)
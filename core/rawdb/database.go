@@ -314,6 +314,11 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		chtTrieNodes   stat
 		bloomTrieNodes stat
 
+		// Istanbul statistics
+		istanbulSnaps stat
+		uptimes       stat
+		randomness    stat
+
 		// Meta- and unaccounted data
 		metadata    stat
 		unaccounted stat
@@ -367,6 +372,12 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			bytes.HasPrefix(key, []byte("bltIndex-")) ||
 			bytes.HasPrefix(key, []byte("bltRoot-")): // Bloomtrie sub
 			bloomTrieNodes.Add(size)
+		case bytes.HasPrefix(key, IstanbulSnapshotPrefix) && len(key) == (len(IstanbulSnapshotPrefix)+common.HashLength):
+			istanbulSnaps.Add(size)
+		case bytes.HasPrefix(key, uptimePrefix) && len(key) == (len(uptimePrefix)+8):
+			uptimes.Add(size)
+		case bytes.HasPrefix(key, randomnessCommitmentPrefix) && len(key) == (len(randomnessCommitmentPrefix)+common.HashLength):
+			randomness.Add(size)
 		default:
 			var accounted bool
 			for _, meta := range [][]byte{
@@ -427,6 +438,9 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Ancient store", "Block number->hash", ancientHashesSize.String(), ancients.String()},
 		{"Light client", "CHT trie nodes", chtTrieNodes.Size(), chtTrieNodes.Count()},
 		{"Light client", "Bloom trie nodes", bloomTrieNodes.Size(), bloomTrieNodes.Count()},
+		{"Istanbul", "Validator snapshots", istanbulSnaps.Size(), istanbulSnaps.Count()},
+		{"Istanbul", "Validator uptime", uptimes.Size(), uptimes.Count()},
+		{"Istanbul", "Randomness commitments", randomness.Size(), randomness.Count()},
 	}
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"Database", "Category", "Size", "Items"})
@@ -63,6 +63,9 @@ var (
 	// snapshotSyncStatusKey tracks the snapshot sync status across restarts.
 	snapshotSyncStatusKey = []byte("SnapshotSyncStatus")
 
+	// onlinePruningKey tracks the progress of an online state pruning run across restarts.
+	onlinePruningKey = []byte("OnlinePruning")
+
 	// txIndexTailKey tracks the oldest block whose transactions have been indexed.
 	txIndexTailKey = []byte("TransactionIndexTail")
 
@@ -96,6 +99,11 @@ var (
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
 	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 
+	// Istanbul prefixes
+	IstanbulSnapshotPrefix     = []byte("istanbul-snapshot")    // IstanbulSnapshotPrefix + hash -> istanbul validator snapshot (json)
+	uptimePrefix               = []byte("uptime")               // uptimePrefix + epoch (uint64 big endian) -> accumulated uptime
+	randomnessCommitmentPrefix = []byte("db-randomness-prefix") // randomnessCommitmentPrefix + commitment -> parent hash
+
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
 )
@@ -160,7 +168,12 @@ func headerTDKey(number uint64, hash common.Hash) []byte {
 // uptimeKey = uptimePrefix + epoch number
 func uptimeKey(epoch uint64) []byte {
 	// abuse encodeBlockNumber for epochs
-	return append([]byte("uptime"), encodeBlockNumber(epoch)...)
+	return append(uptimePrefix, encodeBlockNumber(epoch)...)
+}
+
+// istanbulSnapshotKey = IstanbulSnapshotPrefix + hash
+func istanbulSnapshotKey(hash common.Hash) []byte {
+	return append(IstanbulSnapshotPrefix, hash.Bytes()...)
 }
 
 // headerHashKey = headerPrefix + num (uint64 big endian) + headerHashSuffix
@@ -0,0 +1,598 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/state/snapshot"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"github.com/celo-org/celo-blockchain/trie"
+)
+
+const (
+	// onlineCatchUpInterval is the maximum time spent marking the target state
+	// before the blocks imported in the meantime are marked too. It has to stay
+	// well below the time needed to import 128 blocks, otherwise their states
+	// are garbage collected from memory before they could be marked.
+	onlineCatchUpInterval = 3 * time.Second
+
+	// onlineSnapshotLayers is the number of snapshot layers inspected when
+	// looking for freshly imported states.
+	onlineSnapshotLayers = 128
+)
+
+// Phases of an online pruning run as reported by OnlineProgress.
+const (
+	PhaseIdle       = "idle"
+	PhaseMarking    = "marking"
+	PhaseSweeping   = "sweeping"
+	PhaseCompacting = "compacting"
+)
+
+var (
+	// errPruningRunning is returned if a pruning is requested while another
+	// one is still in progress.
+	errPruningRunning = errors.New("state pruning already running")
+
+	// errPruningStopped is returned by an interrupted pruning run.
+	errPruningStopped = errors.New("state pruning stopped")
+
+	// errSnapshotDisabled is returned if online pruning is requested on a
+	// chain without a snapshot tree to track the live states with.
+	errSnapshotDisabled = errors.New("online state pruning requires the state snapshot")
+
+	// errMarkerBehind is returned if more blocks were imported between two
+	// marking rounds than the chain keeps in memory, so that some of the
+	// intermediate states could not be marked.
+	errMarkerBehind = errors.New("block import outpaced the state marker")
+)
+
+// OnlineConfig contains the throttling parameters of the online pruner.
+type OnlineConfig struct {
+	BloomSize  uint64        // Megabytes of memory allocated to the state bloom filter
+	BatchSize  int           // Maximum number of state entries deleted per batch
+	BatchDelay time.Duration // Pause between two deletion batches to spare the disk
+}
+
+// DefaultOnlineConfig contains the default settings of the online pruner.
+var DefaultOnlineConfig = OnlineConfig{
+	BloomSize:  2048,
+	BatchSize:  10000,
+	BatchDelay: 100 * time.Millisecond,
+}
+
+// Chain defines the subset of the blockchain the online pruner operates on.
+type Chain interface {
+	// CurrentBlock retrieves the current head block of the canonical chain.
+	CurrentBlock() *types.Block
+
+	// GetHeaderByNumber retrieves a canonical block header by number.
+	GetHeaderByNumber(number uint64) *types.Header
+
+	// Snapshots returns the snapshot tree tracking the recent states.
+	Snapshots() *snapshot.Tree
+
+	// StateCache returns the database holding the in-memory states.
+	StateCache() state.Database
+
+	// WithChainLock runs fn while no blocks are imported and no state is
+	// flushed to disk.
+	WithChainLock(fn func() error) error
+}
+
+// OnlineProgress reports the status of the online pruner.
+type OnlineProgress struct {
+	Running bool          `json:"running"`         // Whether a pruning run is in progress
+	Phase   string        `json:"phase"`           // Current phase of the run
+	Root    common.Hash   `json:"root"`            // Persisted state root the live state is marked from
+	Marked  uint64        `json:"markedNodes"`     // Number of trie nodes marked as live
+	Cursor  hexutil.Bytes `json:"cursor"`          // Last database key swept
+	Pruned  uint64        `json:"prunedNodes"`     // Number of state entries deleted
+	Size    uint64        `json:"prunedBytes"`     // Size of the deleted state entries
+	Started time.Time     `json:"started"`         // Time the current run was started or resumed
+	Error   string        `json:"error,omitempty"` // Failure of the last run, if any
+}
+
+// onlineJournal is the progress of an online pruning run persisted after every
+// deletion batch, so that an interrupted run can be resumed.
+type onlineJournal struct {
+	Cursor []byte // Last database key swept
+	Pruned uint64 // Number of state entries deleted so far
+	Size   uint64 // Size of the state entries deleted so far
+}
+
+// OnlinePruner prunes the stale state of a running chain. Contrary to Pruner it
+// doesn't need the node to be stopped:
+//
+//   - the newest state persisted to disk is marked in a state bloom, together with
+//     every state the chain imports while the pruning runs
+//   - the database is swept in throttled batches, deleting the trie nodes and
+//     codes not marked as live
+//
+// Every deletion batch is written with the chain lock held, right after the
+// states imported since the previous batch got marked, so no entry flushed to
+// disk by the chain can be removed. The sweep position is persisted alongside
+// the deletions and an interrupted run resumes from there on the next start.
+// As the live state moves on in the meantime, resuming re-marks it from scratch.
+type OnlinePruner struct {
+	db     ethdb.Database
+	chain  Chain
+	config OnlineConfig
+
+	progress OnlineProgress
+	quit     chan struct{} // Closed to interrupt the running pruning
+	lock     sync.Mutex    // Protects the progress and the quit channel
+	wg       sync.WaitGroup
+}
+
+// NewOnlinePruner creates an online pruner operating on the given chain.
+func NewOnlinePruner(db ethdb.Database, chain Chain, config OnlineConfig) *OnlinePruner {
+	if config.BloomSize == 0 {
+		config.BloomSize = DefaultOnlineConfig.BloomSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultOnlineConfig.BatchSize
+	}
+	return &OnlinePruner{
+		db:       db,
+		chain:    chain,
+		config:   config,
+		progress: OnlineProgress{Phase: PhaseIdle},
+	}
+}
+
+// Start resumes the pruning run interrupted by the last shutdown, if any.
+func (p *OnlinePruner) Start() error {
+	journal, err := p.loadJournal()
+	if err != nil {
+		log.Warn("Discarding corrupted online pruning progress", "err", err)
+		rawdb.DeleteOnlinePruningProgress(p.db)
+		return nil
+	}
+	if journal == nil {
+		return nil
+	}
+	log.Info("Resuming online state pruning", "cursor", hexutil.Bytes(journal.Cursor), "pruned", journal.Pruned)
+	return p.start(journal)
+}
+
+// Prune starts pruning the stale state in the background, or resumes the run
+// interrupted earlier.
+func (p *OnlinePruner) Prune() error {
+	journal, err := p.loadJournal()
+	if err != nil || journal == nil {
+		journal = new(onlineJournal)
+	}
+	return p.start(journal)
+}
+
+// Stop interrupts the running pruning and waits for it to terminate. The
+// progress made so far is kept and the run is resumed on the next start.
+func (p *OnlinePruner) Stop() {
+	p.lock.Lock()
+	if p.quit != nil {
+		close(p.quit)
+		p.quit = nil
+	}
+	p.lock.Unlock()
+	p.wg.Wait()
+}
+
+// Progress returns the status of the online pruner.
+func (p *OnlinePruner) Progress() OnlineProgress {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	progress := p.progress
+	progress.Cursor = common.CopyBytes(p.progress.Cursor)
+	return progress
+}
+
+// loadJournal retrieves the persisted progress of an interrupted run.
+func (p *OnlinePruner) loadJournal() (*onlineJournal, error) {
+	blob := rawdb.ReadOnlinePruningProgress(p.db)
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	journal := new(onlineJournal)
+	if err := rlp.DecodeBytes(blob, journal); err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+// start spawns a pruning run continuing from the given progress.
+func (p *OnlinePruner) start(journal *onlineJournal) error {
+	if p.chain.Snapshots() == nil {
+		return errSnapshotDisabled
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.progress.Running {
+		return errPruningRunning
+	}
+	// Persist the run right away, so it's picked up again after a restart
+	blob, err := rlp.EncodeToBytes(journal)
+	if err != nil {
+		return err
+	}
+	rawdb.WriteOnlinePruningProgress(p.db, blob)
+
+	quit := make(chan struct{})
+	p.quit = quit
+	p.progress = OnlineProgress{
+		Running: true,
+		Phase:   PhaseMarking,
+		Cursor:  common.CopyBytes(journal.Cursor),
+		Pruned:  journal.Pruned,
+		Size:    journal.Size,
+		Started: time.Now(),
+	}
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		err := p.prune(journal, quit)
+		switch err {
+		case nil:
+		case errPruningStopped:
+			log.Info("Online state pruning interrupted", "cursor", hexutil.Bytes(journal.Cursor))
+		default:
+			log.Error("Online state pruning failed", "err", err)
+		}
+		p.lock.Lock()
+		p.progress.Running = false
+		p.progress.Phase = PhaseIdle
+		if err != nil {
+			p.progress.Error = err.Error()
+		}
+		p.lock.Unlock()
+	}()
+	return nil
+}
+
+// prune marks the live state and sweeps every state entry not belonging to it.
+func (p *OnlinePruner) prune(journal *onlineJournal, quit chan struct{}) error {
+	start := time.Now()
+
+	bloom, err := newStateBloomWithSize(p.config.BloomSize)
+	if err != nil {
+		return err
+	}
+	// Pick the newest state persisted to disk as the marking target, and mark the
+	// in-memory states on top of it before any of them is garbage collected.
+	m := &marker{chain: p.chain, bloom: bloom}
+	if err := p.chain.WithChainLock(func() error {
+		root, err := p.persistedRoot()
+		if err != nil {
+			return err
+		}
+		m.base = root
+		return m.catchUp()
+	}); err != nil {
+		return err
+	}
+	p.lock.Lock()
+	p.progress.Root = m.base
+	p.lock.Unlock()
+
+	log.Info("Marking live state", "root", m.base)
+	if err := extractGenesis(p.db, bloom); err != nil {
+		return err
+	}
+	caught := time.Now()
+	tick := func() error {
+		select {
+		case <-quit:
+			return errPruningStopped
+		default:
+		}
+		if time.Since(caught) < onlineCatchUpInterval {
+			return nil
+		}
+		p.lock.Lock()
+		p.progress.Marked = m.nodes
+		p.lock.Unlock()
+
+		caught = time.Now()
+		return p.chain.WithChainLock(m.catchUp)
+	}
+	// The target state is fully persisted, iterate it straight from disk
+	if err := m.mark(trie.NewDatabase(p.db), emptyRoot, m.base, tick); err != nil {
+		return err
+	}
+	log.Info("Marked live state", "root", m.base, "nodes", m.nodes, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	p.lock.Lock()
+	p.progress.Phase = PhaseSweeping
+	p.progress.Marked = m.nodes
+	p.lock.Unlock()
+
+	if err := p.sweep(m, journal, quit); err != nil {
+		return err
+	}
+	if journal.Pruned >= rangeCompactionThreshold {
+		p.lock.Lock()
+		p.progress.Phase = PhaseCompacting
+		p.lock.Unlock()
+
+		if err := compact(p.db); err != nil {
+			return err
+		}
+	}
+	log.Info("Online state pruning successful", "pruned", common.StorageSize(journal.Size), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// sweep deletes the unmarked trie nodes and codes in throttled batches, starting
+// from the cursor of the journal.
+func (p *OnlinePruner) sweep(m *marker, journal *onlineJournal, quit chan struct{}) error {
+	var (
+		keys   [][]byte
+		sizes  []int
+		pstart = time.Now()
+		logged = time.Now()
+	)
+	// flush deletes the collected stale entries and persists the progress. The
+	// states imported since the last batch are marked first, with the chain
+	// lock held, so none of the deleted entries can be referenced by them.
+	flush := func(cursor []byte, done bool) error {
+		return p.chain.WithChainLock(func() error {
+			if err := m.catchUp(); err != nil {
+				return err
+			}
+			batch := p.db.NewBatch()
+			for i, key := range keys {
+				if ok, _ := m.bloom.Contain(stateKey(key)); ok {
+					continue
+				}
+				batch.Delete(key)
+				journal.Pruned++
+				journal.Size += uint64(sizes[i])
+			}
+			journal.Cursor = cursor
+			if done {
+				rawdb.DeleteOnlinePruningProgress(batch)
+			} else {
+				blob, err := rlp.EncodeToBytes(journal)
+				if err != nil {
+					return err
+				}
+				rawdb.WriteOnlinePruningProgress(batch, blob)
+			}
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			keys, sizes = keys[:0], sizes[:0]
+
+			p.lock.Lock()
+			p.progress.Marked = m.nodes
+			p.progress.Cursor = common.CopyBytes(cursor)
+			p.progress.Pruned = journal.Pruned
+			p.progress.Size = journal.Size
+			p.lock.Unlock()
+			return nil
+		})
+	}
+	iter := p.db.NewIterator(nil, journal.Cursor)
+	defer func() { iter.Release() }()
+
+	for iter.Next() {
+		key := iter.Key()
+		checkKey := stateKey(key)
+		if checkKey == nil {
+			continue
+		}
+		if ok, err := m.bloom.Contain(checkKey); err != nil {
+			return err
+		} else if ok {
+			continue
+		}
+		keys = append(keys, common.CopyBytes(key))
+		sizes = append(sizes, len(key)+len(iter.Value()))
+
+		if len(keys) < p.config.BatchSize {
+			continue
+		}
+		cursor := common.CopyBytes(key)
+		if err := flush(cursor, false); err != nil {
+			return err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Pruning state data", "nodes", journal.Pruned, "size", common.StorageSize(journal.Size),
+				"cursor", hexutil.Bytes(cursor), "elapsed", common.PrettyDuration(time.Since(pstart)))
+			logged = time.Now()
+		}
+		// Release the iterator while pausing in order to allow the underlying
+		// compactor to delete the entries.
+		iter.Release()
+		select {
+		case <-quit:
+			return errPruningStopped
+		case <-time.After(p.config.BatchDelay):
+		}
+		iter = p.db.NewIterator(nil, cursor)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return flush(nil, true)
+}
+
+// persistedRoot returns the state root of the most recent canonical block whose
+// state is persisted to disk. The presence of the root node indicates the whole
+// trie is, as the chain always flushes the children of a node first.
+func (p *OnlinePruner) persistedRoot() (common.Hash, error) {
+	for number := p.chain.CurrentBlock().NumberU64(); ; number-- {
+		header := p.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return common.Hash{}, fmt.Errorf("missing header #%d", number)
+		}
+		if blob := rawdb.ReadTrieNode(p.db, header.Root); len(blob) != 0 {
+			return header.Root, nil
+		}
+		if number == 0 {
+			return common.Hash{}, errors.New("no persisted state found")
+		}
+	}
+}
+
+// stateKey returns the key under which the given database entry is tracked in
+// the state bloom, or nil if the entry is neither a trie node nor contract code.
+func stateKey(key []byte) []byte {
+	if len(key) == common.HashLength {
+		return key
+	}
+	if isCode, codeKey := rawdb.IsCodeKey(key); isCode {
+		return codeKey
+	}
+	return nil
+}
+
+// marker records the live states of a running chain into a state bloom.
+type marker struct {
+	chain  Chain
+	bloom  *stateBloom
+	base   common.Hash              // Persisted state root the live states are marked from
+	layers map[common.Hash]struct{} // Roots of the snapshot layers marked by the last catch up
+	nodes  uint64                   // Number of trie nodes marked so far
+}
+
+// catchUp marks the states of the snapshot layers imported since the previous
+// call. It needs to be called with the chain lock held, so the in-memory states
+// are neither flushed nor garbage collected while being marked.
+func (m *marker) catchUp() error {
+	layers := m.chain.Snapshots().Snapshots(m.chain.CurrentBlock().Root(), onlineSnapshotLayers, false)
+	if len(layers) == 0 {
+		return errors.New("no snapshot available for the head state")
+	}
+	var (
+		triedb  = m.chain.StateCache().TrieDB()
+		parent  = m.base
+		marked  = make(map[common.Hash]struct{})
+		tracked = m.layers == nil
+	)
+	for i := len(layers) - 1; i >= 0; i-- {
+		root := layers[i].Root()
+		if _, err := triedb.Node(root); err != nil {
+			continue // State not in memory (anymore), e.g. layers loaded after a restart
+		}
+		if _, ok := m.layers[root]; ok {
+			tracked = true
+		} else if err := m.mark(triedb, parent, root, nil); err != nil {
+			return err
+		}
+		marked[root] = struct{}{}
+		parent = root
+	}
+	// If none of the states marked last time is still around, the ones in
+	// between might have been flushed to disk without being marked.
+	if !tracked && len(m.layers) > 0 {
+		return errMarkerBehind
+	}
+	m.layers = marked
+	return nil
+}
+
+// mark records every trie node and contract code of the state rooted at root,
+// which is not part of the already marked state rooted at parent.
+func (m *marker) mark(triedb *trie.Database, parent, root common.Hash, tick func() error) error {
+	parentTrie, err := trie.New(parent, triedb)
+	if err != nil {
+		return err
+	}
+	rootTrie, err := trie.New(root, triedb)
+	if err != nil {
+		return err
+	}
+	iter, _ := trie.NewDifferenceIterator(parentTrie.NodeIterator(nil), rootTrie.NodeIterator(nil))
+	for iter.Next(true) {
+		// Embedded nodes don't have hash.
+		if hash := iter.Hash(); hash != (common.Hash{}) {
+			m.bloom.Put(hash.Bytes(), nil)
+			m.nodes++
+		}
+		if !iter.Leaf() {
+			continue
+		}
+		var acc state.Account
+		if err := rlp.DecodeBytes(iter.LeafBlob(), &acc); err != nil {
+			return err
+		}
+		// Only the storage slots changed since the parent need marking
+		parentStorage := emptyRoot
+		blob, err := parentTrie.TryGet(iter.LeafKey())
+		if err != nil {
+			return err
+		}
+		if len(blob) > 0 {
+			var parentAcc state.Account
+			if err := rlp.DecodeBytes(blob, &parentAcc); err != nil {
+				return err
+			}
+			parentStorage = parentAcc.Root
+		}
+		if acc.Root != emptyRoot && acc.Root != parentStorage {
+			if err := m.markStorage(triedb, parentStorage, acc.Root); err != nil {
+				return err
+			}
+		}
+		if !bytes.Equal(acc.CodeHash, emptyCode) {
+			m.bloom.Put(acc.CodeHash, nil)
+		}
+		if tick != nil {
+			if err := tick(); err != nil {
+				return err
+			}
+		}
+	}
+	return iter.Error()
+}
+
+// markStorage records the nodes of the storage trie rooted at root, which are
+// not part of the already marked storage trie rooted at parent.
+func (m *marker) markStorage(triedb *trie.Database, parent, root common.Hash) error {
+	parentTrie, err := trie.New(parent, triedb)
+	if err != nil {
+		return err
+	}
+	rootTrie, err := trie.New(root, triedb)
+	if err != nil {
+		return err
+	}
+	iter, _ := trie.NewDifferenceIterator(parentTrie.NodeIterator(nil), rootTrie.NodeIterator(nil))
+	for iter.Next(true) {
+		if hash := iter.Hash(); hash != (common.Hash{}) {
+			m.bloom.Put(hash.Bytes(), nil)
+			m.nodes++
+		}
+	}
+	return iter.Error()
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	mockEngine "github.com/celo-org/celo-blockchain/consensus/consensustest"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/params"
+	"github.com/celo-org/celo-blockchain/rlp"
+	"github.com/celo-org/celo-blockchain/trie"
+)
+
+var testOnlineConfig = OnlineConfig{BloomSize: 16, BatchSize: 8}
+
+// newOnlineTestChain creates a chain of transfer blocks, with the state of block
+// stale persisted to disk and the one of block persisted marking the newest
+// state on disk. Only the states of the most recent blocks are kept in memory.
+func newOnlineTestChain(t *testing.T, blocks, stale, persisted int) (ethdb.Database, *core.BlockChain, []*types.Block) {
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		gendb   = rawdb.NewMemoryDatabase()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &core.Genesis{
+			Config: params.IstanbulTestChainConfig,
+			Alloc:  core.GenesisAlloc{address: {Balance: big.NewInt(1000000000000000)}},
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSigner(gspec.Config)
+	)
+	gspec.MustCommit(db)
+
+	chain, err := core.NewBlockChain(db, nil, gspec.Config, mockEngine.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	generated, _ := core.GenerateChain(gspec.Config, genesis, mockEngine.NewFaker(), gendb, blocks, func(i int, block *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{byte(i + 1)}, big.NewInt(1000), params.TxGas, nil, nil, nil, nil, nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		block.AddTx(tx)
+	})
+	for _, number := range []int{stale, persisted, blocks} {
+		if _, err := chain.InsertChain(generated[int(chain.CurrentBlock().NumberU64()):number]); err != nil {
+			t.Fatalf("failed to insert chain: %v", err)
+		}
+		if number != blocks {
+			if err := chain.StateCache().TrieDB().Commit(generated[number-1].Root(), false, nil); err != nil {
+				t.Fatalf("failed to commit state: %v", err)
+			}
+		}
+	}
+	return db, chain, generated
+}
+
+// checkState iterates over the entire state rooted at root.
+func checkState(t *testing.T, triedb *trie.Database, root common.Hash) {
+	t.Helper()
+
+	tr, err := trie.New(root, triedb)
+	if err != nil {
+		t.Fatalf("state %x unavailable: %v", root, err)
+	}
+	it := tr.NodeIterator(nil)
+	for it.Next(true) {
+	}
+	if it.Error() != nil {
+		t.Fatalf("state %x incomplete: %v", root, it.Error())
+	}
+}
+
+func TestOnlinePruning(t *testing.T) {
+	db, chain, blocks := newOnlineTestChain(t, 150, 5, 12)
+	defer chain.Stop()
+
+	garbage := common.HexToHash("0x80")
+	rawdb.WriteTrieNode(db, garbage, []byte{0x01})
+
+	pruner := NewOnlinePruner(db, chain, testOnlineConfig)
+	if err := pruner.prune(new(onlineJournal), make(chan struct{})); err != nil {
+		t.Fatalf("pruning failed: %v", err)
+	}
+	if blob := rawdb.ReadTrieNode(db, garbage); len(blob) != 0 {
+		t.Error("garbage trie node not pruned")
+	}
+	if blob := rawdb.ReadTrieNode(db, blocks[4].Root()); len(blob) != 0 {
+		t.Error("stale state root not pruned")
+	}
+	if blob := rawdb.ReadOnlinePruningProgress(db); len(blob) != 0 {
+		t.Error("pruning progress not cleaned up")
+	}
+	// The genesis, the persisted and all the in-memory states must be intact
+	checkState(t, trie.NewDatabase(db), chain.Genesis().Root())
+	checkState(t, trie.NewDatabase(db), blocks[11].Root())
+	for _, block := range blocks[len(blocks)-core.TriesInMemory:] {
+		checkState(t, chain.StateCache().TrieDB(), block.Root())
+	}
+}
+
+func TestOnlinePruningResume(t *testing.T) {
+	db, chain, blocks := newOnlineTestChain(t, 150, 5, 12)
+	defer chain.Stop()
+
+	var (
+		swept   = common.HexToHash("0x10")
+		pending = common.HexToHash("0xf0")
+	)
+	rawdb.WriteTrieNode(db, swept, []byte{0x01})
+	rawdb.WriteTrieNode(db, pending, []byte{0x01})
+
+	// Pretend an earlier run was interrupted halfway through the key space
+	journal := &onlineJournal{Cursor: common.HexToHash("0x80").Bytes(), Pruned: 1}
+	blob, err := rlp.EncodeToBytes(journal)
+	if err != nil {
+		t.Fatalf("failed to encode journal: %v", err)
+	}
+	rawdb.WriteOnlinePruningProgress(db, blob)
+
+	pruner := NewOnlinePruner(db, chain, testOnlineConfig)
+	if err := pruner.Start(); err != nil {
+		t.Fatalf("failed to resume pruning: %v", err)
+	}
+	pruner.wg.Wait()
+
+	progress := pruner.Progress()
+	if progress.Running || progress.Error != "" {
+		t.Fatalf("unexpected progress after pruning: %+v", progress)
+	}
+	if progress.Pruned <= journal.Pruned {
+		t.Errorf("pruned count not continued: have %d, want > %d", progress.Pruned, journal.Pruned)
+	}
+	if blob := rawdb.ReadTrieNode(db, swept); len(blob) == 0 {
+		t.Error("entry before the cursor swept again")
+	}
+	if blob := rawdb.ReadTrieNode(db, pending); len(blob) != 0 {
+		t.Error("entry after the cursor not pruned")
+	}
+	checkState(t, chain.StateCache().TrieDB(), blocks[len(blocks)-1].Root())
+}
+
+func TestOnlinePruningStop(t *testing.T) {
+	db, chain, _ := newOnlineTestChain(t, 150, 5, 12)
+	defer chain.Stop()
+
+	rawdb.WriteTrieNode(db, common.HexToHash("0x80"), []byte{0x01})
+
+	pruner := NewOnlinePruner(db, chain, OnlineConfig{BloomSize: 16, BatchSize: 1, BatchDelay: time.Hour})
+	if err := pruner.Prune(); err != nil {
+		t.Fatalf("failed to start pruning: %v", err)
+	}
+	if err := pruner.Prune(); err != errPruningRunning {
+		t.Errorf("concurrent pruning error mismatch: have %v, want %v", err, errPruningRunning)
+	}
+	// Wait for the first batch to be swept, then interrupt the run
+	for progress := pruner.Progress(); progress.Pruned == 0; progress = pruner.Progress() {
+		if !progress.Running {
+			t.Fatalf("pruning terminated early: %+v", progress)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	pruner.Stop()
+
+	progress := pruner.Progress()
+	if progress.Running || progress.Error != errPruningStopped.Error() {
+		t.Fatalf("unexpected progress after stop: %+v", progress)
+	}
+	journal, err := pruner.loadJournal()
+	if err != nil || journal == nil {
+		t.Fatalf("progress not persisted: %v", err)
+	}
+	if journal.Pruned != progress.Pruned || len(journal.Cursor) == 0 {
+		t.Errorf("persisted progress mismatch: have %+v, want pruned %d", journal, progress.Pruned)
+	}
+}
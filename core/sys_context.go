@@ -48,6 +48,24 @@ func NewSysContractCallCtx(vmRunner vm.EVMRunner) (sc *SysContractCallCtx) {
 	return
 }
 
+// NewSysContractCallCtxFromValues creates a SysContractCallCtx from the given values instead of
+// reading them from the system contracts. Every currency with a gas price minimum, other than the
+// native token(CELO) keyed by common.ZeroAddress, is whitelisted.
+func NewSysContractCallCtxFromValues(gasForAlternativeCurrency uint64, gasPriceMinimums GasPriceMinimums) *SysContractCallCtx {
+	sc := &SysContractCallCtx{
+		whitelistedCurrencies:     make(map[common.Address]struct{}),
+		gasForAlternativeCurrency: gasForAlternativeCurrency,
+		gasPriceMinimums:          make(map[common.Address]*big.Int),
+	}
+	for feeCurrency, gasPriceMinimum := range gasPriceMinimums {
+		if feeCurrency != common.ZeroAddress {
+			sc.whitelistedCurrencies[feeCurrency] = struct{}{}
+		}
+		sc.gasPriceMinimums[feeCurrency] = gasPriceMinimum
+	}
+	return sc
+}
+
 // GetIntrinsicGasForAlternativeFeeCurrency retrieves intrinsic gas for non-native fee currencies.
 func (sc *SysContractCallCtx) GetIntrinsicGasForAlternativeFeeCurrency() uint64 {
 	return sc.gasForAlternativeCurrency
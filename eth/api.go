@@ -31,6 +31,7 @@ import (
 	"github.com/celo-org/celo-blockchain/core"
 	"github.com/celo-org/celo-blockchain/core/rawdb"
 	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/state/pruner"
 	"github.com/celo-org/celo-blockchain/core/types"
 	"github.com/celo-org/celo-blockchain/internal/ethapi"
 	"github.com/celo-org/celo-blockchain/rlp"
@@ -299,6 +300,26 @@ func NewPrivateDebugAPI(eth *Ethereum) *PrivateDebugAPI {
 	return &PrivateDebugAPI{eth: eth}
 }
 
+// errArchiveMode is returned when state pruning is requested on an archive node.
+var errArchiveMode = errors.New("state pruning is not available in archive mode")
+
+// PruneState starts deleting the stale state in the background while the node
+// keeps running, or resumes the pruning interrupted earlier.
+func (api *PrivateDebugAPI) PruneState() error {
+	if api.eth.onlinePruner == nil {
+		return errArchiveMode
+	}
+	return api.eth.onlinePruner.Prune()
+}
+
+// StatePruningProgress returns the progress of the online state pruning.
+func (api *PrivateDebugAPI) StatePruningProgress() (pruner.OnlineProgress, error) {
+	if api.eth.onlinePruner == nil {
+		return pruner.OnlineProgress{}, errArchiveMode
+	}
+	return api.eth.onlinePruner.Progress(), nil
+}
+
 // Preimage is a debug API function that returns the preimage for a sha3 hash, if known.
 func (api *PrivateDebugAPI) Preimage(ctx context.Context, hash common.Hash) (hexutil.Bytes, error) {
 	if preimage := rawdb.ReadPreimage(api.eth.ChainDb(), hash); preimage != nil {
@@ -87,6 +87,8 @@ type Ethereum struct {
 
 	APIBackend *EthAPIBackend
 
+	onlinePruner *pruner.OnlinePruner // Background pruner of the stale state, nil in archive mode
+
 	miner          *miner.Miner
 	gatewayFee     *big.Int
 	validator      common.Address
@@ -203,6 +205,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
 
+	// Archive nodes keep all the historical state, there's nothing to prune
+	if !config.NoPruning {
+		eth.onlinePruner = pruner.NewOnlinePruner(chainDb, eth.blockchain, pruner.DefaultOnlineConfig)
+	}
+
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
@@ -265,6 +272,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	stack.RegisterAPIs(eth.APIs())
 	stack.RegisterProtocols(eth.Protocols())
 	stack.RegisterLifecycle(eth)
+	eth.registerHealthChecks(stack)
 	// Check for unclean shutdown
 	if uncleanShutdowns, discards, err := rawdb.PushUncleanShutdownMarker(chainDb); err != nil {
 		log.Error("Could not update unclean-shutdown-marker list", "error", err)
@@ -587,6 +595,13 @@ func (s *Ethereum) Start() error {
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
 
+	// Resume the state pruning interrupted by the last shutdown
+	if s.onlinePruner != nil {
+		if err := s.onlinePruner.Start(); err != nil {
+			log.Warn("Failed to resume online state pruning", "err", err)
+		}
+	}
+
 	if err := s.startAnnounce(); err != nil {
 		return err
 	}
@@ -609,6 +624,9 @@ func (s *Ethereum) Stop() error {
 	s.txPool.Stop()
 	s.miner.Stop()
 	s.miner.Close()
+	if s.onlinePruner != nil {
+		s.onlinePruner.Stop()
+	}
 	s.blockchain.Stop()
 	s.engine.Close()
 	rawdb.PopUncleanShutdownMarker(s.chainDb)
@@ -0,0 +1,259 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"fmt"
+	"sync"
+
+	istanbulBackend "github.com/celo-org/celo-blockchain/consensus/istanbul/backend"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/proxy"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/node"
+)
+
+// syncLagDetails is the JSON detail of the sync health check.
+type syncLagDetails struct {
+	CurrentBlock uint64 `json:"currentBlock"`
+	PeerBlock    uint64 `json:"peerBlock"` // Head of the peer with the highest total difficulty
+	Lag          uint64 `json:"lag"`
+	MaxLag       uint64 `json:"maxLag"`
+}
+
+// registerHealthChecks registers the checks of the chain and of the consensus
+// engine enabled in the health config of the node.
+func (s *Ethereum) registerHealthChecks(stack *node.Node) {
+	config := stack.Config().Health
+	if config.MaxSyncLag > 0 {
+		stack.RegisterReadinessCheck("sync", s.syncHealth(config.MaxSyncLag))
+	}
+	istanbul, isIstanbul := s.engine.(*istanbulBackend.Backend)
+	if !isIstanbul {
+		return
+	}
+	if config.SealWindow > 0 {
+		seals := newSealParticipationCache(istanbul, s.blockchain, config.SealWindow)
+		stack.RegisterLifecycle(seals)
+		stack.RegisterHealthCheck("seal", sealHealth(istanbul, seals))
+	}
+	if config.ProxyLink {
+		stack.RegisterHealthCheck("proxy", proxyHealth(istanbul))
+	}
+	if config.ReplicaState {
+		stack.RegisterHealthCheck("replica", replicaHealth(istanbul))
+	}
+}
+
+// syncHealth checks that the node is at most maxLag blocks behind its best
+// peer. The total difficulty of a Celo block is its number plus one, so the
+// total difficulty announced by a peer gives the number of its head.
+func (s *Ethereum) syncHealth(maxLag uint64) node.HealthCheck {
+	return func() *node.HealthResult {
+		details := &syncLagDetails{CurrentBlock: s.blockchain.CurrentHeader().Number.Uint64(), MaxLag: maxLag}
+		peer := s.handler.peers.peerWithHighestTD()
+		if peer == nil {
+			return &node.HealthResult{Healthy: false, Message: "no peers to sync with", Details: details}
+		}
+		if _, td := peer.Head(); td.Sign() > 0 {
+			details.PeerBlock = td.Uint64() - 1
+		}
+		if details.PeerBlock > details.CurrentBlock {
+			details.Lag = details.PeerBlock - details.CurrentBlock
+		}
+		if details.Lag > maxLag {
+			return &node.HealthResult{Healthy: false, Message: fmt.Sprintf("%d blocks behind", details.Lag), Details: details}
+		}
+		return &node.HealthResult{Healthy: true, Details: details}
+	}
+}
+
+// sealParticipationCache keeps the seal participation of the validator at the
+// chain head. Checking it walks the headers of the whole window, so it's done
+// once per chain head rather than on every health request.
+type sealParticipationCache struct {
+	istanbul *istanbulBackend.Backend
+	chain    *core.BlockChain
+	window   uint64
+
+	mu            sync.RWMutex
+	participation *istanbulBackend.SealParticipation // Participation at the latest head, nil if not validating
+	err           error                              // Failure computing the participation at the latest head
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newSealParticipationCache(istanbul *istanbulBackend.Backend, chain *core.BlockChain, window uint64) *sealParticipationCache {
+	return &sealParticipationCache{
+		istanbul: istanbul,
+		chain:    chain,
+		window:   window,
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start implements node.Lifecycle, starting to follow the chain head.
+func (c *sealParticipationCache) Start() error {
+	c.wg.Add(1)
+	go c.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the chain head loop.
+func (c *sealParticipationCache) Stop() error {
+	close(c.quit)
+	c.wg.Wait()
+	return nil
+}
+
+func (c *sealParticipationCache) loop() {
+	defer c.wg.Done()
+
+	headCh := make(chan core.ChainHeadEvent, 10)
+	headSub := c.chain.SubscribeChainHeadEvent(headCh)
+	defer headSub.Unsubscribe()
+
+	c.update()
+	for {
+		select {
+		case <-headCh:
+			// Heads come in bursts while syncing, only the latest matters
+			for drained := false; !drained; {
+				select {
+				case <-headCh:
+				default:
+					drained = true
+				}
+			}
+			c.update()
+		case <-headSub.Err():
+			return
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// update recomputes the seal participation at the current head.
+func (c *sealParticipationCache) update() {
+	var (
+		participation *istanbulBackend.SealParticipation
+		err           error
+	)
+	if c.istanbul.IsProxy() || c.istanbul.IsValidating() {
+		participation, err = c.istanbul.SealParticipation(c.window)
+	}
+	c.mu.Lock()
+	c.participation, c.err = participation, err
+	c.mu.Unlock()
+}
+
+// get returns the seal participation at the latest head processed.
+func (c *sealParticipationCache) get() (*istanbulBackend.SealParticipation, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.participation, c.err
+}
+
+// sealHealth checks that a validator signed at least one of the parent seals
+// of the last blocks it was elected for, as cached at the latest chain head.
+func sealHealth(istanbul *istanbulBackend.Backend, seals *sealParticipationCache) node.HealthCheck {
+	return func() *node.HealthResult {
+		if !istanbul.IsProxy() && !istanbul.IsValidating() {
+			return &node.HealthResult{Healthy: true, Message: "not validating"}
+		}
+		participation, err := seals.get()
+		if participation == nil && err == nil {
+			// Validating started since the latest head, check again at the next one
+			return &node.HealthResult{Healthy: true, Message: "waiting for the next block"}
+		}
+		if err != nil {
+			return &node.HealthResult{Healthy: false, Message: err.Error()}
+		}
+		result := &node.HealthResult{Healthy: true, Details: participation}
+		switch {
+		case participation.Elected == 0:
+			result.Message = "not elected"
+		case participation.Signed == 0:
+			result.Healthy = false
+			result.Message = fmt.Sprintf("missing from the last %d parent seals", participation.Elected)
+		}
+		return result
+	}
+}
+
+// proxyHealth checks that a proxy is peered with its proxied validator, or that
+// a proxied validator is peered with at least one of its proxies.
+func proxyHealth(istanbul *istanbulBackend.Backend) node.HealthCheck {
+	return func() *node.HealthResult {
+		switch {
+		case istanbul.IsProxy():
+			validators, err := istanbul.GetProxyEngine().GetProxiedValidatorsInfo()
+			if err != nil {
+				return &node.HealthResult{Healthy: false, Message: err.Error()}
+			}
+			for _, validator := range validators {
+				if validator.IsPeered {
+					return &node.HealthResult{Healthy: true, Details: validators}
+				}
+			}
+			return &node.HealthResult{Healthy: false, Message: "proxied validator not peered", Details: validators}
+
+		case istanbul.IsProxiedValidator():
+			engine := istanbul.GetProxiedValidatorEngine()
+			if engine == nil {
+				return &node.HealthResult{Healthy: false, Message: "proxied validator engine not running"}
+			}
+			proxies, assignments, err := engine.GetProxiesAndValAssignments()
+			if err != nil {
+				return &node.HealthResult{Healthy: false, Message: err.Error()}
+			}
+			infos := make([]*proxy.ProxyInfo, 0, len(proxies))
+			peered := false
+			for _, p := range proxies {
+				infos = append(infos, proxy.NewProxyInfo(p, assignments[p.ID()]))
+				peered = peered || p.IsPeered()
+			}
+			if !peered {
+				return &node.HealthResult{Healthy: false, Message: "no proxy peered", Details: infos}
+			}
+			return &node.HealthResult{Healthy: true, Details: infos}
+
+		default:
+			return &node.HealthResult{Healthy: true, Message: "not a proxy or proxied validator"}
+		}
+	}
+}
+
+// replicaHealth checks that a validator is validating if and only if its replica
+// state makes it the primary.
+func replicaHealth(istanbul *istanbulBackend.Backend) node.HealthCheck {
+	return func() *node.HealthResult {
+		if !istanbul.IsValidator() {
+			return &node.HealthResult{Healthy: true, Message: "not a validator"}
+		}
+		summary := istanbul.ReplicaStateSummary()
+		validating := istanbul.IsValidating()
+		switch {
+		case summary.IsPrimary && !validating:
+			return &node.HealthResult{Healthy: false, Message: "primary but not validating", Details: summary}
+		case !summary.IsPrimary && validating:
+			return &node.HealthResult{Healthy: false, Message: "replica but validating", Details: summary}
+		}
+		return &node.HealthResult{Healthy: true, Details: summary}
+	}
+}
@@ -172,16 +172,20 @@ type TraceConfig struct {
 	Tracer  *string
 	Timeout *string
 	Reexec  *uint64
+	// DecodeCeloCalls annotates the calls to the celo core contracts in the
+	// result of the callTracer with the called contract and method.
+	DecodeCeloCalls *bool
 }
 
 // TraceCallConfig is the config for traceCall API. It holds one more
 // field to override the state for tracing.
 type TraceCallConfig struct {
 	*vm.LogConfig
-	Tracer         *string
-	Timeout        *string
-	Reexec         *uint64
-	StateOverrides *ethapi.StateOverride
+	Tracer          *string
+	Timeout         *string
+	Reexec          *uint64
+	StateOverrides  *ethapi.StateOverride
+	DecodeCeloCalls *bool
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -818,10 +822,11 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 	var traceConfig *TraceConfig
 	if config != nil {
 		traceConfig = &TraceConfig{
-			LogConfig: config.LogConfig,
-			Tracer:    config.Tracer,
-			Timeout:   config.Timeout,
-			Reexec:    config.Reexec,
+			LogConfig:       config.LogConfig,
+			Tracer:          config.Tracer,
+			Timeout:         config.Timeout,
+			Reexec:          config.Reexec,
+			DecodeCeloCalls: config.DecodeCeloCalls,
 		}
 	}
 	return api.traceTx(ctx, msg, new(Context), vmctx, vmRunner, statedb, sysCtx, traceConfig)
@@ -893,7 +898,11 @@ func (api *API) traceTx(ctx context.Context, message core.Message, txctx *Contex
 		}, nil
 
 	case *Tracer:
-		return tracer.GetResult()
+		result, err := tracer.GetResult()
+		if err != nil || config.DecodeCeloCalls == nil || !*config.DecodeCeloCalls {
+			return result, err
+		}
+		return decodeCeloCalls(result, vmRunner)
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
@@ -0,0 +1,76 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/contracts"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+	"github.com/celo-org/celo-blockchain/core/vm"
+)
+
+// decodeCeloCalls annotates the calls of a callTracer result to the celo core
+// contracts, resolved through the registry of the given runner, with the name of
+// the called contract and the decoded method. Results which are not JSON objects,
+// as returned by custom tracers, are returned as is.
+func decodeCeloCalls(result json.RawMessage, vmRunner vm.EVMRunner) (json.RawMessage, error) {
+	var call map[string]interface{}
+	if err := json.Unmarshal(result, &call); err != nil || call == nil {
+		return result, nil
+	}
+	decoder := abis.NewDecoder()
+	err := decoder.ResolveRegistry(func(registryId common.Hash) (common.Address, error) {
+		address, err := contracts.GetRegisteredAddress(vmRunner, registryId)
+		if err == contracts.ErrSmartContractNotDeployed || err == contracts.ErrRegistryContractNotDeployed {
+			return common.Address{}, nil
+		}
+		return address, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	annotateCeloCall(decoder, call)
+	return json.Marshal(call)
+}
+
+// annotateCeloCall adds the "contract" and "method" fields to the given call of
+// a callTracer result, and its subcalls, if they are calls to core contracts.
+func annotateCeloCall(decoder *abis.Decoder, call map[string]interface{}) {
+	if to, ok := call["to"].(string); ok && common.IsHexAddress(to) {
+		address := common.HexToAddress(to)
+		if name, ok := decoder.ContractName(address); ok {
+			call["contract"] = name
+			if input, ok := call["input"].(string); ok {
+				if data, err := hexutil.Decode(input); err == nil {
+					if decoded, err := decoder.Decode(&address, data); err == nil {
+						call["method"] = decoded.String()
+					}
+				}
+			}
+		}
+	}
+	if calls, ok := call["calls"].([]interface{}); ok {
+		for _, subcall := range calls {
+			if subcall, ok := subcall.(map[string]interface{}); ok {
+				annotateCeloCall(decoder, subcall)
+			}
+		}
+	}
+}
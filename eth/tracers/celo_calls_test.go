@@ -0,0 +1,85 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+	"github.com/celo-org/celo-blockchain/contracts/testutil"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+func TestDecodeCeloCalls(t *testing.T) {
+	stableToken := common.HexToAddress("0x765de816845861e75a25fca122bb6898b8b1282a")
+	runner := testutil.NewMockEVMRunner()
+	registry := testutil.NewRegistryMock()
+	registry.AddContract(params.StableTokenRegistryId, stableToken)
+	runner.RegisterContract(params.RegistrySmartContractAddress, registry)
+
+	input, err := abis.StableToken.Pack("transferWithComment", common.HexToAddress("0xdead"), big.NewInt(1000), "rent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := fmt.Sprintf(`{
+		"type": "CALL", "from": "0x00000000000000000000000000000000000000aa", "to": "0x00000000000000000000000000000000000000bb", "input": "0x12345678",
+		"calls": [{"type": "CALL", "from": "0x00000000000000000000000000000000000000bb", "to": "%s", "input": "%s"}]
+	}`, stableToken.Hex(), hexutil.Encode(input))
+
+	annotated, err := decodeCeloCalls(json.RawMessage(result), runner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var call struct {
+		Contract string `json:"contract"`
+		Method   string `json:"method"`
+		Calls    []struct {
+			Contract string `json:"contract"`
+			Method   string `json:"method"`
+		} `json:"calls"`
+	}
+	if err := json.Unmarshal(annotated, &call); err != nil {
+		t.Fatal(err)
+	}
+	if call.Contract != "" || call.Method != "" {
+		t.Errorf("annotated a call to an unknown contract: %s %s", call.Contract, call.Method)
+	}
+	if len(call.Calls) != 1 {
+		t.Fatalf("wrong number of subcalls: %d", len(call.Calls))
+	}
+	want := `StableToken.transferWithComment(to: 0x000000000000000000000000000000000000dEaD, value: 1000, comment: "rent")`
+	if have := call.Calls[0]; have.Contract != "StableToken" || have.Method != want {
+		t.Errorf("wrong annotation: have %s %s, want StableToken %s", have.Contract, have.Method, want)
+	}
+}
+
+func TestDecodeCeloCallsNonObjectResult(t *testing.T) {
+	for _, result := range []string{`[1,2,3]`, `"0x1234"`, `42`, `null`} {
+		annotated, err := decodeCeloCalls(json.RawMessage(result), testutil.NewMockEVMRunner())
+		if err != nil {
+			t.Fatalf("result %s: %v", result, err)
+		}
+		if string(annotated) != result {
+			t.Errorf("result modified: have %s, want %s", annotated, result)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package versioncheck enforces the minimum client version set in the
+// BlockchainParameters contract.
+package versioncheck
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/consensus"
+	"github.com/celo-org/celo-blockchain/contracts/blockchain_parameters"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/event"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/metrics"
+	"github.com/celo-org/celo-blockchain/node"
+	"github.com/celo-org/celo-blockchain/params"
+	"github.com/celo-org/celo-blockchain/rpc"
+)
+
+const (
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
+
+	// checkInterval is the minimum time between two reads of the minimum version.
+	// Reading it needs the state of the head, which light clients fetch from the
+	// network, so new heads only trigger a check once the interval has passed.
+	checkInterval = 60 * time.Second
+)
+
+var (
+	minimumMajorGauge = metrics.NewRegisteredGauge("versioncheck/minimum/major", nil)
+	minimumMinorGauge = metrics.NewRegisteredGauge("versioncheck/minimum/minor", nil)
+	minimumPatchGauge = metrics.NewRegisteredGauge("versioncheck/minimum/patch", nil)
+	outdatedGauge     = metrics.NewRegisteredGauge("versioncheck/outdated", nil)
+	haltBlockGauge    = metrics.NewRegisteredGauge("versioncheck/haltblock", nil)
+)
+
+// Policy selects what the node does once it runs a version older than the
+// on-chain minimum.
+type Policy string
+
+const (
+	PolicyWarn           Policy = "warn"            // Keep running and log an error on every check
+	PolicyStopValidating Policy = "stop-validating" // Hand over to a replica through the replica state machine
+	PolicyShutdown       Policy = "shutdown"        // Close the node cleanly
+)
+
+// ParsePolicy converts a policy name into a Policy.
+func ParsePolicy(name string) (Policy, error) {
+	switch policy := Policy(name); policy {
+	case PolicyWarn, PolicyStopValidating, PolicyShutdown:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown version check policy %q (want %s, %s or %s)", name, PolicyWarn, PolicyStopValidating, PolicyShutdown)
+}
+
+// Config are the configuration parameters of the version check.
+type Config struct {
+	Policy      Policy // Action taken when the node falls behind the minimum version
+	GraceBlocks uint64 // Number of blocks to keep running after the minimum is raised above our version
+}
+
+// DefaultConfig is the default version check configuration.
+var DefaultConfig = Config{
+	Policy:      PolicyShutdown,
+	GraceBlocks: 0,
+}
+
+// Status is the outcome of the latest minimum version check.
+type Status struct {
+	Current   string `json:"current"`             // Version of the running client
+	Minimum   string `json:"minimum"`             // Minimum version required on-chain, empty if not read yet
+	Block     uint64 `json:"block"`               // Block the minimum version was read at
+	Outdated  bool   `json:"outdated"`            // Whether the running client is older than the minimum
+	HaltBlock uint64 `json:"haltBlock,omitempty"` // Block at which the policy is enforced if outdated
+	Policy    Policy `json:"policy"`              // Configured policy
+	Enforced  bool   `json:"enforced"`            // Whether the policy has been applied
+}
+
+// MinimumVersionEvent is posted whenever the on-chain minimum version changes,
+// the node falls behind or catches up with it, or the policy is enforced.
+type MinimumVersionEvent struct{ Status Status }
+
+// backend is the set of chain methods needed by the version check, implemented
+// by both the full and the light client.
+type backend interface {
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	CurrentHeader() *types.Header
+	StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error)
+	NewEVMRunner(*types.Header, vm.StateDB) vm.EVMRunner
+}
+
+// validator is the part of the istanbul engine used to stop validating.
+type validator interface {
+	IsPrimary() bool
+	SetStopValidatingBlock(blockNumber *big.Int) error
+	MakeReplica() error
+}
+
+// Service checks the on-chain minimum client version on new chain heads, at most
+// once every checkInterval, and applies the configured policy once the running
+// client is older.
+type Service struct {
+	config    Config
+	backend   backend
+	validator validator // nil if the engine can't validate
+	shutdown  func()
+
+	mu        sync.RWMutex
+	current   *params.VersionInfo
+	minimum   *params.VersionInfo
+	block     uint64
+	haltBlock uint64
+	outdated  bool
+	enforced  bool
+
+	lastCheck time.Time // Time of the latest check, only accessed by the loop
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates the version check service and registers it, along with its
+// celo_minimumClientVersion API, on the node.
+func New(stack *node.Node, backend backend, engine consensus.Engine, config Config) error {
+	s := newService(backend, engine, config, func() {
+		if err := stack.Close(); err != nil {
+			log.Error("Failed to close node", "err", err)
+		}
+	})
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "celo",
+		Version:   "1.0",
+		Service:   &PublicAPI{s},
+		Public:    true,
+	}})
+	stack.RegisterLifecycle(s)
+	return nil
+}
+
+func newService(backend backend, engine consensus.Engine, config Config, shutdown func()) *Service {
+	s := &Service{
+		config:   config,
+		backend:  backend,
+		shutdown: shutdown,
+		current:  params.CurrentVersionInfo,
+		quit:     make(chan struct{}),
+	}
+	if v, ok := engine.(validator); ok {
+		s.validator = v
+	}
+	return s
+}
+
+// Start implements node.Lifecycle, starting the version check loop.
+func (s *Service) Start() error {
+	s.wg.Add(1)
+	go s.loop()
+	log.Info("Started minimum client version check", "version", s.current, "policy", s.config.Policy, "graceBlocks", s.config.GraceBlocks)
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the version check loop.
+func (s *Service) Stop() error {
+	close(s.quit)
+	s.wg.Wait()
+	s.scope.Close()
+	return nil
+}
+
+// SubscribeMinimumVersionEvent registers a subscription of MinimumVersionEvent.
+func (s *Service) SubscribeMinimumVersionEvent(ch chan<- MinimumVersionEvent) event.Subscription {
+	return s.scope.Track(s.feed.Subscribe(ch))
+}
+
+// Status returns the outcome of the latest check.
+func (s *Service) Status() Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status()
+}
+
+func (s *Service) status() Status {
+	status := Status{
+		Current:   s.current.String(),
+		Block:     s.block,
+		Outdated:  s.outdated,
+		HaltBlock: s.haltBlock,
+		Policy:    s.config.Policy,
+		Enforced:  s.enforced,
+	}
+	if s.minimum != nil {
+		status.Minimum = s.minimum.String()
+	}
+	return status
+}
+
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	headCh := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	headSub := s.backend.SubscribeChainHeadEvent(headCh)
+	defer headSub.Unsubscribe()
+
+	s.checkHeader(s.backend.CurrentHeader())
+	for {
+		select {
+		case ev := <-headCh:
+			if s.due(ev.Block.NumberU64(), time.Now()) {
+				s.checkHeader(ev.Block.Header())
+			}
+		case <-headSub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// due returns whether the minimum version should be read at a new head with the
+// given number: once checkInterval passed since the latest check, or when the
+// halt block is reached, for the policy to be enforced on time.
+func (s *Service) due(number uint64, now time.Time) bool {
+	s.mu.RLock()
+	halting := s.outdated && !s.enforced && number >= s.haltBlock
+	s.mu.RUnlock()
+
+	return halting || now.Sub(s.lastCheck) >= checkInterval
+}
+
+// checkHeader reads the minimum version from the state at header.
+func (s *Service) checkHeader(header *types.Header) {
+	if header == nil {
+		return
+	}
+	s.lastCheck = time.Now()
+	statedb, _, err := s.backend.StateAndHeaderByNumberOrHash(context.Background(), rpc.BlockNumberOrHashWithHash(header.Hash(), true))
+	if err != nil {
+		log.Debug("Failed to open state for version check", "number", header.Number, "err", err)
+		return
+	}
+	s.check(header.Number.Uint64(), s.backend.NewEVMRunner(header, statedb))
+}
+
+// check compares the minimum version read through vmRunner at the given block
+// with ours, and schedules or applies the configured policy.
+func (s *Service) check(number uint64, vmRunner vm.EVMRunner) {
+	minimum, err := blockchain_parameters.GetMinimumVersion(vmRunner)
+	if err != nil {
+		log.Debug("Failed to read minimum client version", "number", number, "err", err)
+		return
+	}
+
+	s.mu.Lock()
+	changed := s.minimum == nil || s.minimum.Cmp(minimum) != 0
+	s.minimum = minimum
+	s.block = number
+
+	outdated := s.current.Cmp(minimum) < 0
+	switch {
+	case outdated && !s.outdated:
+		s.haltBlock = number + s.config.GraceBlocks
+		s.schedule()
+		changed = true
+	case !outdated && s.outdated:
+		log.Info("Client version meets the required minimum again", "current", s.current, "required", minimum)
+		if s.config.Policy == PolicyStopValidating && s.validator != nil {
+			log.Warn("Validating is not resumed automatically, use istanbul.startValidating once ready")
+		}
+		s.haltBlock = 0
+		s.enforced = false
+		changed = true
+	}
+	s.outdated = outdated
+
+	if outdated {
+		if !s.enforced && number >= s.haltBlock {
+			s.enforced = true
+			changed = true
+			s.enforce()
+		} else if s.config.Policy == PolicyWarn || !s.enforced {
+			log.Error("Client version older than required", "current", s.current, "required", minimum, "haltBlock", s.haltBlock, "policy", s.config.Policy)
+		}
+	}
+	status := s.status()
+	s.mu.Unlock()
+
+	s.updateMetrics(minimum, status)
+	if changed {
+		s.feed.Send(MinimumVersionEvent{Status: status})
+	}
+}
+
+// schedule prepares the policy for the halt block. For the stop-validating
+// policy the replica state machine is told to stop at the halt block, so a
+// hot-standby replica can take over at a known height.
+func (s *Service) schedule() {
+	log.Error("Client version older than required, scheduling policy", "current", s.current, "required", s.minimum, "haltBlock", s.haltBlock, "policy", s.config.Policy)
+	if s.config.Policy != PolicyStopValidating || s.validator == nil || !s.validator.IsPrimary() {
+		return
+	}
+	if err := s.validator.SetStopValidatingBlock(new(big.Int).SetUint64(s.haltBlock)); err != nil {
+		log.Warn("Failed to schedule stop validating block", "haltBlock", s.haltBlock, "err", err)
+	}
+}
+
+// enforce applies the configured policy.
+func (s *Service) enforce() {
+	log.Error("Client version older than required, enforcing policy", "current", s.current, "required", s.minimum, "policy", s.config.Policy)
+	switch s.config.Policy {
+	case PolicyStopValidating:
+		if s.validator == nil || !s.validator.IsPrimary() {
+			return
+		}
+		if err := s.validator.MakeReplica(); err != nil {
+			log.Error("Failed to stop validating", "err", err)
+		}
+	case PolicyShutdown:
+		// Closing the node stops this service, so it can't be done from the loop
+		go s.shutdown()
+	}
+}
+
+func (s *Service) updateMetrics(minimum *params.VersionInfo, status Status) {
+	minimumMajorGauge.Update(int64(minimum.Major))
+	minimumMinorGauge.Update(int64(minimum.Minor))
+	minimumPatchGauge.Update(int64(minimum.Patch))
+	if status.Outdated {
+		outdatedGauge.Update(1)
+	} else {
+		outdatedGauge.Update(0)
+	}
+	haltBlockGauge.Update(int64(status.HaltBlock))
+}
+
+// PublicAPI exposes the version check status over RPC.
+type PublicAPI struct {
+	s *Service
+}
+
+// MinimumClientVersion returns the on-chain minimum client version along with
+// the running version and the scheduled policy, if any.
+func (api *PublicAPI) MinimumClientVersion() Status {
+	return api.s.Status()
+}
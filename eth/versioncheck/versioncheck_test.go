@@ -0,0 +1,149 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package versioncheck
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/consensus"
+	"github.com/celo-org/celo-blockchain/contracts/testutil"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+type mockValidator struct {
+	consensus.Engine
+	primary   bool
+	stopBlock *big.Int
+}
+
+func (v *mockValidator) IsPrimary() bool { return v.primary }
+
+func (v *mockValidator) SetStopValidatingBlock(blockNumber *big.Int) error {
+	v.stopBlock = blockNumber
+	return nil
+}
+
+func (v *mockValidator) MakeReplica() error {
+	v.primary = false
+	return nil
+}
+
+func newerVersion() params.VersionInfo {
+	return params.VersionInfo{Major: params.CurrentVersionInfo.Major + 1}
+}
+
+func TestUpToDate(t *testing.T) {
+	celo := testutil.NewCeloMock()
+	celo.BlockchainParameters.MinimumVersion = *params.CurrentVersionInfo
+
+	shutdown := false
+	s := newService(nil, nil, DefaultConfig, func() { shutdown = true })
+	s.check(5, celo.Runner)
+
+	status := s.Status()
+	if status.Outdated || status.Enforced || shutdown {
+		t.Fatalf("up to date client considered outdated: %+v", status)
+	}
+	if status.Minimum != params.CurrentVersionInfo.String() || status.Block != 5 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestStopValidatingPolicy(t *testing.T) {
+	celo := testutil.NewCeloMock()
+	celo.BlockchainParameters.MinimumVersion = newerVersion()
+
+	validator := &mockValidator{primary: true}
+	s := newService(nil, validator, Config{Policy: PolicyStopValidating, GraceBlocks: 2}, nil)
+	events := make(chan MinimumVersionEvent, 10)
+	sub := s.SubscribeMinimumVersionEvent(events)
+	defer sub.Unsubscribe()
+
+	// Falling behind schedules the stop on the replica state machine
+	s.check(10, celo.Runner)
+	if validator.stopBlock == nil || validator.stopBlock.Uint64() != 12 {
+		t.Fatalf("stop validating block not scheduled: have %v, want 12", validator.stopBlock)
+	}
+	if ev := <-events; !ev.Status.Outdated || ev.Status.HaltBlock != 12 || ev.Status.Enforced {
+		t.Fatalf("unexpected event: %+v", ev.Status)
+	}
+
+	// The policy is only enforced at the halt block
+	s.check(11, celo.Runner)
+	if !validator.primary {
+		t.Fatalf("stopped validating before the halt block")
+	}
+	s.check(12, celo.Runner)
+	if validator.primary {
+		t.Fatalf("still validating after the halt block")
+	}
+	if ev := <-events; !ev.Status.Enforced {
+		t.Fatalf("unexpected event: %+v", ev.Status)
+	}
+
+	// Lowering the minimum clears the halt
+	celo.BlockchainParameters.MinimumVersion = *params.CurrentVersionInfo
+	s.check(13, celo.Runner)
+	if ev := <-events; ev.Status.Outdated || ev.Status.HaltBlock != 0 {
+		t.Fatalf("unexpected event: %+v", ev.Status)
+	}
+}
+
+func TestShutdownPolicy(t *testing.T) {
+	celo := testutil.NewCeloMock()
+	celo.BlockchainParameters.MinimumVersion = newerVersion()
+
+	done := make(chan struct{})
+	s := newService(nil, nil, Config{Policy: PolicyShutdown}, func() { close(done) })
+	s.check(3, celo.Runner)
+	<-done
+
+	if status := s.Status(); !status.Enforced || status.HaltBlock != 3 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestCheckThrottling(t *testing.T) {
+	celo := testutil.NewCeloMock()
+	celo.BlockchainParameters.MinimumVersion = newerVersion()
+
+	s := newService(nil, &mockValidator{primary: true}, Config{Policy: PolicyStopValidating, GraceBlocks: 5}, nil)
+	now := time.Now()
+	if !s.due(1, now) {
+		t.Fatalf("first check not due")
+	}
+	s.lastCheck = now
+	s.check(1, celo.Runner)
+
+	// New heads don't trigger a check until the interval passed
+	if s.due(2, now.Add(checkInterval/2)) {
+		t.Errorf("check due before the interval passed")
+	}
+	if !s.due(2, now.Add(checkInterval)) {
+		t.Errorf("check not due after the interval passed")
+	}
+	// Except at the halt block, for the policy to be enforced on time
+	if !s.due(6, now.Add(time.Second)) {
+		t.Errorf("check not due at the halt block")
+	}
+	s.check(6, celo.Runner)
+	if s.due(7, now.Add(time.Second)) {
+		t.Errorf("check due after the policy was enforced")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package celoclient provides an RPC client for the celo specific APIs.
+package celoclient
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/celo-org/celo-blockchain"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/core/types"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/ethclient"
+	"github.com/celo-org/celo-blockchain/event"
+	"github.com/celo-org/celo-blockchain/rpc"
+)
+
+// Client is a wrapper around rpc.Client that implements the celo specific
+// functionality, such as the istanbul consensus APIs.
+//
+// If you want to use the standardized Ethereum RPC functionality, use ethclient.Client instead.
+type Client struct {
+	c *rpc.Client
+}
+
+// New creates a client that uses the given RPC client.
+func New(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// ValidatorData is a validator of an istanbul snapshot.
+type ValidatorData struct {
+	Address      common.Address
+	BLSPublicKey blscrypto.SerializedPublicKey
+}
+
+// Snapshot is the istanbul validator set at a block.
+type Snapshot struct {
+	Epoch      uint64          `json:"epoch"`
+	Number     uint64          `json:"number"`
+	Hash       common.Hash     `json:"hash"`
+	Validators []ValidatorData `json:"validators"`
+}
+
+// RoundState is the state of the istanbul consensus of a validator.
+type RoundState struct {
+	State              string       `json:"state"`
+	Sequence           *big.Int     `json:"sequence"`
+	Round              *big.Int     `json:"round"`
+	DesiredRound       *big.Int     `json:"desiredRound"`
+	PendingRequestHash *common.Hash `json:"pendingRequestHash"`
+
+	ValidatorSet []common.Address `json:"validatorSet"`
+	Proposer     common.Address   `json:"proposer"`
+
+	Prepares      []common.Address `json:"prepares"`
+	Commits       []common.Address `json:"commits"`
+	ParentCommits []common.Address `json:"parentCommits"`
+
+	Preprepare          *istanbul.PreprepareSummary          `json:"preprepare"`
+	PreparedCertificate *istanbul.PreparedCertificateSummary `json:"preparedCertificate"`
+}
+
+// ReplicaState is the state of a validator running as a replica, that only
+// starts or stops participating in the consensus at the configured blocks.
+type ReplicaState struct {
+	State                string   `json:"state"`
+	IsPrimary            bool     `json:"isPrimary"`
+	StartValidatingBlock *big.Int `json:"startValidatingBlock"`
+	StopValidatingBlock  *big.Int `json:"stopValidatingBlock"`
+}
+
+// Validators returns the validators that must sign the block with the given
+// number. The block number can be nil, in which case the latest block is used.
+func (ec *Client) Validators(ctx context.Context, blockNumber *big.Int) ([]common.Address, error) {
+	var result []common.Address
+	err := ec.c.CallContext(ctx, &result, "istanbul_getValidators", toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// ValidatorsBLSPublicKeys returns the BLS public keys of the validators that must
+// sign the block with the given number, in the same order as Validators.
+func (ec *Client) ValidatorsBLSPublicKeys(ctx context.Context, blockNumber *big.Int) ([]blscrypto.SerializedPublicKey, error) {
+	var result []blscrypto.SerializedPublicKey
+	err := ec.c.CallContext(ctx, &result, "istanbul_getValidatorsBLSPublicKeys", toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// Snapshot returns the istanbul snapshot at the block with the given number.
+func (ec *Client) Snapshot(ctx context.Context, blockNumber *big.Int) (*Snapshot, error) {
+	var result *Snapshot
+	err := ec.c.CallContext(ctx, &result, "istanbul_getSnapshot", toBlockNumArg(blockNumber))
+	if err == nil && result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, err
+}
+
+// EpochSize returns the number of blocks in an epoch.
+func (ec *Client) EpochSize(ctx context.Context) (uint64, error) {
+	snapshot, err := ec.Snapshot(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return snapshot.Epoch, nil
+}
+
+// Proposer returns the proposer of the block with the given number in the
+// given round.
+func (ec *Client) Proposer(ctx context.Context, blockNumber *big.Int, round uint64) (common.Address, error) {
+	var result common.Address
+	err := ec.c.CallContext(ctx, &result, "istanbul_getProposer", toBlockNumArg(blockNumber), round)
+	return result, err
+}
+
+// LookbackWindow returns the number of blocks over which the uptime of the
+// validators is measured at the block with the given number.
+func (ec *Client) LookbackWindow(ctx context.Context, blockNumber *big.Int) (uint64, error) {
+	var result uint64
+	err := ec.c.CallContext(ctx, &result, "istanbul_getLookbackWindow", toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// RoundState returns the current state of the istanbul consensus of the node,
+// which must be validating.
+func (ec *Client) RoundState(ctx context.Context) (*RoundState, error) {
+	var result *RoundState
+	err := ec.c.CallContext(ctx, &result, "istanbul_getCurrentRoundState")
+	return result, err
+}
+
+// ReplicaState returns the current replica state of the node.
+func (ec *Client) ReplicaState(ctx context.Context) (*ReplicaState, error) {
+	var result *ReplicaState
+	err := ec.c.CallContext(ctx, &result, "istanbul_getCurrentReplicaState")
+	return result, err
+}
+
+// IsValidating returns whether the node participates in the consensus.
+func (ec *Client) IsValidating(ctx context.Context) (bool, error) {
+	var result bool
+	err := ec.c.CallContext(ctx, &result, "istanbul_isValidating")
+	return result, err
+}
+
+// GasPriceMinimum returns the gas price minimum in the given fee currency at the
+// block with the given number. A nil fee currency stands for CELO.
+func (ec *Client) GasPriceMinimum(ctx context.Context, feeCurrency *common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	if err := ec.c.CallContext(ctx, &result, "celo_getGasPriceMinimum", feeCurrency, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// BlockReceipt returns the receipt of the system calls of the block with the
+// given hash, such as the epoch rewards distribution.
+func (ec *Client) BlockReceipt(ctx context.Context, blockHash common.Hash) (*types.Receipt, error) {
+	var r *types.Receipt
+	err := ec.c.CallContext(ctx, &r, "eth_getBlockReceipt", blockHash)
+	if err == nil && r == nil {
+		return nil, ethereum.NotFound
+	}
+	return r, err
+}
+
+// SubscribeNewEpoch subscribes to notifications about the last block of every
+// epoch, in which the epoch rewards are distributed and the validators of the
+// next epoch are elected.
+func (ec *Client) SubscribeNewEpoch(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	epochSize, err := ec.EpochSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(chan *types.Header)
+	headSub, err := ethclient.NewClient(ec.c).SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer headSub.Unsubscribe()
+		for {
+			select {
+			case head := <-heads:
+				if !istanbul.IsLastBlockOfEpoch(head.Number.Uint64(), epochSize) {
+					continue
+				}
+				select {
+				case ch <- head:
+				case err := <-headSub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-headSub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+func toBlockNumArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	pending := big.NewInt(-1)
+	if number.Cmp(pending) == 0 {
+		return "pending"
+	}
+	return hexutil.EncodeBig(number)
+}
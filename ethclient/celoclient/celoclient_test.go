@@ -0,0 +1,228 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package celoclient
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul/core"
+	"github.com/celo-org/celo-blockchain/core/types"
+	blscrypto "github.com/celo-org/celo-blockchain/crypto/bls"
+	"github.com/celo-org/celo-blockchain/rpc"
+)
+
+const testEpochSize = 3
+
+var (
+	testValidators = []istanbul.ValidatorDataWithBLSKeyCache{
+		{Address: common.HexToAddress("0x01"), BLSPublicKey: blscrypto.SerializedPublicKey{1}},
+		{Address: common.HexToAddress("0x02"), BLSPublicKey: blscrypto.SerializedPublicKey{2}},
+	}
+	testCurrency = common.HexToAddress("0xd008")
+)
+
+// istanbulAPI serves the results of the istanbul API with the types it uses.
+type istanbulAPI struct{}
+
+func (api *istanbulAPI) GetSnapshot(number *rpc.BlockNumber) (interface{}, error) {
+	return &struct {
+		Epoch      uint64                                  `json:"epoch"`
+		Number     uint64                                  `json:"number"`
+		Hash       common.Hash                             `json:"hash"`
+		Validators []istanbul.ValidatorDataWithBLSKeyCache `json:"validators"`
+	}{testEpochSize, uint64(number.Int64()), common.HexToHash("0xaa"), testValidators}, nil
+}
+
+func (api *istanbulAPI) GetValidators(number *rpc.BlockNumber) ([]common.Address, error) {
+	return []common.Address{testValidators[0].Address, testValidators[1].Address}, nil
+}
+
+func (api *istanbulAPI) GetValidatorsBLSPublicKeys(number *rpc.BlockNumber) ([]blscrypto.SerializedPublicKey, error) {
+	return []blscrypto.SerializedPublicKey{testValidators[0].BLSPublicKey, testValidators[1].BLSPublicKey}, nil
+}
+
+func (api *istanbulAPI) GetProposer(sequence *rpc.BlockNumber, round *uint64) (common.Address, error) {
+	return testValidators[int(sequence.Int64()+int64(*round))%len(testValidators)].Address, nil
+}
+
+func (api *istanbulAPI) GetLookbackWindow(number *rpc.BlockNumber) (uint64, error) {
+	return 12, nil
+}
+
+func (api *istanbulAPI) GetCurrentRoundState() (*core.RoundStateSummary, error) {
+	return &core.RoundStateSummary{
+		State:        "Waiting for new round",
+		Sequence:     big.NewInt(10),
+		Round:        big.NewInt(1),
+		DesiredRound: big.NewInt(2),
+		ValidatorSet: []common.Address{testValidators[0].Address, testValidators[1].Address},
+		Proposer:     testValidators[1].Address,
+		Prepares:     []common.Address{testValidators[0].Address},
+		Preprepare: &istanbul.PreprepareSummary{
+			View:         &istanbul.View{Round: big.NewInt(1), Sequence: big.NewInt(10)},
+			ProposalHash: common.HexToHash("0xbb"),
+		},
+	}, nil
+}
+
+// celoAPI serves the celo API.
+type celoAPI struct{}
+
+func (api *celoAPI) GetGasPriceMinimum(feeCurrency *common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	if feeCurrency != nil && *feeCurrency == testCurrency {
+		return (*hexutil.Big)(big.NewInt(500)), nil
+	}
+	return (*hexutil.Big)(big.NewInt(100)), nil
+}
+
+// ethAPI notifies subscribers of a chain of empty headers.
+type ethAPI struct{}
+
+func (api *ethAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+	go func() {
+		for number := int64(1); number <= 3*testEpochSize; number++ {
+			notifier.Notify(sub.ID, &types.Header{Number: big.NewInt(number)})
+		}
+	}()
+	return sub, nil
+}
+
+func newTestClient(t *testing.T) *Client {
+	server := rpc.NewServer()
+	for name, service := range map[string]interface{}{"istanbul": new(istanbulAPI), "celo": new(celoAPI), "eth": new(ethAPI)} {
+		if err := server.RegisterName(name, service); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+	return New(client)
+}
+
+func TestIstanbulAPI(t *testing.T) {
+	ec := newTestClient(t)
+	ctx := context.Background()
+
+	snapshot, err := ec.Snapshot(ctx, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Snapshot{
+		Epoch:  testEpochSize,
+		Number: 5,
+		Hash:   common.HexToHash("0xaa"),
+		Validators: []ValidatorData{
+			{testValidators[0].Address, testValidators[0].BLSPublicKey},
+			{testValidators[1].Address, testValidators[1].BLSPublicKey},
+		},
+	}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Errorf("snapshot mismatch: have %+v, want %+v", snapshot, want)
+	}
+
+	validators, err := ec.Validators(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ec.ValidatorsBLSPublicKeys(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range want.Validators {
+		if validators[i] != v.Address || keys[i] != v.BLSPublicKey {
+			t.Errorf("validator %d mismatch: have %v %v, want %v %v", i, validators[i], keys[i], v.Address, v.BLSPublicKey)
+		}
+	}
+
+	proposer, err := ec.Proposer(ctx, big.NewInt(4), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposer != testValidators[1].Address {
+		t.Errorf("proposer mismatch: have %v, want %v", proposer, testValidators[1].Address)
+	}
+
+	window, err := ec.LookbackWindow(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if window != 12 {
+		t.Errorf("lookback window mismatch: have %d, want 12", window)
+	}
+
+	roundState, err := ec.RoundState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundState.Sequence.Int64() != 10 || roundState.DesiredRound.Int64() != 2 || roundState.Proposer != testValidators[1].Address {
+		t.Errorf("round state mismatch: %+v", roundState)
+	}
+	if roundState.Preprepare == nil || roundState.Preprepare.View.Round.Int64() != 1 {
+		t.Errorf("preprepare mismatch: %+v", roundState.Preprepare)
+	}
+}
+
+func TestGasPriceMinimum(t *testing.T) {
+	ec := newTestClient(t)
+
+	for _, tt := range []struct {
+		currency *common.Address
+		want     int64
+	}{{nil, 100}, {&testCurrency, 500}} {
+		gasPriceMinimum, err := ec.GasPriceMinimum(context.Background(), tt.currency, big.NewInt(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gasPriceMinimum.Int64() != tt.want {
+			t.Errorf("gas price minimum mismatch for %v: have %v, want %d", tt.currency, gasPriceMinimum, tt.want)
+		}
+	}
+}
+
+func TestSubscribeNewEpoch(t *testing.T) {
+	ec := newTestClient(t)
+
+	ch := make(chan *types.Header)
+	sub, err := ec.SubscribeNewEpoch(context.Background(), ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	for _, want := range []uint64{testEpochSize, 2 * testEpochSize, 3 * testEpochSize} {
+		select {
+		case head := <-ch:
+			if head.Number.Uint64() != want {
+				t.Fatalf("wrong epoch block: have %d, want %d", head.Number, want)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for block %d", want)
+		}
+	}
+}
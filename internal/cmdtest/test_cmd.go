@@ -188,6 +188,15 @@ func (tt *TestCmd) ExpectExit() {
 	}
 }
 
+// Output reads all output from stdout, and returns the data.
+func (tt *TestCmd) Output() []byte {
+	var output []byte
+	tt.withKillTimeout(func() {
+		output, _ = ioutil.ReadAll(tt.stdout)
+	})
+	return output
+}
+
 func (tt *TestCmd) WaitExit() {
 	tt.Err = tt.cmd.Wait()
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/common/hexutil"
 	"github.com/celo-org/celo-blockchain/common/math"
+	"github.com/celo-org/celo-blockchain/contracts/gasprice_minimum"
 	"github.com/celo-org/celo-blockchain/core"
 	"github.com/celo-org/celo-blockchain/core/state"
 	"github.com/celo-org/celo-blockchain/core/types"
@@ -128,6 +129,61 @@ func (s *PublicEthereumAPI) Syncing() (interface{}, error) {
 	}, nil
 }
 
+// maxProjectionBlocks caps the number of blocks celo_projectGasPriceMinimum simulates,
+// as every block costs a contract execution.
+const maxProjectionBlocks = 1000
+
+// PublicCeloAPI provides an API to access Celo specific information.
+// It offers only methods that operate on public data that is freely available to anyone.
+type PublicCeloAPI struct {
+	b Backend
+}
+
+// NewPublicCeloAPI creates a new Celo protocol API.
+func NewPublicCeloAPI(b Backend) *PublicCeloAPI {
+	return &PublicCeloAPI{b}
+}
+
+// ProjectGasPriceMinimum returns the gas price minimum in the given fee currency for each of the
+// next blocksAhead blocks, assuming every one of them uses assumedUtilization (between 0 and 1)
+// of the block gas limit. The GasPriceMinimum contract's update rule is executed on a copy of
+// the latest state, so wallets can pick a fee cap that stays valid while the minimum rises.
+func (s *PublicCeloAPI) ProjectGasPriceMinimum(ctx context.Context, feeCurrency *common.Address, blocksAhead hexutil.Uint64, assumedUtilization float64) ([]*hexutil.Big, error) {
+	if blocksAhead == 0 || blocksAhead > maxProjectionBlocks {
+		return nil, fmt.Errorf("blocksAhead must be between 1 and %d", maxProjectionBlocks)
+	}
+	if assumedUtilization < 0 || assumedUtilization > 1 {
+		return nil, errors.New("assumedUtilization must be between 0 and 1")
+	}
+	statedb, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	vmRunner := s.b.NewEVMRunner(header, statedb.Copy())
+	projection, err := gasprice_minimum.ProjectGasPriceMinimum(vmRunner, feeCurrency, uint64(blocksAhead), assumedUtilization)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*hexutil.Big, len(projection))
+	for i, gasPriceMinimum := range projection {
+		result[i] = (*hexutil.Big)(gasPriceMinimum)
+	}
+	return result, nil
+}
+
+// GetGasPriceMinimum returns the gas price minimum in the given fee currency at the given block.
+func (s *PublicCeloAPI) GetGasPriceMinimum(ctx context.Context, feeCurrency *common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	header, err := s.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	gasPriceMinimum, err := s.b.GasPriceMinimumForHeader(ctx, feeCurrency, header)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(gasPriceMinimum), nil
+}
+
 // PublicTxPoolAPI offers and API for the transaction pool. It only operates on data that is non confidential.
 type PublicTxPoolAPI struct {
 	b Backend
@@ -0,0 +1,258 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package web3ext
+
+// Helpers are the console helpers of the modules, which are loaded after all the
+// web3.js extensions of the node's modules. Unlike the extensions, they implement
+// multi-step procedures on top of the raw RPCs.
+var Helpers = map[string]string{
+	"istanbul": IstanbulHelpersJs,
+}
+
+const IstanbulHelpersJs = `
+(function() {
+	var istanbul = web3.istanbul;
+
+	// tryCall returns the result of fn, or undefined if the call fails, such as
+	// the proxy RPCs on a node that is not proxied.
+	function tryCall(fn) {
+		try {
+			return fn();
+		} catch (err) {
+			return undefined;
+		}
+	}
+
+	function contains(addresses, address) {
+		address = address.toLowerCase();
+		for (var i = 0; i < addresses.length; i++) {
+			if (addresses[i].toLowerCase() == address) {
+				return true;
+			}
+		}
+		return false;
+	}
+
+	function epochNumber(block, epochSize) {
+		return Math.ceil(block / epochSize);
+	}
+
+	function epochFirstBlock(epoch, epochSize) {
+		return (epoch - 1) * epochSize + 1;
+	}
+
+	// handover schedules the handover of the validating key between a primary
+	// and a replica at the given block: the primary stops validating before the
+	// block and the replica starts validating at the block. It must be run on
+	// both nodes with the same block.
+	istanbul.handover = function(block) {
+		if (typeof block != 'number' || block % 1 != 0 || block <= 0) {
+			throw new Error('handover block must be a positive block number');
+		}
+		var head = web3.eth.blockNumber;
+		if (block <= head + 1) {
+			throw new Error('handover block ' + block + ' is not in the future, head is at ' + head);
+		}
+		var state = istanbul.replicaState;
+		if (state.state == 'Not a validator') {
+			throw new Error('this node is not configured as a validator');
+		}
+		var epochSize = istanbul.getSnapshot('latest').epoch;
+		if (epochFirstBlock(epochNumber(block, epochSize), epochSize) != block) {
+			console.log('warning: block ' + block + ' is not the first block of an epoch, the validator set changes at epoch boundaries');
+		}
+		if (state.isPrimary) {
+			console.log('primary: stopping validating at block ' + block + ', the replica must start at the same block');
+			istanbul.stopAtBlock(block);
+		} else {
+			console.log('replica: starting validating at block ' + block + ', the primary must stop at the same block');
+			istanbul.startAtBlock(block);
+		}
+		return istanbul.replicaState;
+	};
+
+	// proxyStatus reports the proxies of a proxied validator, or the validators
+	// proxied by a proxy, and the problems with their links.
+	istanbul.proxyStatus = function() {
+		var status = {role: 'none', proxies: [], problems: []};
+		var proxies = tryCall(function() { return istanbul.proxies; });
+		if (proxies !== undefined) {
+			status.role = 'proxied validator';
+			if (proxies.length == 0) {
+				status.problems.push('no proxy is configured');
+			}
+			for (var i = 0; i < proxies.length; i++) {
+				var p = proxies[i];
+				status.proxies.push({
+					internal: p.internalEnodeUrl,
+					external: p.externalEnodeUrl,
+					peered: p.isPeered,
+					validators: p.validators ? p.validators.length : 0
+				});
+				if (!p.isPeered) {
+					var since = p.disconnectedTimestamp > 0 ? ' since ' + new Date(p.disconnectedTimestamp * 1000).toISOString() : '';
+					status.problems.push('proxy ' + p.internalEnodeUrl + ' is not peered' + since);
+				} else if (!p.validators || p.validators.length == 0) {
+					status.problems.push('proxy ' + p.internalEnodeUrl + ' has no validators assigned');
+				}
+			}
+			return status;
+		}
+		var validators = tryCall(function() { return istanbul.proxiedValidators; });
+		if (validators !== undefined) {
+			status.role = 'proxy';
+			for (var i = 0; i < validators.length; i++) {
+				var v = validators[i];
+				status.proxies.push({validator: v.address, enode: v.enodeURL, peered: v.isPeered});
+				if (!v.isPeered) {
+					status.problems.push('proxied validator ' + v.address + ' is not peered');
+				}
+			}
+			if (validators.length == 0) {
+				status.problems.push('no proxied validator is connected');
+			}
+		}
+		return status;
+	};
+
+	// addProxyChecked adds a proxy to a proxied validator, checking that the
+	// enode URLs are well formed and that the proxy is not already added.
+	istanbul.addProxyChecked = function(url, externalUrl) {
+		if (!/^enode:\/\/[0-9a-fA-F]{128}@/.test(url) || !/^enode:\/\/[0-9a-fA-F]{128}@/.test(externalUrl)) {
+			throw new Error('proxy URLs must be enode URLs');
+		}
+		var proxies = istanbul.proxies;
+		for (var i = 0; i < proxies.length; i++) {
+			if (proxies[i].internalEnodeUrl.split('@')[0] == url.split('@')[0]) {
+				throw new Error('proxy ' + url + ' is already added');
+			}
+		}
+		istanbul.addProxy(url, externalUrl);
+		return istanbul.proxyStatus();
+	};
+
+	// removeProxyChecked removes a proxy from a proxied validator, refusing to
+	// remove the last peered proxy unless forced.
+	istanbul.removeProxyChecked = function(url, force) {
+		var proxies = istanbul.proxies;
+		var found = false, peeredOthers = 0;
+		for (var i = 0; i < proxies.length; i++) {
+			if (proxies[i].internalEnodeUrl.split('@')[0] == url.split('@')[0]) {
+				found = true;
+			} else if (proxies[i].isPeered) {
+				peeredOthers++;
+			}
+		}
+		if (!found) {
+			throw new Error('proxy ' + url + ' is not added');
+		}
+		if (peeredOthers == 0 && !force) {
+			throw new Error('proxy ' + url + ' is the last peered proxy, the validator would be disconnected (pass true to force)');
+		}
+		istanbul.removeProxy(url);
+		return istanbul.proxyStatus();
+	};
+
+	// whyNotSigning cross-checks the validating state of the node, its membership
+	// in the validator set, its proxies and its recent seals, and returns the
+	// reasons it is not signing blocks.
+	istanbul.whyNotSigning = function(window) {
+		window = window || 100;
+		var reasons = [];
+		var address = web3.eth.validator();
+		var syncing = web3.eth.syncing;
+		if (syncing) {
+			reasons.push('node is syncing: block ' + syncing.currentBlock + ' of ' + syncing.highestBlock);
+		}
+		var state = istanbul.replicaState;
+		if (state.state == 'Not a validator') {
+			reasons.push('node is not configured as a validator');
+			return reasons;
+		}
+		if (!istanbul.validating) {
+			var reason = 'node is not validating (' + state.state + ')';
+			if (state.startValidatingBlock) {
+				reason += ', it starts at block ' + state.startValidatingBlock;
+			}
+			reasons.push(reason);
+		} else if (state.stopValidatingBlock) {
+			reasons.push('node stops validating at block ' + state.stopValidatingBlock);
+		}
+		if (!contains(istanbul.getValidators('latest'), address)) {
+			reasons.push('validator ' + address + ' is not in the validator set of the current epoch');
+		}
+		var proxies = istanbul.proxyStatus();
+		for (var i = 0; i < proxies.problems.length; i++) {
+			reasons.push(proxies.problems[i]);
+		}
+		var seals = istanbul.getSealParticipation(window);
+		if (seals.elected > 0 && seals.signed == 0) {
+			reasons.push('none of the last ' + seals.elected + ' parent seals the validator was elected for include its signature');
+		} else if (seals.signed < seals.elected) {
+			reasons.push('missing from ' + (seals.elected - seals.signed) + ' of the last ' + seals.elected + ' parent seals, last signed block ' + seals.lastSigned);
+		}
+		var round = tryCall(function() { return istanbul.currentRoundState; });
+		if (round && round.desiredRound > round.round) {
+			reasons.push('consensus is changing round at sequence ' + round.sequence + ': round ' + round.round + ', desired round ' + round.desiredRound);
+		}
+		return reasons;
+	};
+
+	// epochSummary returns the summary of the given epoch, or of the current one:
+	// its blocks, progress, and the changes to the validator set.
+	istanbul.epochSummary = function(epoch) {
+		var head = web3.eth.blockNumber;
+		var epochSize = istanbul.getSnapshot('latest').epoch;
+		var current = epochNumber(head, epochSize);
+		if (current == 0) {
+			throw new Error('the chain is at the genesis block, no epoch has started');
+		}
+		if (epoch === undefined) {
+			epoch = current;
+		}
+		if (epoch < 1 || epoch > current) {
+			throw new Error('epoch ' + epoch + ' is not in [1, ' + current + ']');
+		}
+		var first = epochFirstBlock(epoch, epochSize);
+		var last = epoch * epochSize;
+		var validators = istanbul.getValidators(first);
+		var summary = {
+			epoch: epoch,
+			firstBlock: first,
+			lastBlock: last,
+			progress: (Math.min(head, last) - first + 1) + '/' + epochSize,
+			validators: validators.length,
+			elected: [],
+			unelected: []
+		};
+		if (epoch > 1) {
+			var previous = istanbul.getValidators(first - epochSize);
+			for (var i = 0; i < validators.length; i++) {
+				if (!contains(previous, validators[i])) {
+					summary.elected.push(validators[i]);
+				}
+			}
+			for (var i = 0; i < previous.length; i++) {
+				if (!contains(validators, previous[i])) {
+					summary.unelected.push(previous[i]);
+				}
+			}
+		}
+		return summary;
+	};
+})();
+`
@@ -19,6 +19,7 @@ package web3ext
 
 var Modules = map[string]string{
 	"admin":    AdminJs,
+	"celo":     CeloJs,
 	"debug":    DebugJs,
 	"eth":      EthJs,
 	"istanbul": Istanbul_JS,
@@ -121,6 +122,40 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'peerPurposes',
+			getter: 'admin_peerPurposes'
+		}),
+	]
+});
+`
+
+const CeloJs = `
+web3._extend({
+	property: 'celo',
+	methods: [
+		new web3._extend.Method({
+			name: 'projectGasPriceMinimum',
+			call: 'celo_projectGasPriceMinimum',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal, null],
+			outputFormatter: function(projection) {
+				return projection.map(web3._extend.utils.toBigNumber);
+			}
+		}),
+		new web3._extend.Method({
+			name: 'getGasPriceMinimum',
+			call: 'celo_getGasPriceMinimum',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+	],
+	properties: [
+		new web3._extend.Property({
+			name: 'minimumClientVersion',
+			getter: 'celo_minimumClientVersion'
+		}),
 	]
 });
 `
@@ -360,6 +395,16 @@ web3._extend({
 			params: 2,
 			inputFormatter:[null, null],
 		}),
+		new web3._extend.Method({
+			name: 'pruneState',
+			call: 'debug_pruneState',
+			params: 0,
+		}),
+		new web3._extend.Method({
+			name: 'statePruningProgress',
+			call: 'debug_statePruningProgress',
+			params: 0,
+		}),
 		new web3._extend.Method({
 			name: 'freezeClient',
 			call: 'debug_freezeClient',
@@ -696,6 +741,11 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getSealParticipation',
+			call: 'istanbul_getSealParticipation',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'addProxy',
 			call: 'istanbul_addProxy',
@@ -740,6 +790,10 @@ web3._extend({
 			name: 'currentRoundState',
 			getter: 'istanbul_getCurrentRoundState',
 		}),
+		new web3._extend.Property({
+			name: 'blockProductionStats',
+			getter: 'istanbul_getBlockProductionStats',
+		}),
 		new web3._extend.Property({
 			name: 'proxies',
 			getter: 'istanbul_getProxiesInfo',
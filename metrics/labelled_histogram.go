@@ -0,0 +1,166 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// LabelledHistograms count float64 observations into fixed buckets, keeping a
+// separate series for every value of a label. Unlike Histograms they don't
+// sample, so they map directly onto Prometheus histograms.
+type LabelledHistogram interface {
+	Label() string
+	Buckets() []float64
+	Observe(labelValue string, v float64)
+	Series() []HistogramSeries
+	Snapshot() LabelledHistogram
+}
+
+// HistogramSeries holds the observations of a LabelledHistogram for one label value.
+type HistogramSeries struct {
+	LabelValue string
+	Counts     []uint64 // Cumulative number of observations less than or equal to each bucket
+	Count      uint64   // Total number of observations
+	Sum        float64  // Sum of all the observations
+}
+
+// GetOrRegisterLabelledHistogram returns an existing LabelledHistogram or
+// constructs and registers a new StandardLabelledHistogram.
+func GetOrRegisterLabelledHistogram(name string, r Registry, label string, buckets []float64) LabelledHistogram {
+	if nil == r {
+		r = DefaultRegistry
+	}
+	return r.GetOrRegister(name, func() LabelledHistogram { return NewLabelledHistogram(label, buckets) }).(LabelledHistogram)
+}
+
+// NewLabelledHistogram constructs a new StandardLabelledHistogram. The buckets
+// are the upper bounds of each bucket, in increasing order.
+func NewLabelledHistogram(label string, buckets []float64) LabelledHistogram {
+	if !Enabled {
+		return NilLabelledHistogram{}
+	}
+	return &StandardLabelledHistogram{
+		label:   label,
+		buckets: append([]float64{}, buckets...),
+		series:  make(map[string]*HistogramSeries),
+	}
+}
+
+// NewRegisteredLabelledHistogram constructs and registers a new StandardLabelledHistogram.
+func NewRegisteredLabelledHistogram(name string, r Registry, label string, buckets []float64) LabelledHistogram {
+	c := NewLabelledHistogram(label, buckets)
+	if nil == r {
+		r = DefaultRegistry
+	}
+	r.Register(name, c)
+	return c
+}
+
+// LabelledHistogramSnapshot is a read-only copy of another LabelledHistogram.
+type LabelledHistogramSnapshot struct {
+	label   string
+	buckets []float64
+	series  []HistogramSeries
+}
+
+// Label returns the name of the label the series are split by.
+func (h *LabelledHistogramSnapshot) Label() string { return h.label }
+
+// Buckets returns the upper bounds of the buckets.
+func (h *LabelledHistogramSnapshot) Buckets() []float64 { return h.buckets }
+
+// Observe panics.
+func (*LabelledHistogramSnapshot) Observe(string, float64) {
+	panic("Observe called on a LabelledHistogramSnapshot")
+}
+
+// Series returns the series at the time the snapshot was taken, sorted by label value.
+func (h *LabelledHistogramSnapshot) Series() []HistogramSeries { return h.series }
+
+// Snapshot returns the snapshot.
+func (h *LabelledHistogramSnapshot) Snapshot() LabelledHistogram { return h }
+
+// NilLabelledHistogram is a no-op LabelledHistogram.
+type NilLabelledHistogram struct{}
+
+// Label is a no-op.
+func (NilLabelledHistogram) Label() string { return "" }
+
+// Buckets is a no-op.
+func (NilLabelledHistogram) Buckets() []float64 { return nil }
+
+// Observe is a no-op.
+func (NilLabelledHistogram) Observe(string, float64) {}
+
+// Series is a no-op.
+func (NilLabelledHistogram) Series() []HistogramSeries { return nil }
+
+// Snapshot is a no-op.
+func (NilLabelledHistogram) Snapshot() LabelledHistogram { return NilLabelledHistogram{} }
+
+// StandardLabelledHistogram is the standard implementation of a LabelledHistogram.
+type StandardLabelledHistogram struct {
+	label   string
+	buckets []float64
+	series  map[string]*HistogramSeries
+	mutex   sync.Mutex
+}
+
+// Label returns the name of the label the series are split by.
+func (h *StandardLabelledHistogram) Label() string { return h.label }
+
+// Buckets returns the upper bounds of the buckets.
+func (h *StandardLabelledHistogram) Buckets() []float64 { return h.buckets }
+
+// Observe records an observation in the series of the label value.
+func (h *StandardLabelledHistogram) Observe(labelValue string, v float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	s, ok := h.series[labelValue]
+	if !ok {
+		s = &HistogramSeries{LabelValue: labelValue, Counts: make([]uint64, len(h.buckets))}
+		h.series[labelValue] = s
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.Counts[i]++
+		}
+	}
+	s.Count++
+	s.Sum += v
+}
+
+// Series returns a copy of every series, sorted by label value.
+func (h *StandardLabelledHistogram) Series() []HistogramSeries {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	series := make([]HistogramSeries, 0, len(h.series))
+	for _, s := range h.series {
+		cpy := *s
+		cpy.Counts = append([]uint64{}, s.Counts...)
+		series = append(series, cpy)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].LabelValue < series[j].LabelValue })
+	return series
+}
+
+// Snapshot returns a read-only copy of the histogram.
+func (h *StandardLabelledHistogram) Snapshot() LabelledHistogram {
+	return &LabelledHistogramSnapshot{label: h.label, buckets: h.buckets, series: h.Series()}
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetOrRegisterLabelledHistogram(t *testing.T) {
+	r := NewRegistry()
+	NewRegisteredLabelledHistogram("foo", r, "proposer", []float64{1, 2}).Observe("true", 1)
+	if h := GetOrRegisterLabelledHistogram("foo", r, "proposer", []float64{1, 2}); len(h.Series()) != 1 {
+		t.Fatal(h)
+	}
+}
+
+func TestLabelledHistogram(t *testing.T) {
+	h := NewLabelledHistogram("proposer", []float64{0.5, 1, 2})
+	for _, v := range []float64{0.1, 0.5, 1.5, 3} {
+		h.Observe("false", v)
+	}
+	h.Observe("true", 1)
+
+	snapshot := h.Snapshot()
+	h.Observe("true", 0.1)
+
+	want := []HistogramSeries{
+		{LabelValue: "false", Counts: []uint64{2, 2, 3}, Count: 4, Sum: 5.1},
+		{LabelValue: "true", Counts: []uint64{0, 1, 1}, Count: 1, Sum: 1},
+	}
+	if series := snapshot.Series(); !reflect.DeepEqual(series, want) {
+		t.Errorf("series mismatch:\nhave %+v\nwant %+v", series, want)
+	}
+	if label := snapshot.Label(); label != "proposer" {
+		t.Errorf("label mismatch: have %s, want proposer", label)
+	}
+}
@@ -29,8 +29,11 @@ var (
 	typeGaugeTpl           = "# TYPE %s gauge\n"
 	typeCounterTpl         = "# TYPE %s counter\n"
 	typeSummaryTpl         = "# TYPE %s summary\n"
+	typeHistogramTpl       = "# TYPE %s histogram\n"
 	keyValueTpl            = "%s %v\n\n"
 	keyQuantileTagValueTpl = "%s {quantile=\"%s\"} %v\n"
+	keyBucketTagValueTpl   = "%s_bucket{%s=\"%s\",le=\"%s\"} %v\n"
+	keyLabelTagValueTpl    = "%s{%s=\"%s\"} %v\n"
 )
 
 // collector is a collection of byte buffers that aggregate Prometheus reports
@@ -98,6 +101,24 @@ func (c *collector) addResettingTimer(name string, m metrics.ResettingTimer) {
 	c.buff.WriteRune('\n')
 }
 
+func (c *collector) addLabelledHistogram(name string, m metrics.LabelledHistogram) {
+	series := m.Series()
+	if len(series) == 0 {
+		return
+	}
+	name = mutateKey(name)
+	c.buff.WriteString(fmt.Sprintf(typeHistogramTpl, name))
+	for _, s := range series {
+		for i, bound := range m.Buckets() {
+			c.buff.WriteString(fmt.Sprintf(keyBucketTagValueTpl, name, m.Label(), s.LabelValue, strconv.FormatFloat(bound, 'f', -1, 64), s.Counts[i]))
+		}
+		c.buff.WriteString(fmt.Sprintf(keyBucketTagValueTpl, name, m.Label(), s.LabelValue, "+Inf", s.Count))
+		c.buff.WriteString(fmt.Sprintf(keyLabelTagValueTpl, name+"_sum", m.Label(), s.LabelValue, s.Sum))
+		c.buff.WriteString(fmt.Sprintf(keyLabelTagValueTpl, name+"_count", m.Label(), s.LabelValue, s.Count))
+	}
+	c.buff.WriteRune('\n')
+}
+
 func (c *collector) writeGaugeCounter(name string, value interface{}) {
 	name = mutateKey(name)
 	c.buff.WriteString(fmt.Sprintf(typeGaugeTpl, name))
@@ -108,3 +108,35 @@ test_resetting_timer {quantile="0.99"} 120000000
 		t.Fatal("unexpected collector output")
 	}
 }
+
+func TestCollectorLabelledHistogram(t *testing.T) {
+	c := newCollector()
+
+	histogram := metrics.NewLabelledHistogram("proposer", []float64{0.5, 1})
+	histogram.Observe("true", 0.25)
+	histogram.Observe("true", 2)
+	histogram.Observe("false", 0.75)
+	c.addLabelledHistogram("test/labelled_histogram", histogram.Snapshot())
+
+	c.addLabelledHistogram("test/empty_labelled_histogram", metrics.NewLabelledHistogram("proposer", []float64{1}))
+
+	const expectedOutput = `# TYPE test_labelled_histogram histogram
+test_labelled_histogram_bucket{proposer="false",le="0.5"} 0
+test_labelled_histogram_bucket{proposer="false",le="1"} 1
+test_labelled_histogram_bucket{proposer="false",le="+Inf"} 1
+test_labelled_histogram_sum{proposer="false"} 0.75
+test_labelled_histogram_count{proposer="false"} 1
+test_labelled_histogram_bucket{proposer="true",le="0.5"} 1
+test_labelled_histogram_bucket{proposer="true",le="1"} 1
+test_labelled_histogram_bucket{proposer="true",le="+Inf"} 2
+test_labelled_histogram_sum{proposer="true"} 2.25
+test_labelled_histogram_count{proposer="true"} 2
+
+`
+	exp := c.buff.String()
+	if exp != expectedOutput {
+		t.Log("Expected Output:\n", expectedOutput)
+		t.Log("Actual Output:\n", exp)
+		t.Fatal("unexpected collector output")
+	}
+}
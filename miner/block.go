@@ -51,6 +51,7 @@ type blockState struct {
 	receipts       []*types.Receipt
 	randomness     *types.Randomness // The types.Randomness of the last block by mined by this worker.
 	txFeeRecipient common.Address
+	buildStart     time.Time // When the block construction started, after waiting for the block time
 }
 
 // prepareBlock intializes a new blockState that is ready to have transaction included to.
@@ -94,6 +95,7 @@ func prepareBlock(w *worker) (*blockState, error) {
 	}
 
 	// Initialize the block state itself
+	buildStart := time.Now()
 	state, err := w.chain.StateAt(parent.Root())
 	if err != nil {
 		return nil, fmt.Errorf("Failed to get the parent state: %w:", err)
@@ -107,6 +109,7 @@ func prepareBlock(w *worker) (*blockState, error) {
 		tcount:         0,
 		gasLimit:       blockchain_parameters.GetBlockGasLimitOrDefault(vmRunner),
 		header:         header,
+		buildStart:     buildStart,
 		txFeeRecipient: txFeeRecipient,
 		sysCtx:         core.NewSysContractCallCtx(w.chain.NewEVMRunner(header, state.Copy())),
 	}
@@ -343,6 +346,7 @@ func (b *blockState) finalizeAndAssemble(w *worker) (*types.Block, error) {
 		if err := istanbul.UpdateValSetDiff(w.chain, block.MutableHeader(), b.state); err != nil {
 			return nil, fmt.Errorf("Unable to update Validator Set Diff: %w", err)
 		}
+		istanbul.RecordProposalBuildTime(block.NumberU64(), time.Since(b.buildStart))
 	}
 	// FinalizeAndAssemble adds the "block receipt" to then calculate the Bloom filter and receipts hash.
 	// But it doesn't return the receipts.  So we have to add the "block receipt" to b.receipts here, for
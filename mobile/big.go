@@ -82,6 +82,15 @@ func (bi *BigInt) Sign() int {
 	return bi.bigint.Sign()
 }
 
+// toBigIntPtr returns the underlying big int, or nil for a nil big int, as
+// optional values are passed as nil from the mobile app.
+func (bi *BigInt) toBigIntPtr() *big.Int {
+	if bi == nil {
+		return nil
+	}
+	return bi.bigint
+}
+
 // SetString sets the big int to x.
 //
 // The string prefix determines the actual conversion base. A prefix of "0x" or
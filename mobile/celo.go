@@ -0,0 +1,163 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+// Contains the wrappers of the celo specific APIs, such as the istanbul
+// validator set and the gateway fees of the light servers.
+
+package geth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/consensus/istanbul"
+	"github.com/celo-org/celo-blockchain/ethclient/celoclient"
+	"github.com/celo-org/celo-blockchain/les"
+)
+
+// GatewayFeeInfo is the gateway fee requested by a light server, and the
+// recipient the gateway fee of the transactions relayed by it must be paid to.
+type GatewayFeeInfo struct {
+	info *les.GatewayFeeInformation
+}
+
+func (gfi *GatewayFeeInfo) GetGatewayFee() *BigInt { return &BigInt{gfi.info.GatewayFee} }
+func (gfi *GatewayFeeInfo) GetEtherbase() *Address { return &Address{gfi.info.Etherbase} }
+
+// RequestGatewayFees asks the light servers the node is connected to for their
+// gateway fees. The node must be running in light or lightest sync mode, and the
+// fees are known to SuggestGatewayFee once the light servers answered.
+func (n *Node) RequestGatewayFees() error {
+	if n.les == nil {
+		return errors.New("les was not initialised")
+	}
+	return les.NewPrivateLightClientAPI(n.les).RequestPeerGatewayFees()
+}
+
+// SuggestGatewayFee returns the lowest gateway fee among those the light servers
+// answered to RequestGatewayFees with.
+func (n *Node) SuggestGatewayFee() (*GatewayFeeInfo, error) {
+	if n.les == nil {
+		return nil, errors.New("les was not initialised")
+	}
+	info, err := les.NewPrivateLightClientAPI(n.les).SuggestGatewayFee()
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.New("no gateway fee known from the light servers")
+	}
+	return &GatewayFeeInfo{info}, nil
+}
+
+// IstanbulSnapshot is the istanbul validator set at a block.
+type IstanbulSnapshot struct {
+	snapshot *celoclient.Snapshot
+}
+
+func (s *IstanbulSnapshot) GetEpochSize() int64 { return int64(s.snapshot.Epoch) }
+func (s *IstanbulSnapshot) GetNumber() int64    { return int64(s.snapshot.Number) }
+func (s *IstanbulSnapshot) GetHash() *Hash      { return &Hash{s.snapshot.Hash} }
+
+// GetValidators returns the addresses of the validators of the snapshot.
+func (s *IstanbulSnapshot) GetValidators() *Addresses {
+	addresses := make([]common.Address, len(s.snapshot.Validators))
+	for i, validator := range s.snapshot.Validators {
+		addresses[i] = validator.Address
+	}
+	return &Addresses{addresses}
+}
+
+// GetValidatorBLSPublicKey returns the BLS public key of the validator at the
+// given index of the snapshot.
+func (s *IstanbulSnapshot) GetValidatorBLSPublicKey(index int) ([]byte, error) {
+	if index < 0 || index >= len(s.snapshot.Validators) {
+		return nil, errors.New("index out of bounds")
+	}
+	return common.CopyBytes(s.snapshot.Validators[index].BLSPublicKey[:]), nil
+}
+
+// toBlockNumber converts a block number of the mobile API, where a number <0
+// stands for the latest known block, to the block number of the clients.
+func toBlockNumber(number int64) *big.Int {
+	if number < 0 {
+		return nil
+	}
+	return big.NewInt(number)
+}
+
+// GetIstanbulSnapshot returns the istanbul validator set at the given block. If
+// number is <0, the latest known block is used.
+func (ec *EthereumClient) GetIstanbulSnapshot(ctx *Context, number int64) (snapshot *IstanbulSnapshot, _ error) {
+	rawSnapshot, err := ec.celo.Snapshot(ctx.context, toBlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	return &IstanbulSnapshot{rawSnapshot}, nil
+}
+
+// GetValidators returns the validators that must sign the given block. If number
+// is <0, the latest known block is used.
+func (ec *EthereumClient) GetValidators(ctx *Context, number int64) (validators *Addresses, _ error) {
+	rawValidators, err := ec.celo.Validators(ctx.context, toBlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	return &Addresses{rawValidators}, nil
+}
+
+// GetEpochSize returns the number of blocks in an epoch.
+func (ec *EthereumClient) GetEpochSize(ctx *Context) (size int64, _ error) {
+	rawSize, err := ec.celo.EpochSize(ctx.context)
+	return int64(rawSize), err
+}
+
+// GetGasPriceMinimum returns the gas price minimum in the given fee currency, nil
+// for CELO, at the given block. If number is <0, the latest known block is used.
+func (ec *EthereumClient) GetGasPriceMinimum(ctx *Context, feeCurrency *Address, number int64) (price *BigInt, _ error) {
+	rawPrice, err := ec.celo.GasPriceMinimum(ctx.context, feeCurrency.toAddressPtr(), toBlockNumber(number))
+	return &BigInt{rawPrice}, err
+}
+
+// GetEpochNumber returns the epoch of the given block. Epoch 0 only contains the
+// genesis block, epoch 1 starts at block 1.
+func GetEpochNumber(number int64, epochSize int64) (epoch int64, _ error) {
+	if number < 0 || epochSize <= 0 {
+		return 0, fmt.Errorf("invalid block number %d or epoch size %d", number, epochSize)
+	}
+	return int64(istanbul.GetEpochNumber(uint64(number), uint64(epochSize))), nil
+}
+
+// GetEpochFirstBlockNumber returns the first block of the given epoch, which must
+// not be epoch 0.
+func GetEpochFirstBlockNumber(epoch int64, epochSize int64) (number int64, _ error) {
+	if epoch < 0 || epochSize <= 0 {
+		return 0, fmt.Errorf("invalid epoch %d or epoch size %d", epoch, epochSize)
+	}
+	rawNumber, err := istanbul.GetEpochFirstBlockNumber(uint64(epoch), uint64(epochSize))
+	return int64(rawNumber), err
+}
+
+// GetEpochLastBlockNumber returns the last block of the given epoch, in which the
+// validators of the next epoch are elected.
+func GetEpochLastBlockNumber(epoch int64, epochSize int64) (number int64, _ error) {
+	if epoch < 0 || epochSize <= 0 {
+		return 0, fmt.Errorf("invalid epoch %d or epoch size %d", epoch, epochSize)
+	}
+	return int64(istanbul.GetEpochLastBlockNumber(uint64(epoch), uint64(epochSize))), nil
+}
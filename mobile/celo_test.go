@@ -0,0 +1,83 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core/types"
+)
+
+func TestCeloTransactionFields(t *testing.T) {
+	to := &Address{common.HexToAddress("0xbeef")}
+	feeCurrency := &Address{common.HexToAddress("0xd008")}
+	recipient := &Address{common.HexToAddress("0xfee")}
+
+	// Optional celo fields are passed as nil by the mobile apps
+	tx := NewTransaction(1, to, NewBigInt(10), 21000, NewBigInt(5), nil, nil, nil, nil)
+	if tx.GetFeeCurrency() != nil || tx.GetGatewayFeeRecipient() != nil || tx.GetGatewayFee().Sign() != 0 {
+		t.Errorf("celo fields set: %v %v %v", tx.GetFeeCurrency(), tx.GetGatewayFeeRecipient(), tx.GetGatewayFee())
+	}
+
+	tx = NewCeloDynamicFeeTransaction(NewBigInt(44787), 1, to, NewBigInt(10), 21000, NewBigInt(1), NewBigInt(5), feeCurrency, recipient, NewBigInt(7), nil)
+	blob, err := tx.EncodeRLP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := NewTransactionFromRLP(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.GetType() != types.CeloDynamicFeeTxType {
+		t.Errorf("type mismatch: have %d, want %d", decoded.GetType(), types.CeloDynamicFeeTxType)
+	}
+	if decoded.GetFeeCurrency().address != feeCurrency.address {
+		t.Errorf("fee currency mismatch: have %v, want %v", decoded.GetFeeCurrency(), feeCurrency)
+	}
+	if decoded.GetGatewayFeeRecipient().address != recipient.address || decoded.GetGatewayFee().GetInt64() != 7 {
+		t.Errorf("gateway fee mismatch: have %v %v", decoded.GetGatewayFeeRecipient(), decoded.GetGatewayFee())
+	}
+	if decoded.GetGasTipCap().GetInt64() != 1 || decoded.GetGasFeeCap().GetInt64() != 5 {
+		t.Errorf("gas caps mismatch: have %v %v", decoded.GetGasTipCap(), decoded.GetGasFeeCap())
+	}
+}
+
+func TestEpochNumbers(t *testing.T) {
+	for _, tt := range []struct {
+		number, epoch, first, last int64
+	}{{1, 1, 1, 10}, {10, 1, 1, 10}, {11, 2, 11, 20}} {
+		epoch, err := GetEpochNumber(tt.number, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := GetEpochFirstBlockNumber(epoch, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last, err := GetEpochLastBlockNumber(epoch, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if epoch != tt.epoch || first != tt.first || last != tt.last {
+			t.Errorf("block %d: have epoch %d [%d, %d], want %d [%d, %d]", tt.number, epoch, first, last, tt.epoch, tt.first, tt.last)
+		}
+	}
+	if _, err := GetEpochFirstBlockNumber(0, 10); err == nil {
+		t.Error("first block of epoch 0 returned")
+	}
+}
@@ -171,6 +171,16 @@ func (a *Address) GetBytes() []byte {
 	return a.address[:]
 }
 
+// toAddressPtr returns a pointer to the address, or nil for a nil address, as
+// optional addresses are passed as nil from the mobile app.
+func (a *Address) toAddressPtr() *common.Address {
+	if a == nil {
+		return nil
+	}
+	address := a.address
+	return &address
+}
+
 // SetHex sets the specified hex string as the address value.
 func (a *Address) SetHex(address string) error {
 	address = strings.ToLower(address)
@@ -23,17 +23,28 @@ import (
 
 	"github.com/celo-org/celo-blockchain/core/types"
 	"github.com/celo-org/celo-blockchain/ethclient"
+	"github.com/celo-org/celo-blockchain/ethclient/celoclient"
+	"github.com/celo-org/celo-blockchain/rpc"
 )
 
-// EthereumClient provides access to the Ethereum APIs.
+// EthereumClient provides access to the Ethereum APIs, and to the celo specific
+// APIs such as the istanbul consensus.
 type EthereumClient struct {
 	client *ethclient.Client
+	celo   *celoclient.Client
 }
 
 // NewEthereumClient connects a client to the given URL.
 func NewEthereumClient(rawurl string) (client *EthereumClient, _ error) {
-	rawClient, err := ethclient.Dial(rawurl)
-	return &EthereumClient{rawClient}, err
+	rawClient, err := rpc.Dial(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return newEthereumClient(rawClient), nil
+}
+
+func newEthereumClient(c *rpc.Client) *EthereumClient {
+	return &EthereumClient{ethclient.NewClient(c), celoclient.New(c)}
 }
 
 // GetBlockByHash returns the given full block.
@@ -298,6 +309,13 @@ func (ec *EthereumClient) SuggestGasPrice(ctx *Context) (price *BigInt, _ error)
 	return &BigInt{rawPrice}, err
 }
 
+// SuggestGasPriceInCurrency retrieves the currently suggested gas price in the
+// given fee currency, nil for CELO, to allow a timely execution of a transaction.
+func (ec *EthereumClient) SuggestGasPriceInCurrency(ctx *Context, feeCurrency *Address) (price *BigInt, _ error) {
+	rawPrice, err := ec.client.SuggestGasPriceInCurrency(ctx.context, feeCurrency.toAddressPtr())
+	return &BigInt{rawPrice}, err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific transaction based on
 // the current pending state of the backend blockchain. There is no guarantee that this is
 // the true gas limit requirement as other transactions may be added or removed by miners,
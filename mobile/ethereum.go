@@ -72,6 +72,33 @@ func (msg *CallMsg) SetTo(address *Address) {
 	msg.msg.To = &address.address
 }
 
+// GetFeeCurrency returns the currency the fees are paid in, nil for CELO.
+func (msg *CallMsg) GetFeeCurrency() *Address {
+	if feeCurrency := msg.msg.FeeCurrency; feeCurrency != nil {
+		return &Address{*feeCurrency}
+	}
+	return nil
+}
+
+// GetGatewayFeeRecipient returns the recipient of the gateway fee, nil if no
+// gateway fee is paid.
+func (msg *CallMsg) GetGatewayFeeRecipient() *Address {
+	if recipient := msg.msg.GatewayFeeRecipient; recipient != nil {
+		return &Address{*recipient}
+	}
+	return nil
+}
+
+func (msg *CallMsg) GetGatewayFee() *BigInt { return &BigInt{msg.msg.GatewayFee} }
+
+func (msg *CallMsg) SetFeeCurrency(address *Address) {
+	msg.msg.FeeCurrency = address.toAddressPtr()
+}
+func (msg *CallMsg) SetGatewayFeeRecipient(address *Address) {
+	msg.msg.GatewayFeeRecipient = address.toAddressPtr()
+}
+func (msg *CallMsg) SetGatewayFee(fee *BigInt) { msg.msg.GatewayFee = fee.toBigIntPtr() }
+
 // SyncProgress gives progress indications when the node is synchronising with
 // the Ethereum network.
 type SyncProgress struct {
@@ -28,7 +28,6 @@ import (
 	"github.com/celo-org/celo-blockchain/core"
 	"github.com/celo-org/celo-blockchain/eth/downloader"
 	"github.com/celo-org/celo-blockchain/eth/ethconfig"
-	"github.com/celo-org/celo-blockchain/ethclient"
 	"github.com/celo-org/celo-blockchain/ethstats"
 	"github.com/celo-org/celo-blockchain/internal/debug"
 	"github.com/celo-org/celo-blockchain/les"
@@ -122,6 +121,12 @@ type NodeConfig struct {
 	// See https://geth.ethereum.org/doc/Mobile_Account-management for reference
 	UseLightweightKDF bool
 
+	// GatewayFee is the gateway fee the transactions sent by a light or lightest
+	// node must pay for the light servers to relay them. Transactions paying a
+	// lower gateway fee are not sent to the light servers. If nil, the default of
+	// the node is used.
+	GatewayFee *BigInt
+
 	// IPCPath is the requested location to place the IPC endpoint. If the path is
 	// a simple file name, it is placed inside the data directory (or on the root
 	// pipe path on Windows), whereas if it's a resolvable path name (absolute or
@@ -252,6 +257,9 @@ func NewNode(datadir string, config *NodeConfig) (stack *Node, _ error) {
 		ethConf.SyncMode = getSyncMode(config.SyncMode)
 		ethConf.NetworkId = uint64(config.EthereumNetworkID)
 		ethConf.DatabaseCache = config.EthereumDatabaseCache
+		if config.GatewayFee != nil {
+			ethConf.GatewayFee = config.GatewayFee.bigint
+		}
 		// Use an in memory DB for replica state
 		ethConf.Istanbul.ReplicaStateDBPath = ""
 		// Use an in memory DB for validatorEnode table
@@ -321,7 +329,7 @@ func (n *Node) GetEthereumClient() (client *EthereumClient, _ error) {
 	if err != nil {
 		return nil, err
 	}
-	return &EthereumClient{ethclient.NewClient(rpc)}, nil
+	return newEthereumClient(rpc), nil
 }
 
 // GetNodeInfo gathers and returns a collection of metadata known about the host.
@@ -204,14 +204,34 @@ func NewContractCreation(nonce int64, amount *BigInt, gasLimit int64, gasPrice *
 	return &Transaction{types.NewContractCreation(uint64(nonce), amount.bigint, uint64(gasLimit), gasPrice.bigint, nil, nil, nil, common.CopyBytes(data))}
 }
 
-// NewTransaction creates a new transaction with the given properties.
+// NewTransaction creates a new transaction with the given properties. The fee
+// currency, gateway fee recipient and gateway fee can be nil, in which case the
+// fees are paid in CELO and no gateway fee is paid.
 func NewTransaction(nonce int64, to *Address, amount *BigInt, gasLimit int64, gasPrice *BigInt, feeCurrency, gatewayFeeRecipient *Address, gatewayFee *BigInt, data []byte) *Transaction {
-	convertedFeeCurrency := common.BytesToAddress(feeCurrency.GetBytes())
-	convertedGatewayFeeRecipient := common.BytesToAddress(gatewayFeeRecipient.GetBytes())
 	if to == nil {
-		return &Transaction{types.NewContractCreation(uint64(nonce), amount.bigint, uint64(gasLimit), gasPrice.bigint, &convertedFeeCurrency, &convertedGatewayFeeRecipient, gatewayFee.bigint, common.CopyBytes(data))}
+		return &Transaction{types.NewContractCreation(uint64(nonce), amount.bigint, uint64(gasLimit), gasPrice.bigint, feeCurrency.toAddressPtr(), gatewayFeeRecipient.toAddressPtr(), gatewayFee.toBigIntPtr(), common.CopyBytes(data))}
 	}
-	return &Transaction{types.NewTransaction(uint64(nonce), to.address, amount.bigint, uint64(gasLimit), gasPrice.bigint, &convertedFeeCurrency, &convertedGatewayFeeRecipient, gatewayFee.bigint, common.CopyBytes(data))}
+	return &Transaction{types.NewTransaction(uint64(nonce), to.address, amount.bigint, uint64(gasLimit), gasPrice.bigint, feeCurrency.toAddressPtr(), gatewayFeeRecipient.toAddressPtr(), gatewayFee.toBigIntPtr(), common.CopyBytes(data))}
+}
+
+// NewCeloDynamicFeeTransaction creates a new dynamic fee transaction with the given
+// properties. The destination can be nil for contract creations, while the fee
+// currency, gateway fee recipient and gateway fee can be nil, in which case the fees
+// are paid in CELO and no gateway fee is paid.
+func NewCeloDynamicFeeTransaction(chainID *BigInt, nonce int64, to *Address, amount *BigInt, gasLimit int64, gasTipCap, gasFeeCap *BigInt, feeCurrency, gatewayFeeRecipient *Address, gatewayFee *BigInt, data []byte) *Transaction {
+	return &Transaction{types.NewTx(&types.CeloDynamicFeeTx{
+		ChainID:             chainID.bigint,
+		Nonce:               uint64(nonce),
+		GasTipCap:           gasTipCap.bigint,
+		GasFeeCap:           gasFeeCap.bigint,
+		Gas:                 uint64(gasLimit),
+		FeeCurrency:         feeCurrency.toAddressPtr(),
+		GatewayFeeRecipient: gatewayFeeRecipient.toAddressPtr(),
+		GatewayFee:          gatewayFee.toBigIntPtr(),
+		To:                  to.toAddressPtr(),
+		Value:               amount.bigint,
+		Data:                common.CopyBytes(data),
+	})}
 }
 
 // NewTransactionFromRLP parses a transaction from an RLP data dump.
@@ -258,9 +278,33 @@ func (tx *Transaction) GetGasPrice() *BigInt { return &BigInt{tx.tx.GasPrice()}
 func (tx *Transaction) GetValue() *BigInt    { return &BigInt{tx.tx.Value()} }
 func (tx *Transaction) GetNonce() int64      { return int64(tx.tx.Nonce()) }
 
+func (tx *Transaction) GetType() int          { return int(tx.tx.Type()) }
+func (tx *Transaction) GetGasTipCap() *BigInt { return &BigInt{tx.tx.GasTipCap()} }
+func (tx *Transaction) GetGasFeeCap() *BigInt { return &BigInt{tx.tx.GasFeeCap()} }
+
 func (tx *Transaction) GetHash() *Hash   { return &Hash{tx.tx.Hash()} }
 func (tx *Transaction) GetCost() *BigInt { return &BigInt{tx.tx.Cost()} }
 
+// GetFeeCurrency returns the currency the fees are paid in, nil for CELO.
+func (tx *Transaction) GetFeeCurrency() *Address {
+	if feeCurrency := tx.tx.FeeCurrency(); feeCurrency != nil {
+		return &Address{*feeCurrency}
+	}
+	return nil
+}
+
+// GetGatewayFeeRecipient returns the recipient of the gateway fee, nil if no
+// gateway fee is paid.
+func (tx *Transaction) GetGatewayFeeRecipient() *Address {
+	if recipient := tx.tx.GatewayFeeRecipient(); recipient != nil {
+		return &Address{*recipient}
+	}
+	return nil
+}
+
+// GetGatewayFee returns the gateway fee, zero if no gateway fee is paid.
+func (tx *Transaction) GetGatewayFee() *BigInt { return &BigInt{tx.tx.GatewayFee()} }
+
 func (tx *Transaction) GetTo() *Address {
 	if to := tx.tx.To(); to != nil {
 		return &Address{*to}
@@ -271,7 +315,7 @@ func (tx *Transaction) GetTo() *Address {
 func (tx *Transaction) WithSignature(sig []byte, chainID *BigInt) (signedTx *Transaction, _ error) {
 	var signer types.Signer = types.HomesteadSigner{}
 	if chainID != nil {
-		signer = types.NewEIP155Signer(chainID.bigint)
+		signer = types.LatestSignerForChainID(chainID.bigint)
 	}
 	rawTx, err := tx.tx.WithSignature(signer, common.CopyBytes(sig))
 	return &Transaction{rawTx}, err
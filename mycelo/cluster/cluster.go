@@ -10,7 +10,8 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// Cluster represent a set of nodes (validators)
+// Cluster represent a set of nodes (validators,
+// their proxies and replicas, full nodes and light clients)
 // that are managed together
 type Cluster struct {
 	env    *env.Environment
@@ -36,30 +37,66 @@ func New(env *env.Environment, cfg Config) *Cluster {
 // This implies running `geth init` but also
 // configuring static nodes and node accounts
 func (cl *Cluster) Init() error {
-	var err error
-
 	nodes := cl.ensureNodes()
-	enodeUrls := make([]string, len(nodes))
-	console.Info("Initializing validator nodes")
-	for i, node := range nodes {
-		console.Infof("validator-%d> geth init", i)
+	topology := cl.env.Topology()
+	if topology.NumLightClients+topology.NumLightestClients > 0 && topology.NumFullNodes == 0 {
+		return fmt.Errorf("light clients require at least one full node to serve them")
+	}
+	for _, node := range nodes {
+		if node.Number >= portsPerRole {
+			return fmt.Errorf("too many %s nodes, at most %d are supported", node.Role, portsPerRole)
+		}
+	}
+
+	console.Info("Initializing nodes")
+	enodeUrls := make(map[*Node]string, len(nodes))
+	for _, node := range nodes {
+		console.Infof("%s> geth init", node.Name)
 		if err := node.Init(cl.env.GenesisPath()); err != nil {
 			return err
 		}
 
-		enodeUrls[i], err = node.EnodeURL()
+		enodeURL, err := node.EnodeURL()
 		if err != nil {
 			return err
 		}
+		enodeUrls[node] = enodeURL
 	}
 
-	// Connect each validator to each other
-	for i, node := range nodes {
+	// Validators and replicas behind proxies only connect to their proxies, which
+	// they do through the proxy flags. Every other node of the p2p network connects
+	// to each other, while light clients connect to the full nodes serving them.
+	var public, servers []*Node
+	for _, node := range nodes {
+		switch {
+		case node.IsValidating() && len(node.Proxies) > 0:
+		case node.Role == LightClientRole || node.Role == LightestClientRole:
+		default:
+			public = append(public, node)
+		}
+		if node.Role == FullNodeRole {
+			servers = append(servers, node)
+		}
+	}
+	staticNodes := func(peers []*Node, self *Node) []string {
 		var urls []string
-		urls = append(urls, enodeUrls[:i]...)
-		urls = append(urls, enodeUrls[i+1:]...)
-		err = node.SetStaticNodes(urls...)
-		if err != nil {
+		for _, peer := range peers {
+			if peer != self {
+				urls = append(urls, enodeUrls[peer])
+			}
+		}
+		return urls
+	}
+	for _, node := range nodes {
+		var urls []string
+		switch {
+		case node.IsValidating() && len(node.Proxies) > 0:
+		case node.Role == LightClientRole || node.Role == LightestClientRole:
+			urls = staticNodes(servers, node)
+		default:
+			urls = staticNodes(public, node)
+		}
+		if err := node.SetStaticNodes(urls...); err != nil {
 			return err
 		}
 	}
@@ -67,23 +104,67 @@ func (cl *Cluster) Init() error {
 	return nil
 }
 
+// ensureNodes creates the nodes of the topology: each validator followed by its
+// proxies and replicas, then the full nodes and light clients. Nodes are numbered
+// within their role, so validator-XX keeps number XX and its ports whatever the
+// other nodes are.
 func (cl *Cluster) ensureNodes() []*Node {
 
 	if cl.nodes == nil {
 		validators := cl.env.Accounts().ValidatorAccounts()
 		txFeeRecipients := cl.env.Accounts().TxFeeRecipientAccounts()
-		cl.nodes = make([]*Node, len(validators))
-		for i, validator := range validators {
+		topology := cl.env.Topology()
+
+		numbers := make(map[NodeRole]int)
+		addNode := func(name string, role NodeRole, configure func(*NodeConfig)) *Node {
 			nodeConfig := &NodeConfig{
-				GethPath:              cl.config.GethPath,
-				ExtraFlags:            cl.config.ExtraFlags,
-				Number:                i,
-				Account:               validator,
-				TxFeeRecipientAccount: txFeeRecipients[i],
-				Datadir:               cl.env.ValidatorDatadir(i),
-				ChainID:               cl.env.Config.ChainID,
+				GethPath:   cl.config.GethPath,
+				ExtraFlags: cl.config.ExtraFlags,
+				Name:       name,
+				Role:       role,
+				Number:     numbers[role],
+				Datadir:    cl.env.NodeDatadir(name),
+				ChainID:    cl.env.Config.ChainID,
+			}
+			if configure != nil {
+				configure(nodeConfig)
 			}
-			cl.nodes[i] = NewNode(nodeConfig)
+			node := NewNode(nodeConfig)
+			numbers[role]++
+			cl.nodes = append(cl.nodes, node)
+			return node
+		}
+
+		for i, validator := range validators {
+			validator, txFeeRecipient := validator, txFeeRecipients[i]
+			name := fmt.Sprintf("validator-%02d", i)
+
+			node := addNode(name, ValidatorRole, func(cfg *NodeConfig) {
+				cfg.Account = validator
+				cfg.TxFeeRecipientAccount = txFeeRecipient
+				cfg.Datadir = cl.env.ValidatorDatadir(i)
+			})
+			for j := 0; j < topology.ProxiesPerValidator; j++ {
+				node.Proxies = append(node.Proxies, addNode(fmt.Sprintf("%s-proxy-%02d", name, j), ProxyRole, func(cfg *NodeConfig) {
+					cfg.ProxiedValidator = validator.Address
+				}))
+			}
+			for j := 0; j < topology.ReplicasPerValidator; j++ {
+				addNode(fmt.Sprintf("%s-replica-%02d", name, j), ReplicaRole, func(cfg *NodeConfig) {
+					cfg.Account = validator
+					cfg.TxFeeRecipientAccount = txFeeRecipient
+					cfg.Proxies = node.Proxies
+				})
+			}
+		}
+		for i := 0; i < topology.NumFullNodes; i++ {
+			addNode(fmt.Sprintf("fullnode-%02d", i), FullNodeRole, nil)
+		}
+		for i := 0; i < topology.NumLightClients; i++ {
+			addNode(fmt.Sprintf("light-%02d", i), LightClientRole, nil)
+		}
+		for i := 0; i < topology.NumLightestClients; i++ {
+			addNode(fmt.Sprintf("lightest-%02d", i), LightestClientRole, nil)
 		}
 	}
 	return cl.nodes
@@ -91,12 +172,12 @@ func (cl *Cluster) ensureNodes() []*Node {
 
 // PrintNodeInfo prints debug information about nodes
 func (cl *Cluster) PrintNodeInfo() error {
-	for i, node := range cl.ensureNodes() {
+	for _, node := range cl.ensureNodes() {
 		endoreURL, err := node.EnodeURL()
 		if err != nil {
 			return err
 		}
-		fmt.Printf("validator-%d: %s\n", i, endoreURL)
+		fmt.Printf("%s (%s): %s\n", node.Name, node.Role, endoreURL)
 	}
 	return nil
 }
@@ -105,10 +186,9 @@ func (cl *Cluster) PrintNodeInfo() error {
 func (cl *Cluster) Run(ctx context.Context) error {
 	group, ctx := errgroup.WithContext(ctx)
 	log.Printf("Starting cluster")
-	for i, node := range cl.ensureNodes() {
+	for _, node := range cl.ensureNodes() {
 		node := node
-		i := i
-		log.Printf("Starting validator%02d...", i)
+		log.Printf("Starting %s...", node.Name)
 		group.Go(func() error { return node.Run(ctx) })
 	}
 	return group.Wait()
@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	. "github.com/onsi/gomega"
+)
+
+func newTestCluster(topology env.TopologyConfig) *Cluster {
+	environment, err := env.New("/tmp/mycelo-cluster", &env.Config{
+		ChainID: big.NewInt(1500),
+		Accounts: env.AccountsConfig{
+			Mnemonic:           env.MustNewMnemonic(),
+			NumValidators:      2,
+			ValidatorsPerGroup: 1,
+		},
+		Topology: topology,
+	})
+	Ω(err).ShouldNot(HaveOccurred())
+	return New(environment, Config{})
+}
+
+func TestValidatorNumbersAreStable(t *testing.T) {
+	RegisterTestingT(t)
+
+	validatorPorts := func(cl *Cluster) (ports []int64) {
+		for _, node := range cl.ensureNodes() {
+			if node.Role == ValidatorRole {
+				ports = append(ports, node.NodePort(), node.RPCPort())
+			}
+		}
+		return ports
+	}
+
+	plain := validatorPorts(newTestCluster(env.TopologyConfig{}))
+	Ω(plain).Should(Equal([]int64{30303, 8545, 30304, 8546}))
+
+	withOtherNodes := validatorPorts(newTestCluster(env.TopologyConfig{
+		ProxiesPerValidator:  2,
+		ReplicasPerValidator: 1,
+		NumFullNodes:         1,
+	}))
+	Ω(withOtherNodes).Should(Equal(plain))
+}
+
+func TestNodePortsAreUnique(t *testing.T) {
+	RegisterTestingT(t)
+
+	cl := newTestCluster(env.TopologyConfig{
+		ProxiesPerValidator:  2,
+		ReplicasPerValidator: 1,
+		NumFullNodes:         2,
+		NumLightClients:      1,
+		NumLightestClients:   1,
+	})
+	nodes := cl.ensureNodes()
+	Ω(nodes).Should(HaveLen(2 + 4 + 2 + 2 + 1 + 1))
+
+	used := make(map[int64]string)
+	use := func(port int64, name string) {
+		Ω(used).ShouldNot(HaveKey(port), "port %d of %s already used by %s", port, name, used[port])
+		used[port] = name
+	}
+	for _, node := range nodes {
+		use(node.NodePort(), node.Name)
+		use(node.RPCPort(), node.Name)
+		if node.Role == ProxyRole {
+			use(node.ProxyInternalPort(), node.Name)
+		}
+	}
+}
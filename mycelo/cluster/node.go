@@ -23,26 +23,84 @@ import (
 	"github.com/celo-org/celo-blockchain/common"
 )
 
+// NodeRole is the role a node plays within the cluster
+type NodeRole int
+
+// The roles of the nodes of a cluster
+const (
+	ValidatorRole      NodeRole = iota // Validator, proxied if it has any proxies
+	ProxyRole                          // Proxy in front of a validator
+	ReplicaRole                        // Hot-standby replica of a validator
+	FullNodeRole                       // Full node serving light clients
+	LightClientRole                    // Light client
+	LightestClientRole                 // Lightest client
+)
+
+// String implements the stringer interface.
+func (role NodeRole) String() string {
+	switch role {
+	case ValidatorRole:
+		return "validator"
+	case ProxyRole:
+		return "proxy"
+	case ReplicaRole:
+		return "replica"
+	case FullNodeRole:
+		return "fullnode"
+	case LightClientRole:
+		return "light"
+	case LightestClientRole:
+		return "lightest"
+	}
+	return "unknown"
+}
+
 // NodeConfig represents the configuration of a celo-blockchain node runner
 type NodeConfig struct {
 	GethPath              string
 	ExtraFlags            string
 	ChainID               *big.Int
-	Number                int
+	Name                  string
+	Role                  NodeRole
+	Number                int // Index of the node among the nodes of its role
 	Account               env.Account
 	TxFeeRecipientAccount env.Account
 	OtherAccounts         []env.Account
 	Datadir               string
+
+	// Proxies of a validator or replica, and the validator a proxy fronts
+	Proxies          []*Node
+	ProxiedValidator common.Address
+}
+
+// portsPerRole is the size of the port range of each role, and so the maximum
+// number of nodes of a role
+const portsPerRole = 1000
+
+// portOffset is the offset of the node's ports, within the range of its role
+func (nc *NodeConfig) portOffset() int64 {
+	return int64(nc.Role)*portsPerRole + int64(nc.Number)
 }
 
 // RPCPort is the rpc port this node will use
 func (nc *NodeConfig) RPCPort() int64 {
-	return int64(8545 + nc.Number)
+	return 8545 + nc.portOffset()
 }
 
 // NodePort is the node port this node will use
 func (nc *NodeConfig) NodePort() int64 {
-	return int64(30303 + nc.Number)
+	return 30303 + nc.portOffset()
+}
+
+// ProxyInternalPort is the port a proxy listens to for its validator, in a range
+// after the node ports of every role
+func (nc *NodeConfig) ProxyInternalPort() int64 {
+	return 40303 + int64(nc.Number)
+}
+
+// IsValidating returns whether the node runs the consensus engine
+func (nc *NodeConfig) IsValidating() bool {
+	return nc.Role == ValidatorRole || nc.Role == ReplicaRole
 }
 
 // Node represents a Node runner
@@ -75,15 +133,41 @@ func (n *Node) SetStaticNodes(enodeUrls ...string) error {
 
 // EnodeURL returns the enode url used by the node
 func (n *Node) EnodeURL() (string, error) {
+	return n.enodeURL(n.NodePort())
+}
+
+// InternalEnodeURL returns the enode url a proxy's validator connects to
+func (n *Node) InternalEnodeURL() (string, error) {
+	return n.enodeURL(n.ProxyInternalPort())
+}
+
+func (n *Node) enodeURL(port int64) (string, error) {
 	nodekey, err := crypto.LoadECDSA(n.keyFile())
 	if err != nil {
 		return "", err
 	}
 	ip := net.IP{127, 0, 0, 1}
-	en := enode.NewV4(&nodekey.PublicKey, ip, int(n.NodePort()), int(n.NodePort()))
+	en := enode.NewV4(&nodekey.PublicKey, ip, int(port), int(port))
 	return en.URLv4(), nil
 }
 
+// proxyEnodeURLPairs returns the internal and external enode urls of the node's proxies
+func (n *Node) proxyEnodeURLPairs() (string, error) {
+	pairs := make([]string, len(n.Proxies))
+	for i, proxy := range n.Proxies {
+		internal, err := proxy.InternalEnodeURL()
+		if err != nil {
+			return "", err
+		}
+		external, err := proxy.EnodeURL()
+		if err != nil {
+			return "", err
+		}
+		pairs[i] = internal + ";" + external
+	}
+	return strings.Join(pairs, ","), nil
+}
+
 // AccountAddresses retrieves the list of accounts currently configured in the node
 func (n *Node) AccountAddresses() []common.Address {
 	ks := keystore.NewKeyStore(path.Join(n.Datadir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
@@ -120,8 +204,10 @@ func (n *Node) Init(GenesisJSON string) error {
 
 	// Add Accounts
 	ks := keystore.NewKeyStore(path.Join(n.Datadir, "keystore"), keystore.LightScryptN, keystore.LightScryptP)
-	if _, err := ks.ImportECDSA(n.Account.PrivateKey, ""); err != nil {
-		return err
+	if n.IsValidating() {
+		if _, err := ks.ImportECDSA(n.Account.PrivateKey, ""); err != nil {
+			return err
+		}
 	}
 	for _, acc := range n.OtherAccounts {
 		if _, err := ks.ImportECDSA(acc.PrivateKey, ""); err != nil {
@@ -145,19 +231,10 @@ func (n *Node) generateNodeKey() error {
 
 // Run will run the node
 func (n *Node) Run(ctx context.Context) error {
-
-	var addressToUnlock string
-	for _, addr := range n.AccountAddresses() {
-		addressToUnlock += "," + addr.Hex()
-	}
-
 	args := []string{
 		"--datadir", n.Datadir,
 		"--verbosity", "4",
 		"--networkid", n.ChainID.String(),
-		"--syncmode", "full",
-		"--mine",
-		"--allow-insecure-unlock",
 		"--nodiscover",
 		"--nat", "extip:127.0.0.1",
 		"--port", strconv.FormatInt(n.NodePort(), 10),
@@ -165,25 +242,13 @@ func (n *Node) Run(ctx context.Context) error {
 		"--rpcaddr", "127.0.0.1",
 		"--rpcport", strconv.FormatInt(n.RPCPort(), 10),
 		"--rpcapi", "eth,net,web3,debug,admin,personal,istanbul,txpool",
-		// "--nodiscover", "--nousb ",
-		"--unlock", addressToUnlock,
-		"--password", n.pwdFile(),
 	}
 
-	// Once we're sure we won't run v1.2.x and older, can get rid of this check
-	// and just use the new options
-	helpBytes, _ := exec.Command(n.GethPath, "--help").Output() // #nosec G204
-	useTxFeeRecipient := strings.Contains(string(helpBytes), "miner.validator")
-	if useTxFeeRecipient {
-		args = append(args,
-			"--miner.validator", n.Account.Address.Hex(),
-			"--tx-fee-recipient", n.TxFeeRecipientAccount.Address.Hex(),
-		)
-	} else {
-		args = append(args,
-			"--etherbase", n.Account.Address.Hex(),
-		)
+	roleArgs, err := n.roleArgs()
+	if err != nil {
+		return err
 	}
+	args = append(args, roleArgs...)
 
 	if n.ExtraFlags != "" {
 		args = append(args, strings.Fields(n.ExtraFlags)...)
@@ -221,6 +286,76 @@ func (n *Node) Run(ctx context.Context) error {
 	return cmd.Wait()
 }
 
+// roleArgs returns the flags specific to the node's role
+func (n *Node) roleArgs() ([]string, error) {
+	switch n.Role {
+	case ProxyRole:
+		return []string{
+			"--syncmode", "full",
+			"--proxy.proxy",
+			"--proxy.proxiedvalidatoraddress", n.ProxiedValidator.Hex(),
+			"--proxy.internalendpoint", fmt.Sprintf(":%d", n.ProxyInternalPort()),
+		}, nil
+
+	case FullNodeRole:
+		return []string{
+			"--syncmode", "full",
+			"--light.serve", "90",
+			"--light.maxpeers", "100",
+		}, nil
+
+	case LightClientRole:
+		return []string{"--syncmode", "light"}, nil
+
+	case LightestClientRole:
+		return []string{"--syncmode", "lightest"}, nil
+	}
+
+	// Validators and replicas
+	var addressToUnlock string
+	for _, addr := range n.AccountAddresses() {
+		addressToUnlock += "," + addr.Hex()
+	}
+	args := []string{
+		"--syncmode", "full",
+		"--mine",
+		"--allow-insecure-unlock",
+		// "--nodiscover", "--nousb ",
+		"--unlock", addressToUnlock,
+		"--password", n.pwdFile(),
+	}
+	if n.Role == ReplicaRole {
+		args = append(args, "--istanbul.replica")
+	}
+	if len(n.Proxies) > 0 {
+		pairs, err := n.proxyEnodeURLPairs()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args,
+			"--proxy.proxied",
+			"--proxy.proxyenodeurlpairs", pairs,
+			"--proxy.allowprivateip",
+		)
+	}
+
+	// Once we're sure we won't run v1.2.x and older, can get rid of this check
+	// and just use the new options
+	helpBytes, _ := exec.Command(n.GethPath, "--help").Output() // #nosec G204
+	useTxFeeRecipient := strings.Contains(string(helpBytes), "miner.validator")
+	if useTxFeeRecipient {
+		args = append(args,
+			"--miner.validator", n.Account.Address.Hex(),
+			"--tx-fee-recipient", n.TxFeeRecipientAccount.Address.Hex(),
+		)
+	} else {
+		args = append(args,
+			"--etherbase", n.Account.Address.Hex(),
+		)
+	}
+	return args, nil
+}
+
 func (n *Node) pwdFile() string         { return path.Join(n.Datadir, "password") }
 func (n *Node) logFile() string         { return path.Join(n.Datadir, "geth.log") }
 func (n *Node) keyFile() string         { return path.Join(n.Datadir, "celo/nodekey") }
@@ -1,6 +1,7 @@
 package contract
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/celo-org/celo-blockchain/accounts/abi"
@@ -25,6 +26,16 @@ func AbiFor(name string) *abi.ABI {
 	return abi
 }
 
+// ContractNames returns the names of the core contracts with a known ABI, sorted
+func ContractNames() []string {
+	names := make([]string, 0, len(abis))
+	for name := range abis {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // DeployCoreContract deploys one of celo's core contracts
 func DeployCoreContract(cfg *runtime.Config, contractName string, code []byte, params ...interface{}) (*EVMBackend, error) {
 	return DeployEVMBackend(AbiFor(contractName), cfg, code, params...)
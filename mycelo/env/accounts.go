@@ -64,6 +64,12 @@ func (a *Account) MustBLSProofOfPossession() []byte {
 
 // BLSProofOfPossession generates bls proof of possession
 func (a *Account) BLSProofOfPossession() ([]byte, error) {
+	return a.BLSProofOfPossessionFor(a.Address)
+}
+
+// BLSProofOfPossessionFor generates bls proof of possession over another address,
+// as required when authorizing the account as signer of that address
+func (a *Account) BLSProofOfPossessionFor(address common.Address) ([]byte, error) {
 	privateKeyBytes, err := blscrypto.ECDSAToBLS(a.PrivateKey)
 	if err != nil {
 		return nil, err
@@ -75,7 +81,7 @@ func (a *Account) BLSProofOfPossession() ([]byte, error) {
 	}
 	defer privateKey.Destroy()
 
-	signature, err := privateKey.SignPoP(a.Address.Bytes())
+	signature, err := privateKey.SignPoP(address.Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +60,15 @@ func (env *Environment) ValidatorDatadir(idx int) string { return env.paths.vali
 // ValidatorIPC returns the ipc path to validator-[idx]
 func (env *Environment) ValidatorIPC(idx int) string { return env.paths.validatorIPC(idx) }
 
+// Topology retrieves the topology config
+func (env *Environment) Topology() *TopologyConfig { return &env.Config.Topology }
+
+// NodeDatadir returns the datadir that mycelo uses to run the node with the given name
+func (env *Environment) NodeDatadir(name string) string { return env.paths.nodeDatadir(name) }
+
+// NodeIPC returns the ipc path to the node with the given name
+func (env *Environment) NodeIPC(name string) string { return env.paths.nodeIPC(name) }
+
 // IPC returns the IPC path to the first validator
 func (env *Environment) IPC() string { return env.paths.validatorIPC(0) }
 
@@ -72,6 +81,15 @@ func (env *Environment) SaveGenesis(genesis *core.Genesis) error {
 	return nil
 }
 
+// LoadGenesis reads the genesis.json of the environment
+func (env *Environment) LoadGenesis() (*core.Genesis, error) {
+	var genesis core.Genesis
+	if err := utils.ReadJson(&genesis, env.paths.genesisJSON()); err != nil {
+		return nil, err
+	}
+	return &genesis, nil
+}
+
 func (env *Environment) ensureWorkdir() {
 	if !fileutils.FileExists(env.paths.Workdir) {
 		os.MkdirAll(env.paths.Workdir, os.ModePerm)
@@ -25,3 +25,11 @@ func (p paths) validatorDatadir(idx int) string {
 func (p paths) validatorIPC(idx int) string {
 	return path.Join(p.Workdir, fmt.Sprintf("validator-%02d/geth.ipc", idx))
 }
+
+func (p paths) nodeDatadir(name string) string {
+	return path.Join(p.Workdir, name)
+}
+
+func (p paths) nodeIPC(name string) string {
+	return path.Join(p.Workdir, name, "geth.ipc")
+}
@@ -8,6 +8,16 @@ import (
 type Config struct {
 	ChainID  *big.Int       `json:"chainId"`  // chainId identifies the current chain and is used for replay protection
 	Accounts AccountsConfig `json:"accounts"` // Accounts configuration for the environment
+	Topology TopologyConfig `json:"topology"` // Nodes to run besides the validators
+}
+
+// TopologyConfig represents the nodes of the environment besides the validators
+type TopologyConfig struct {
+	ProxiesPerValidator  int `json:"proxiesPerValidator"`  // Number of proxies in front of each validator
+	ReplicasPerValidator int `json:"replicasPerValidator"` // Number of hot-standby replicas of each validator
+	NumFullNodes         int `json:"fullNodes"`            // Number of full nodes, serving light clients
+	NumLightClients      int `json:"lightClients"`         // Number of light clients
+	NumLightestClients   int `json:"lightestClients"`      // Number of lightest clients
 }
 
 // AccountsConfig represents accounts configuration for the environment
@@ -18,6 +18,10 @@ func TestConfigMarhalling(t *testing.T) {
 			NumValidators:      6,
 			ValidatorsPerGroup: 2,
 		},
+		Topology: TopologyConfig{
+			ProxiesPerValidator: 2,
+			NumLightClients:     1,
+		},
 	}
 
 	raw, err := json.Marshal(cfg)
@@ -42,6 +46,13 @@ func TestConfigReadJson(t *testing.T) {
 		  "mnemonic": "aloha hawai",
 		  "validators": 6,
 		  "validatorsPerGroup": 2
+		},
+		"topology": {
+		  "proxiesPerValidator": 1,
+		  "replicasPerValidator": 1,
+		  "fullNodes": 2,
+		  "lightClients": 3,
+		  "lightestClients": 4
 		}
 	 }`)
 
@@ -52,6 +63,13 @@ func TestConfigReadJson(t *testing.T) {
 			NumValidators:      6,
 			ValidatorsPerGroup: 2,
 		},
+		Topology: TopologyConfig{
+			ProxiesPerValidator:  1,
+			ReplicasPerValidator: 1,
+			NumFullNodes:         2,
+			NumLightClients:      3,
+			NumLightestClients:   4,
+		},
 	}
 
 	var resultCfg Config
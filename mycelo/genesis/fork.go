@@ -0,0 +1,243 @@
+package genesis
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/celo-org/celo-blockchain/accounts"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/decimal/token"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/core/vm/runtime"
+	"github.com/celo-org/celo-blockchain/crypto"
+	"github.com/celo-org/celo-blockchain/ethdb"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/mycelo/contract"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+var devAccountBalance = token.MustNew("50000").BigInt() // 50k of each token
+
+// forkContext holds the state of a live chain being turned into a local testnet
+type forkContext struct {
+	accounts      *env.AccountsConfig
+	statedb       *state.StateDB
+	runtimeConfig *runtime.Config
+	logger        log.Logger
+}
+
+// OpenForkState opens the state of the canonical block with the given number in
+// the chain database, or of the head block if the number is negative.
+func OpenForkState(db ethdb.Database, number int64) (*state.StateDB, *types.Header, error) {
+	var header *types.Header
+	if number >= 0 {
+		header = rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, uint64(number)), uint64(number))
+	} else {
+		header = rawdb.ReadHeadHeader(db)
+	}
+	if header == nil {
+		return nil, nil, errors.New("block not found")
+	}
+	statedb, err := state.New(header.Root, state.NewDatabase(db), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return statedb, header, nil
+}
+
+// GenerateGenesisFromState creates a genesis block holding the state of a live chain
+// at the given block, with the elected validators taken over by the environment's
+// validator accounts.
+//
+// The number of validators of the accounts config is set to the size of the elected
+// set. Every elected validator gets one of the local validator accounts authorized as
+// its signer, and the admin and developer accounts are funded with CELO, cUSD and cEUR.
+// These contract calls run at the block number of the forked state, so that epoch
+// dependent contracts behave as on the live chain, but the resulting genesis is block
+// 0 of the new chain. The state needs to be complete, including the preimages of all
+// account addresses and storage keys.
+func GenerateGenesisFromState(accounts *env.AccountsConfig, cfg *Config, statedb *state.StateDB, number *big.Int) (*core.Genesis, error) {
+	adminAddress := accounts.AdminAccount().Address
+	ctx := &forkContext{
+		accounts: accounts,
+		statedb:  statedb,
+		logger:   log.New("obj", "fork"),
+		runtimeConfig: &runtime.Config{
+			ChainConfig: cfg.ChainConfig(),
+			Origin:      adminAddress,
+			State:       statedb,
+			GasLimit:    1000000000000000,
+			GasPrice:    big.NewInt(0),
+			Value:       big.NewInt(0),
+			Time:        newBigInt(cfg.GenesisTimestamp),
+			Coinbase:    adminAddress,
+			BlockNumber: new(big.Int).Set(number),
+			EVMConfig:   vm.Config{},
+		},
+	}
+
+	if err := ctx.replaceValidators(); err != nil {
+		return nil, err
+	}
+	if err := ctx.fundAccounts(); err != nil {
+		return nil, err
+	}
+
+	genesisAlloc, err := forkGenesisAlloc(statedb)
+	if err != nil {
+		return nil, err
+	}
+
+	extraData, err := generateGenesisExtraData(accounts.ValidatorAccounts())
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.Genesis{
+		Config:    cfg.ChainConfig(),
+		ExtraData: extraData,
+		Coinbase:  adminAddress,
+		Timestamp: cfg.GenesisTimestamp,
+		Alloc:     genesisAlloc,
+	}, nil
+}
+
+// replaceValidators authorizes a local validator account as signer of every
+// validator the election contract would currently elect
+func (ctx *forkContext) replaceValidators() error {
+	var signers []common.Address
+	if _, err := ctx.contract("Election").Query(&signers, "electValidatorSigners"); err != nil {
+		return fmt.Errorf("error electing validators: %w", err)
+	}
+	ctx.accounts.NumValidators = len(signers)
+	validatorAccounts := ctx.accounts.ValidatorAccounts()
+
+	accountsContract := ctx.contract("Accounts")
+	for i, signer := range signers {
+		var account common.Address
+		if _, err := accountsContract.Query(&account, "signerToAccount", signer); err != nil {
+			return err
+		}
+		validator := validatorAccounts[i]
+		logger := ctx.logger.New("account", account, "signer", signer)
+
+		// Signer authorizations are signed over the hash of the authorizing account
+		sig, err := crypto.Sign(accounts.TextHash(crypto.Keccak256(account.Bytes())), validator.PrivateKey)
+		if err != nil {
+			return err
+		}
+		blsPub, err := validator.BLSPublicKey()
+		if err != nil {
+			return err
+		}
+		blsPop, err := validator.BLSProofOfPossessionFor(account)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("Replace validator signer", "newSigner", validator.Address)
+		// remove the 0x04 prefix from the pub key (we need the 64 bytes variant)
+		err = accountsContract.SimpleCallFrom(account, "authorizeValidatorSignerWithKeys",
+			validator.Address, sig[64]+27, common.BytesToHash(sig[:32]), common.BytesToHash(sig[32:64]),
+			validator.PublicKey()[1:], blsPub[:], blsPop)
+		if err != nil {
+			return fmt.Errorf("error replacing signer of validator %s: %w", account.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// fundAccounts mints tokens for the admin and developer accounts, keeping the
+// tokens' total supply consistent
+func (ctx *forkContext) fundAccounts() error {
+	recipients := append([]env.Account{*ctx.accounts.AdminAccount()}, ctx.accounts.DeveloperAccounts()...)
+
+	// Only the VM (the zero address) is allowed to mint CELO, and the exchanges to mint stable tokens
+	minters := []struct {
+		token  string
+		minter string
+	}{
+		{"GoldToken", ""},
+		{"StableToken", "Exchange"},
+		{"StableTokenEUR", "ExchangeEUR"},
+	}
+	for _, m := range minters {
+		tokenAddress := ctx.registryAddress(m.token)
+		minterAddress := ctx.registryAddress(m.minter)
+		if tokenAddress == common.ZeroAddress || (m.minter != "" && minterAddress == common.ZeroAddress) {
+			ctx.logger.Warn("Token not registered, skipping funding", "token", m.token)
+			continue
+		}
+		tokenContract := contract.CoreContract(ctx.runtimeConfig, m.token, tokenAddress)
+		for _, recipient := range recipients {
+			ctx.logger.Info("Fund account", "token", m.token, "account", recipient.Address, "amount", devAccountBalance)
+			if err := tokenContract.SimpleCallFrom(minterAddress, "mint", recipient.Address, devAccountBalance); err != nil {
+				return fmt.Errorf("error minting %s: %w", m.token, err)
+			}
+		}
+	}
+	return nil
+}
+
+// registryAddress returns the address registered for the contract, or the zero address
+func (ctx *forkContext) registryAddress(contractName string) common.Address {
+	if contractName == "" {
+		return common.ZeroAddress
+	}
+	var address common.Address
+	registry := contract.CoreContract(ctx.runtimeConfig, "Registry", params.RegistrySmartContractAddress)
+	if _, err := registry.Query(&address, "getAddressForString", contractName); err != nil {
+		ctx.logger.Warn("Registry lookup failed", "contract", contractName, "err", err)
+		return common.ZeroAddress
+	}
+	return address
+}
+
+func (ctx *forkContext) contract(contractName string) *contract.EVMBackend {
+	return contract.CoreContract(ctx.runtimeConfig, contractName, ctx.registryAddress(contractName))
+}
+
+// forkGenesisAlloc commits the forked state and converts it into a genesis
+// allocation. Unlike a generated genesis, accounts of a live chain have sent
+// transactions, so their nonces are kept.
+func forkGenesisAlloc(statedb *state.StateDB) (core.GenesisAlloc, error) {
+	root, err := statedb.Commit(true)
+	if err != nil {
+		return nil, err
+	}
+	genesisAlloc := dumpGenesisAlloc(statedb)
+	for addr, account := range genesisAlloc {
+		account.Nonce = statedb.GetNonce(addr)
+		genesisAlloc[addr] = account
+	}
+	if err := verifyGenesisAlloc(genesisAlloc, root); err != nil {
+		return nil, err
+	}
+	return genesisAlloc, nil
+}
+
+// verifyGenesisAlloc checks that the genesis allocation reproduces the given state
+// root, which it won't if preimages of addresses or storage keys were missing
+func verifyGenesisAlloc(genesisAlloc core.GenesisAlloc, root common.Hash) error {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	for addr, account := range genesisAlloc {
+		if account.Balance != nil {
+			statedb.AddBalance(addr, account.Balance)
+		}
+		statedb.SetCode(addr, account.Code)
+		statedb.SetNonce(addr, account.Nonce)
+		for key, value := range account.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+	if allocRoot := statedb.IntermediateRoot(true); allocRoot != root {
+		return fmt.Errorf("genesis allocation root %s does not match the state root %s, are preimages missing from the source chain?", allocRoot.Hex(), root.Hex())
+	}
+	return nil
+}
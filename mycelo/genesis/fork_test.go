@@ -0,0 +1,136 @@
+package genesis
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/trie"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	forkedEOA      = common.HexToAddress("0x00000000000000000000000000000000000000e0")
+	forkedContract = common.HexToAddress("0x00000000000000000000000000000000000000c0")
+)
+
+// writeForkDatadir writes a chain database with two blocks, whose states differ by
+// the nonce of an account, along with the preimages of their state.
+func writeForkDatadir(t *testing.T, chaindata string) {
+	db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 16, 16, filepath.Join(chaindata, "ancient"), "", false)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer db.Close()
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabaseWithConfig(db, &trie.Config{Preimages: true}), nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	statedb.SetBalance(forkedEOA, big.NewInt(1000))
+	statedb.SetNonce(forkedEOA, 7)
+	statedb.SetBalance(forkedContract, big.NewInt(5))
+	statedb.SetNonce(forkedContract, 1)
+	statedb.SetCode(forkedContract, []byte{0x60, 0x00, 0x54, 0x00})
+	statedb.SetState(forkedContract, common.HexToHash("0x01"), common.HexToHash("0xdead"))
+	statedb.SetState(forkedContract, common.HexToHash("0x02"), common.HexToHash("0xbeef"))
+
+	for number := uint64(0); number < 2; number++ {
+		if number == 1 {
+			statedb.SetNonce(forkedEOA, 8)
+		}
+		root, err := statedb.Commit(true)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(statedb.Database().TrieDB().Commit(root, false, nil)).Should(Succeed())
+
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Root: root, Extra: make([]byte, types.IstanbulExtraVanity)}
+		rawdb.WriteHeader(db, header)
+		rawdb.WriteCanonicalHash(db, header.Hash(), number)
+		rawdb.WriteHeadHeaderHash(db, header.Hash())
+	}
+}
+
+func TestForkGenesisAlloc(t *testing.T) {
+	RegisterTestingT(t)
+
+	datadir, err := ioutil.TempDir("", "mycelo-fork")
+	Ω(err).ShouldNot(HaveOccurred())
+	defer os.RemoveAll(datadir)
+	chaindata := filepath.Join(datadir, "celo", "chaindata")
+	writeForkDatadir(t, chaindata)
+
+	// Opened read only, as the source node's datadir
+	db, err := rawdb.NewLevelDBDatabaseWithFreezer(chaindata, 16, 16, filepath.Join(chaindata, "ancient"), "", true)
+	Ω(err).ShouldNot(HaveOccurred())
+	defer db.Close()
+
+	for _, tt := range []struct {
+		block  int64
+		number uint64
+		nonce  uint64
+	}{
+		{block: -1, number: 1, nonce: 8}, // Head block
+		{block: 0, number: 0, nonce: 7},
+	} {
+		statedb, header, err := OpenForkState(db, tt.block)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(header.Number.Uint64()).Should(Equal(tt.number))
+
+		alloc, err := forkGenesisAlloc(statedb)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(alloc).Should(HaveLen(2))
+
+		eoa := alloc[forkedEOA]
+		Ω(eoa.Balance).Should(Equal(big.NewInt(1000)))
+		Ω(eoa.Nonce).Should(Equal(tt.nonce))
+		Ω(eoa.Code).Should(BeEmpty())
+		Ω(eoa.Storage).Should(BeEmpty())
+
+		contract := alloc[forkedContract]
+		Ω(contract.Balance).Should(Equal(big.NewInt(5)))
+		Ω(contract.Nonce).Should(Equal(uint64(1)))
+		Ω(contract.Code).Should(Equal([]byte{0x60, 0x00, 0x54, 0x00}))
+		Ω(contract.Storage).Should(Equal(map[common.Hash]common.Hash{
+			common.HexToHash("0x01"): common.HexToHash("0xdead"),
+			common.HexToHash("0x02"): common.HexToHash("0xbeef"),
+		}))
+	}
+
+	_, _, err = OpenForkState(db, 2)
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestForkGenesisAllocMissingPreimages(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Without preimages the accounts can't be dumped, and the allocation doesn't
+	// reproduce the state root
+	db := rawdb.NewMemoryDatabase()
+	statedb, err := state.New(common.Hash{}, state.NewDatabaseWithConfig(db, &trie.Config{Preimages: false}), nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	statedb.SetBalance(forkedEOA, big.NewInt(1000))
+	root, err := statedb.Commit(true)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(statedb.Database().TrieDB().Commit(root, false, nil)).Should(Succeed())
+
+	statedb, err = state.New(root, state.NewDatabase(db), nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	_, err = forkGenesisAlloc(statedb)
+	Ω(err).Should(HaveOccurred())
+}
+
+func TestDumpGenesisAllocWithoutNonces(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Generated genesis allocations are unchanged by the forking support
+	statedb, err := state.New(common.Hash{}, state.NewDatabaseWithConfig(rawdb.NewMemoryDatabase(), &trie.Config{Preimages: true}), nil)
+	Ω(err).ShouldNot(HaveOccurred())
+	statedb.SetBalance(forkedEOA, big.NewInt(1000))
+	statedb.SetNonce(forkedEOA, 3)
+	_, err = statedb.Commit(true)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	Ω(dumpGenesisAlloc(statedb)[forkedEOA].Nonce).Should(BeZero())
+}
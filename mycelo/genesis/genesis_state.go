@@ -179,6 +179,11 @@ func (ctx *deployContext) deploy() (core.GenesisAlloc, error) {
 		return nil, err
 	}
 
+	return dumpGenesisAlloc(ctx.statedb), nil
+}
+
+// dumpGenesisAlloc converts the committed state into a genesis allocation
+func dumpGenesisAlloc(statedb *state.StateDB) core.GenesisAlloc {
 	dumpConfig := state.DumpConfig{
 		SkipCode:          false,
 		SkipStorage:       false,
@@ -186,7 +191,7 @@ func (ctx *deployContext) deploy() (core.GenesisAlloc, error) {
 		Start:             nil,
 		Max:               0,
 	}
-	dump := ctx.statedb.RawDump(&dumpConfig).Accounts
+	dump := statedb.RawDump(&dumpConfig).Accounts
 	genesisAlloc := make(map[common.Address]core.GenesisAccount)
 	for acc, dumpAcc := range dump {
 		var account core.GenesisAccount
@@ -208,7 +213,7 @@ func (ctx *deployContext) deploy() (core.GenesisAlloc, error) {
 
 	}
 
-	return genesisAlloc, nil
+	return genesisAlloc
 }
 
 // Initialize Admin
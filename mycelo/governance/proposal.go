@@ -0,0 +1,221 @@
+package governance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/mycelo/contract"
+)
+
+// ProposalTransaction is one of the transactions executed by a proposal, in the
+// format used by celocli's `governance:propose --jsonTransactions`.
+//
+// The destination is either the core contract named by Contract, looked up in the
+// registry, or Address. The call data is either Data, or Function called with Args
+// using the core contract's ABI.
+type ProposalTransaction struct {
+	Contract string            `json:"contract,omitempty"`
+	Address  *common.Address   `json:"address,omitempty"`
+	Function string            `json:"function,omitempty"`
+	Args     []json.RawMessage `json:"args,omitempty"`
+	Data     hexutil.Bytes     `json:"data,omitempty"`
+	Value    *hexOrDecimal     `json:"value,omitempty"`
+}
+
+// hexOrDecimal is a big integer that accepts both JSON numbers and decimal or hex strings
+type hexOrDecimal big.Int
+
+func (h *hexOrDecimal) UnmarshalJSON(input []byte) error {
+	v, err := parseBigInt(json.RawMessage(input))
+	if err != nil {
+		return err
+	}
+	*h = hexOrDecimal(*v)
+	return nil
+}
+
+func (h *hexOrDecimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*big.Int)(h).String())
+}
+
+// LoadProposal reads a proposal's transactions from a JSON file
+func LoadProposal(path string) ([]ProposalTransaction, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var txs []ProposalTransaction
+	if err := json.Unmarshal(raw, &txs); err != nil {
+		return nil, fmt.Errorf("invalid proposal %s: %w", path, err)
+	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("proposal %s has no transactions", path)
+	}
+	return txs, nil
+}
+
+// encode returns the destination, value and call data of the transaction
+func (tx *ProposalTransaction) encode(registryLookup func(string) (common.Address, error)) (common.Address, *big.Int, []byte, error) {
+	value := new(big.Int)
+	if tx.Value != nil {
+		value = (*big.Int)(tx.Value)
+	}
+
+	var destination common.Address
+	switch {
+	case tx.Address != nil:
+		destination = *tx.Address
+	case tx.Contract != "":
+		var err error
+		if destination, err = registryLookup(tx.Contract); err != nil {
+			return common.Address{}, nil, nil, err
+		}
+	default:
+		return common.Address{}, nil, nil, fmt.Errorf("transaction has neither a contract nor an address")
+	}
+
+	if tx.Function == "" {
+		return destination, value, tx.Data, nil
+	}
+	if tx.Contract == "" {
+		return common.Address{}, nil, nil, fmt.Errorf("function %s needs a contract to find its ABI", tx.Function)
+	}
+	if len(tx.Data) > 0 {
+		return common.Address{}, nil, nil, fmt.Errorf("transaction has both data and a function")
+	}
+	if !hasABI(tx.Contract) {
+		return common.Address{}, nil, nil, fmt.Errorf("no ABI for contract %s", tx.Contract)
+	}
+	contractABI := contract.AbiFor(tx.Contract)
+	method, ok := contractABI.Methods[tx.Function]
+	if !ok {
+		return common.Address{}, nil, nil, fmt.Errorf("contract %s has no function %s", tx.Contract, tx.Function)
+	}
+	if len(tx.Args) != len(method.Inputs) {
+		return common.Address{}, nil, nil, fmt.Errorf("%s.%s takes %d arguments, got %d", tx.Contract, tx.Function, len(method.Inputs), len(tx.Args))
+	}
+	args := make([]interface{}, len(tx.Args))
+	for i, input := range method.Inputs {
+		arg, err := convertArg(input.Type, tx.Args[i])
+		if err != nil {
+			return common.Address{}, nil, nil, fmt.Errorf("%s.%s argument %s: %w", tx.Contract, tx.Function, input.Name, err)
+		}
+		args[i] = arg.Interface()
+	}
+	data, err := contractABI.Pack(tx.Function, args...)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return destination, value, data, nil
+}
+
+func hasABI(contractName string) bool {
+	for _, name := range contract.ContractNames() {
+		if name == contractName {
+			return true
+		}
+	}
+	return false
+}
+
+// convertArg converts a JSON value into the Go value the ABI packer expects for the type
+func convertArg(t abi.Type, raw json.RawMessage) (reflect.Value, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		v, err := parseBigInt(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if t.Size > 64 {
+			return reflect.ValueOf(v), nil
+		}
+		out := reflect.New(t.GetType()).Elem()
+		if t.T == abi.IntTy {
+			out.SetInt(v.Int64())
+		} else {
+			out.SetUint(v.Uint64())
+		}
+		return out, nil
+
+	case abi.BoolTy:
+		var b bool
+		err := json.Unmarshal(raw, &b)
+		return reflect.ValueOf(b), err
+
+	case abi.StringTy:
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return reflect.ValueOf(s), err
+
+	case abi.AddressTy:
+		var a common.Address
+		err := json.Unmarshal(raw, &a)
+		return reflect.ValueOf(a), err
+
+	case abi.BytesTy, abi.FixedBytesTy, abi.HashTy:
+		var b hexutil.Bytes
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return reflect.Value{}, err
+		}
+		if t.T == abi.BytesTy {
+			return reflect.ValueOf([]byte(b)), nil
+		}
+		out := reflect.New(t.GetType()).Elem()
+		if len(b) != out.Len() {
+			return reflect.Value{}, fmt.Errorf("expected %d bytes, got %d", out.Len(), len(b))
+		}
+		reflect.Copy(out, reflect.ValueOf([]byte(b)))
+		return out, nil
+
+	case abi.SliceTy, abi.ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return reflect.Value{}, err
+		}
+		var out reflect.Value
+		if t.T == abi.SliceTy {
+			out = reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		} else {
+			if len(elems) != t.Size {
+				return reflect.Value{}, fmt.Errorf("expected %d elements, got %d", t.Size, len(elems))
+			}
+			out = reflect.New(t.GetType()).Elem()
+		}
+		for i, elem := range elems {
+			v, err := convertArg(*t.Elem, elem)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			out.Index(i).Set(v)
+		}
+		return out, nil
+	}
+	return reflect.Value{}, fmt.Errorf("unsupported argument type %s", t.String())
+}
+
+// parseBigInt parses a JSON number, or a string holding a decimal or 0x prefixed hex number
+func parseBigInt(raw json.RawMessage) (*big.Int, error) {
+	s := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+	}
+	v, ok := new(big.Int), false
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, ok = v.SetString(s[2:], 16)
+	} else {
+		v, ok = v.SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", string(raw))
+	}
+	return v, nil
+}
@@ -0,0 +1,67 @@
+package governance
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/mycelo/contract"
+	. "github.com/onsi/gomega"
+)
+
+func TestProposalTransactionEncode(t *testing.T) {
+	RegisterTestingT(t)
+
+	governanceAddress := common.HexToAddress("0xd023")
+	lookup := func(name string) (common.Address, error) {
+		Ω(name).Should(Equal("Governance"))
+		return governanceAddress, nil
+	}
+
+	var txs []ProposalTransaction
+	err := json.Unmarshal([]byte(`[
+		{"contract": "Governance", "function": "setMinDeposit", "args": ["1000"]},
+		{"contract": "Governance", "function": "setConstitution", "args": ["0x000000000000000000000000000000000000d008", "0x12345678", 5]},
+		{"address": "0x000000000000000000000000000000000000abcd", "data": "0x01020304", "value": "0x10"}
+	]`), &txs)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	destination, value, data, err := txs[0].encode(lookup)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(destination).Should(Equal(governanceAddress))
+	Ω(value).Should(Equal(big.NewInt(0)))
+	expected, _ := contract.AbiFor("Governance").Pack("setMinDeposit", big.NewInt(1000))
+	Ω(data).Should(Equal(expected))
+
+	_, _, data, err = txs[1].encode(lookup)
+	Ω(err).ShouldNot(HaveOccurred())
+	expected, _ = contract.AbiFor("Governance").Pack("setConstitution", common.HexToAddress("0xd008"), [4]byte{0x12, 0x34, 0x56, 0x78}, big.NewInt(5))
+	Ω(data).Should(Equal(expected))
+
+	destination, value, data, err = txs[2].encode(lookup)
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(destination).Should(Equal(common.HexToAddress("0xabcd")))
+	Ω(value).Should(Equal(big.NewInt(16)))
+	Ω(data).Should(Equal([]byte{1, 2, 3, 4}))
+}
+
+func TestProposalTransactionEncodeErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	lookup := func(name string) (common.Address, error) { return common.HexToAddress("0x1"), nil }
+	for _, raw := range []string{
+		`{"data": "0x01"}`,
+		`{"address": "0x000000000000000000000000000000000000abcd", "function": "setMinDeposit", "args": ["1"]}`,
+		`{"contract": "Unknown", "function": "foo"}`,
+		`{"contract": "Governance", "function": "unknown"}`,
+		`{"contract": "Governance", "function": "setMinDeposit", "args": []}`,
+		`{"contract": "Governance", "function": "setMinDeposit", "args": ["abc"]}`,
+		`{"contract": "Governance", "function": "setConstitution", "args": ["0x000000000000000000000000000000000000d008", "0x12", 5]}`,
+	} {
+		var tx ProposalTransaction
+		Ω(json.Unmarshal([]byte(raw), &tx)).Should(Succeed(), raw)
+		_, _, _, err := tx.encode(lookup)
+		Ω(err).Should(HaveOccurred(), raw)
+	}
+}
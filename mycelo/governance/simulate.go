@@ -0,0 +1,558 @@
+package governance
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/vm"
+	"github.com/celo-org/celo-blockchain/core/vm/runtime"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/mycelo/contract"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+// stageNames are the stages of a proposal, as in the Proposals library
+var stageNames = []string{"None", "Queued", "Approval", "Referendum", "Execution", "Expiration"}
+
+const (
+	stageReferendum = 3
+	stageExecution  = 4
+
+	// voteYes is the Yes value of the Proposals library's VoteValue
+	voteYes = uint8(3)
+
+	// defaultBlockPeriod is used when the genesis has no istanbul config
+	defaultBlockPeriod = 5
+)
+
+// Report is the outcome of a simulated proposal
+type Report struct {
+	ProposalID *big.Int      `json:"proposalId"`
+	Steps      []Step        `json:"steps"`
+	VoteTotals *VoteTotals   `json:"voteTotals,omitempty"`
+	Executed   bool          `json:"executed"`
+	Reverts    []Revert      `json:"reverts,omitempty"`
+	StateDiff  []AccountDiff `json:"stateDiff,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// Step is a governance transaction sent during the simulation
+type Step struct {
+	Name  string         `json:"name"`
+	From  common.Address `json:"from"`
+	Block uint64         `json:"block"`
+	Time  uint64         `json:"time"`
+	Error string         `json:"error,omitempty"`
+}
+
+// VoteTotals are the referendum results of the proposal
+type VoteTotals struct {
+	Yes     *big.Int `json:"yes"`
+	No      *big.Int `json:"no"`
+	Abstain *big.Int `json:"abstain"`
+	Passing bool     `json:"passing"`
+}
+
+// Revert is a proposal transaction that fails when executed by Governance
+type Revert struct {
+	Index       int            `json:"index"`
+	Destination common.Address `json:"destination"`
+	Contract    string         `json:"contract,omitempty"`
+	Reason      string         `json:"reason"`
+}
+
+// AccountDiff is the change the proposal's execution made to an account
+type AccountDiff struct {
+	Address     common.Address  `json:"address"`
+	Contract    string          `json:"contract,omitempty"`
+	Balance     *BalanceChange  `json:"balance,omitempty"`
+	Nonce       *NonceChange    `json:"nonce,omitempty"`
+	CodeChanged bool            `json:"codeChanged,omitempty"`
+	Storage     []StorageChange `json:"storage,omitempty"`
+}
+
+// BalanceChange is a change of an account's CELO balance
+type BalanceChange struct {
+	From *big.Int `json:"from"`
+	To   *big.Int `json:"to"`
+}
+
+// NonceChange is a change of an account's nonce
+type NonceChange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// StorageChange is a change of a storage slot
+type StorageChange struct {
+	Key  common.Hash `json:"key"`
+	From common.Hash `json:"from"`
+	To   common.Hash `json:"to"`
+}
+
+// simulation runs a proposal through governance on top of a genesis state. Every
+// governance transaction is applied in its own block, and time is warped forward
+// between the proposal stages.
+type simulation struct {
+	accounts      *env.AccountsConfig
+	statedb       *state.StateDB
+	runtimeConfig *runtime.Config
+	blockPeriod   uint64
+	report        *Report
+	logger        log.Logger
+}
+
+// Simulate runs a proposal through propose, upvote, approval, referendum and execution,
+// starting from the genesis of an environment.
+//
+// The first developer account (or the admin if there are none) proposes and executes,
+// the first validator upvotes, the approver approves, and all validator and validator
+// group accounts vote yes. The simulation stops at the first failing step, which is
+// recorded in the returned report along with the referendum results, the state changes
+// of the execution and, if it fails, the reverts of the proposal's transactions.
+func Simulate(accounts *env.AccountsConfig, genesis *core.Genesis, txs []ProposalTransaction, descriptionURL string) (*Report, error) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return nil, err
+	}
+	for addr, account := range genesis.Alloc {
+		if account.Balance != nil {
+			statedb.AddBalance(addr, account.Balance)
+		}
+		statedb.SetCode(addr, account.Code)
+		statedb.SetNonce(addr, account.Nonce)
+		for key, value := range account.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+
+	blockPeriod := uint64(defaultBlockPeriod)
+	if genesis.Config != nil && genesis.Config.Istanbul != nil && genesis.Config.Istanbul.BlockPeriod > 0 {
+		blockPeriod = genesis.Config.Istanbul.BlockPeriod
+	}
+	adminAddress := accounts.AdminAccount().Address
+	s := &simulation{
+		accounts:    accounts,
+		statedb:     statedb,
+		blockPeriod: blockPeriod,
+		report:      &Report{},
+		logger:      log.New("obj", "governance-simulation"),
+		runtimeConfig: &runtime.Config{
+			ChainConfig: genesis.Config,
+			Origin:      adminAddress,
+			State:       statedb,
+			GasLimit:    params.DefaultGasLimit,
+			GasPrice:    big.NewInt(0),
+			Value:       big.NewInt(0),
+			Time:        new(big.Int).SetUint64(genesis.Timestamp),
+			Coinbase:    adminAddress,
+			BlockNumber: big.NewInt(0),
+			EVMConfig:   vm.Config{},
+		},
+	}
+	if err := s.run(txs, descriptionURL); err != nil {
+		s.logger.Error("Simulation stopped", "err", err)
+		s.report.Error = err.Error()
+	}
+	return s.report, nil
+}
+
+func (s *simulation) run(txs []ProposalTransaction, descriptionURL string) error {
+	governance, err := s.contract("Governance")
+	if err != nil {
+		return err
+	}
+	proposal, err := s.encodeProposal(txs)
+	if err != nil {
+		return err
+	}
+
+	proposer := s.accounts.AdminAccount().Address
+	if devAccounts := s.accounts.DeveloperAccounts(); len(devAccounts) > 0 {
+		proposer = devAccounts[0].Address
+	}
+
+	// Propose
+	var minDeposit *big.Int
+	if _, err := governance.Query(&minDeposit, "minDeposit"); err != nil {
+		return err
+	}
+	if balance := s.statedb.GetBalance(proposer); balance.Cmp(minDeposit) < 0 {
+		s.logger.Warn("Funding proposer with the deposit", "proposer", proposer, "deposit", minDeposit)
+		s.statedb.AddBalance(proposer, new(big.Int).Sub(minDeposit, balance))
+	}
+	err = s.step("propose", proposer, func() error {
+		_, err := governance.Call(contract.CallOpts{Origin: proposer, Value: minDeposit}, "propose",
+			proposal.values, proposal.destinations, proposal.data, proposal.dataLengths, descriptionURL)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	var proposalID *big.Int
+	if _, err := governance.Query(&proposalID, "proposalCount"); err != nil {
+		return err
+	}
+	s.report.ProposalID = proposalID
+	s.logger.Info("Proposal created", "id", proposalID, "transactions", len(txs))
+
+	// Upvote, then wait for the proposal to be dequeued
+	validators := s.accounts.ValidatorAccounts()
+	var queued bool
+	if _, err := governance.Query(&queued, "isQueued", proposalID); err != nil {
+		return err
+	}
+	if queued && len(validators) > 0 {
+		err := s.step("upvote", validators[0].Address, func() error {
+			return governance.SimpleCallFrom(validators[0].Address, "upvote", proposalID, common.Big0, common.Big0)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	var lastDequeue, dequeueFrequency *big.Int
+	if _, err := governance.Query(&lastDequeue, "lastDequeue"); err != nil {
+		return err
+	}
+	if _, err := governance.Query(&dequeueFrequency, "dequeueFrequency"); err != nil {
+		return err
+	}
+	s.warpTo(new(big.Int).Add(lastDequeue, dequeueFrequency).Uint64())
+	if err := s.step("dequeueProposalsIfReady", proposer, func() error {
+		return governance.SimpleCallFrom(proposer, "dequeueProposalsIfReady")
+	}); err != nil {
+		return err
+	}
+	index, err := s.dequeueIndex(governance, proposalID)
+	if err != nil {
+		return err
+	}
+
+	// Approve
+	var approver common.Address
+	if _, err := governance.Query(&approver, "approver"); err != nil {
+		return err
+	}
+	if err := s.step("approve", approver, func() error {
+		return governance.SimpleCallFrom(approver, "approve", proposalID, index)
+	}); err != nil {
+		return err
+	}
+
+	// Referendum
+	if err := s.warpToStage(governance, proposalID, stageReferendum, "getApprovalStageDuration"); err != nil {
+		return err
+	}
+	voters := append(validators, s.accounts.ValidatorGroupAccounts()...)
+	votes := 0
+	for _, voter := range voters {
+		voter := voter
+		err := s.step("vote", voter.Address, func() error {
+			return governance.SimpleCallFrom(voter.Address, "vote", proposalID, index, voteYes)
+		})
+		if err == nil {
+			votes++
+		}
+	}
+	if votes == 0 {
+		return fmt.Errorf("no account could vote on the proposal")
+	}
+
+	// Execution
+	if err := s.warpToStage(governance, proposalID, stageExecution, "getReferendumStageDuration"); err != nil {
+		return err
+	}
+	var totals [3]*big.Int
+	if _, err := governance.Query(&totals, "getVoteTotals", proposalID); err != nil {
+		return err
+	}
+	s.report.VoteTotals = &VoteTotals{Yes: totals[0], No: totals[1], Abstain: totals[2]}
+	if _, err := governance.Query(&s.report.VoteTotals.Passing, "isProposalPassing", proposalID); err != nil {
+		return err
+	}
+
+	before, err := s.dump()
+	if err != nil {
+		return err
+	}
+	// Each transaction is replayed on a copy of the state, to find which of them revert
+	preExecution := s.statedb.Copy()
+	err = s.step("execute", proposer, func() error {
+		return governance.SimpleCallFrom(proposer, "execute", proposalID, index)
+	})
+	if err != nil {
+		s.report.Reverts = s.findReverts(preExecution, governance.Address, proposal)
+		return err
+	}
+	s.report.Executed = true
+	after, err := s.dump()
+	if err != nil {
+		return err
+	}
+	s.report.StateDiff = s.diff(before, after)
+	return nil
+}
+
+// encodedProposal holds the arguments of Governance.propose
+type encodedProposal struct {
+	values       []*big.Int
+	destinations []common.Address
+	data         []byte
+	dataLengths  []*big.Int
+}
+
+func (s *simulation) encodeProposal(txs []ProposalTransaction) (*encodedProposal, error) {
+	proposal := &encodedProposal{}
+	for i, tx := range txs {
+		destination, value, data, err := tx.encode(s.registryAddress)
+		if err != nil {
+			return nil, fmt.Errorf("proposal transaction %d: %w", i, err)
+		}
+		proposal.values = append(proposal.values, value)
+		proposal.destinations = append(proposal.destinations, destination)
+		proposal.data = append(proposal.data, data...)
+		proposal.dataLengths = append(proposal.dataLengths, big.NewInt(int64(len(data))))
+	}
+	return proposal, nil
+}
+
+// step applies a governance transaction in a new block and records it in the report
+func (s *simulation) step(name string, from common.Address, apply func() error) error {
+	s.warpTo(s.runtimeConfig.Time.Uint64() + s.blockPeriod)
+	err := apply()
+	step := Step{
+		Name:  name,
+		From:  from,
+		Block: s.runtimeConfig.BlockNumber.Uint64(),
+		Time:  s.runtimeConfig.Time.Uint64(),
+	}
+	if err != nil {
+		step.Error = err.Error()
+		s.logger.Warn("Governance step failed", "step", name, "from", from, "block", step.Block, "err", err)
+	} else {
+		s.logger.Info("Governance step", "step", name, "from", from, "block", step.Block)
+	}
+	s.report.Steps = append(s.report.Steps, step)
+	return err
+}
+
+// warpTo moves the simulated chain forward to the given time, skipping the blocks
+// in between. Time never goes backwards.
+func (s *simulation) warpTo(timestamp uint64) {
+	now := s.runtimeConfig.Time.Uint64()
+	if timestamp <= now {
+		return
+	}
+	blocks := (timestamp - now + s.blockPeriod - 1) / s.blockPeriod
+	s.runtimeConfig.BlockNumber = new(big.Int).Add(s.runtimeConfig.BlockNumber, new(big.Int).SetUint64(blocks))
+	s.runtimeConfig.Time = new(big.Int).SetUint64(now + blocks*s.blockPeriod)
+}
+
+// warpToStage warps past the current stage of the proposal, whose duration is returned
+// by durationMethod, and checks it reached the expected stage
+func (s *simulation) warpToStage(governance *contract.EVMBackend, proposalID *big.Int, stage uint8, durationMethod string) error {
+	var duration *big.Int
+	if _, err := governance.Query(&duration, durationMethod); err != nil {
+		return err
+	}
+	timestamp, err := s.proposalTimestamp(governance, proposalID)
+	if err != nil {
+		return err
+	}
+	var elapsed uint64
+	if stage == stageExecution {
+		// The referendum starts once the approval stage ends
+		var approval *big.Int
+		if _, err := governance.Query(&approval, "getApprovalStageDuration"); err != nil {
+			return err
+		}
+		elapsed = approval.Uint64()
+	}
+	s.warpTo(timestamp.Uint64() + elapsed + duration.Uint64())
+
+	var current uint8
+	if _, err := governance.Query(&current, "getProposalStage", proposalID); err != nil {
+		return err
+	}
+	if current != stage {
+		return fmt.Errorf("proposal is in stage %s instead of %s", stageName(current), stageName(stage))
+	}
+	return nil
+}
+
+// proposalTimestamp returns the timestamp of the proposal, which is its dequeue time once dequeued
+func (s *simulation) proposalTimestamp(governance *contract.EVMBackend, proposalID *big.Int) (*big.Int, error) {
+	// proposer, deposit, timestamp, transaction count, description url
+	out := make([]interface{}, 5)
+	if _, err := governance.Query(&out, "getProposal", proposalID); err != nil {
+		return nil, err
+	}
+	timestamp, ok := out[2].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getProposal timestamp %v", out[2])
+	}
+	return timestamp, nil
+}
+
+func stageName(stage uint8) string {
+	if int(stage) < len(stageNames) {
+		return stageNames[stage]
+	}
+	return fmt.Sprintf("%d", stage)
+}
+
+// dequeueIndex returns the index of the proposal in the dequeued proposals
+func (s *simulation) dequeueIndex(governance *contract.EVMBackend, proposalID *big.Int) (*big.Int, error) {
+	var dequeued []*big.Int
+	if _, err := governance.Query(&dequeued, "getDequeue"); err != nil {
+		return nil, err
+	}
+	for i, id := range dequeued {
+		if id.Cmp(proposalID) == 0 {
+			return big.NewInt(int64(i)), nil
+		}
+	}
+	var stage uint8
+	if _, err := governance.Query(&stage, "getProposalStage", proposalID); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("proposal %s was not dequeued, stage: %s", proposalID, stageName(stage))
+}
+
+// findReverts replays the proposal's transactions one after another, as sent by
+// Governance, and returns the ones that fail
+func (s *simulation) findReverts(statedb *state.StateDB, governance common.Address, proposal *encodedProposal) []Revert {
+	names := s.contractNames()
+	var reverts []Revert
+	offset := uint64(0)
+	for i, destination := range proposal.destinations {
+		length := proposal.dataLengths[i].Uint64()
+		data := proposal.data[offset : offset+length]
+		offset += length
+
+		cfg := *s.runtimeConfig
+		cfg.State = statedb
+		cfg.Origin = governance
+		cfg.Value = proposal.values[i]
+		ret, _, err := runtime.Call(destination, data, &cfg)
+		if err == nil {
+			continue
+		}
+		reason := err.Error()
+		if revertReason, err := abi.UnpackRevert(ret); err == nil {
+			reason = "Revert: " + revertReason
+		}
+		reverts = append(reverts, Revert{Index: i, Destination: destination, Contract: names[destination], Reason: reason})
+	}
+	return reverts
+}
+
+// dump commits the state and returns all its accounts
+func (s *simulation) dump() (map[common.Address]state.DumpAccount, error) {
+	if _, err := s.statedb.Commit(true); err != nil {
+		return nil, err
+	}
+	return s.statedb.RawDump(&state.DumpConfig{OnlyWithAddresses: true}).Accounts, nil
+}
+
+// diff returns the changes between two dumps of the state
+func (s *simulation) diff(before, after map[common.Address]state.DumpAccount) []AccountDiff {
+	names := s.contractNames()
+	addresses := make(map[common.Address]bool)
+	for addr := range before {
+		addresses[addr] = true
+	}
+	for addr := range after {
+		addresses[addr] = true
+	}
+
+	var diffs []AccountDiff
+	for addr := range addresses {
+		from, to := before[addr], after[addr]
+		diff := AccountDiff{Address: addr, Contract: names[addr]}
+		if from.Balance != to.Balance {
+			diff.Balance = &BalanceChange{From: parseBalance(from.Balance), To: parseBalance(to.Balance)}
+		}
+		if from.Nonce != to.Nonce {
+			diff.Nonce = &NonceChange{From: from.Nonce, To: to.Nonce}
+		}
+		diff.CodeChanged = string(from.CodeHash) != string(to.CodeHash)
+
+		keys := make(map[common.Hash]bool)
+		for key := range from.Storage {
+			keys[key] = true
+		}
+		for key := range to.Storage {
+			keys[key] = true
+		}
+		for key := range keys {
+			fromValue, toValue := common.HexToHash(from.Storage[key]), common.HexToHash(to.Storage[key])
+			if fromValue != toValue {
+				diff.Storage = append(diff.Storage, StorageChange{Key: key, From: fromValue, To: toValue})
+			}
+		}
+		sortStorageChanges(diff.Storage)
+
+		if diff.Balance != nil || diff.Nonce != nil || diff.CodeChanged || len(diff.Storage) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	sortAccountDiffs(diffs)
+	return diffs
+}
+
+func parseBalance(balance string) *big.Int {
+	value, ok := new(big.Int).SetString(balance, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return value
+}
+
+// contractNames maps the addresses registered in the registry to their names
+func (s *simulation) contractNames() map[common.Address]string {
+	names := make(map[common.Address]string)
+	for _, name := range contract.ContractNames() {
+		if address, err := s.registryAddress(name); err == nil {
+			names[address] = name
+		}
+	}
+	return names
+}
+
+// registryAddress returns the address registered for the contract
+func (s *simulation) registryAddress(contractName string) (common.Address, error) {
+	var address common.Address
+	registry := contract.CoreContract(s.runtimeConfig, "Registry", params.RegistrySmartContractAddress)
+	if _, err := registry.Query(&address, "getAddressForString", contractName); err != nil {
+		return common.Address{}, fmt.Errorf("registry lookup for %s failed: %w", contractName, err)
+	}
+	if address == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("contract %s is not registered", contractName)
+	}
+	return address, nil
+}
+
+func (s *simulation) contract(contractName string) (*contract.EVMBackend, error) {
+	address, err := s.registryAddress(contractName)
+	if err != nil {
+		return nil, err
+	}
+	return contract.CoreContract(s.runtimeConfig, contractName, address), nil
+}
+
+func sortAccountDiffs(diffs []AccountDiff) {
+	sort.Slice(diffs, func(i, j int) bool { return bytes.Compare(diffs[i].Address[:], diffs[j].Address[:]) < 0 })
+}
+
+func sortStorageChanges(changes []StorageChange) {
+	sort.Slice(changes, func(i, j int) bool { return bytes.Compare(changes[i].Key[:], changes[j].Key[:]) < 0 })
+}
@@ -3,19 +3,17 @@ package loadbot
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"sync"
 	"time"
 
 	bind "github.com/celo-org/celo-blockchain/accounts/abi/bind_v2"
 	"github.com/celo-org/celo-blockchain/common"
-
+	"github.com/celo-org/celo-blockchain/core/types"
 	"github.com/celo-org/celo-blockchain/ethclient"
-	"github.com/celo-org/celo-blockchain/mycelo/contract"
 	"github.com/celo-org/celo-blockchain/mycelo/env"
-	"golang.org/x/sync/errgroup"
 )
 
 // 110k gas for stable token transfer is pretty reasonable. It's just under 100k in practice
@@ -37,6 +35,8 @@ type txConfig struct {
 	Verbose           bool
 	SkipGasEstimation bool
 	MixFeeCurrency    bool
+	Workload          WorkloadKind
+	StorageContract   common.Address
 }
 
 // Config represent the load bot run configuration
@@ -50,10 +50,34 @@ type Config struct {
 	MaxPending            uint64
 	SkipGasEstimation     bool
 	MixFeeCurrency        bool
+	// Workloads is the weighted mix of transactions to send, cUSD transfers only if empty
+	Workloads []Workload
+	// DrainTimeout is how long to wait for in-flight transactions once the context is done
+	DrainTimeout time.Duration
 }
 
-// Start will start loads bots
-func Start(ctx context.Context, cfg *Config) error {
+// DefaultWorkloads is the workload mix used when none is configured
+var DefaultWorkloads = []Workload{{Kind: CUSDTransfer, Weight: 1}}
+
+// Start will start loads bots, until the context is done. It returns the report
+// of the transactions sent, once the in-flight ones are mined or DrainTimeout passes.
+func Start(ctx context.Context, cfg *Config) (*Report, error) {
+	workloads := cfg.Workloads
+	if len(workloads) == 0 {
+		workloads = DefaultWorkloads
+	}
+	if totalWeight(workloads) <= 0 {
+		return nil, errors.New("no workload with a positive weight")
+	}
+
+	var storageContract common.Address
+	if hasWorkload(workloads, StorageCall) {
+		var err error
+		if storageContract, err = deployStorageContract(ctx, cfg.Clients[0], cfg.ChainID, cfg.Accounts[0]); err != nil {
+			return nil, fmt.Errorf("failed to deploy the storage contract: %v", err)
+		}
+	}
+
 	// Set up nonces, we have to manage nonces because calling PendingNonceAt
 	// is racy and often results in using the same nonce more than once when
 	// applying heavy load.
@@ -61,7 +85,7 @@ func Start(ctx context.Context, cfg *Config) error {
 	for i, a := range cfg.Accounts {
 		nonce, err := cfg.Clients[0].PendingNonceAt(ctx, a.Address)
 		if err != nil {
-			return fmt.Errorf("failed to retrieve pending nonce for account %s: %v", a.Address.String(), err)
+			return nil, fmt.Errorf("failed to retrieve pending nonce for account %s: %v", a.Address.String(), err)
 		}
 		nonces[i] = nonce
 	}
@@ -71,13 +95,22 @@ func Start(ctx context.Context, cfg *Config) error {
 	sendIdx := 0
 	clientIdx := 0
 
+	// In-flight transactions outlive the submission context, to measure their inclusion
+	waitCtx, cancelWait := context.WithCancel(context.Background())
+	defer cancelWait()
+
 	// Fire off transactions
 	period := 1 * time.Second / time.Duration(cfg.TransactionsPerSecond)
 	ticker := time.NewTicker(period)
-	group, ctx := errgroup.WithContext(ctx)
+	defer ticker.Stop()
+	var group sync.WaitGroup
 	lg := &LoadGenerator{
 		MaxPending: cfg.MaxPending,
 	}
+	txStats := newStats(workloads)
+	// A transaction that couldn't be sent leaves a gap in its sender's nonces
+	resync := make(chan int, len(cfg.Accounts))
+	txStats.started(time.Now())
 	for {
 		select {
 		case <-ticker.C:
@@ -94,31 +127,55 @@ func Start(ctx context.Context, cfg *Config) error {
 			recipient := cfg.Accounts[recvIdx%len(cfg.Accounts)].Address
 
 			sendIdx++
-			sender := cfg.Accounts[sendIdx%len(cfg.Accounts)]
-			nonce := nonces[sendIdx%len(cfg.Accounts)]
-			nonces[sendIdx%len(cfg.Accounts)]++
+			senderIdx := sendIdx % len(cfg.Accounts)
+			sender := cfg.Accounts[senderIdx]
+			nonce := nonces[senderIdx]
+			nonces[senderIdx]++
 
 			clientIdx++
 			client := cfg.Clients[clientIdx%len(cfg.Clients)]
-			group.Go(func() error {
-				txCfg := txConfig{
-					Acc:               sender,
-					Nonce:             nonce,
-					Recipient:         recipient,
-					Value:             cfg.Amount,
-					Verbose:           cfg.Verbose,
-					SkipGasEstimation: cfg.SkipGasEstimation,
-					MixFeeCurrency:    cfg.MixFeeCurrency,
+			txCfg := txConfig{
+				Acc:               sender,
+				Nonce:             nonce,
+				Recipient:         recipient,
+				Value:             cfg.Amount,
+				Verbose:           cfg.Verbose,
+				SkipGasEstimation: cfg.SkipGasEstimation,
+				MixFeeCurrency:    cfg.MixFeeCurrency,
+				Workload:          pickWorkload(workloads),
+				StorageContract:   storageContract,
+			}
+			group.Add(1)
+			go func() {
+				defer group.Done()
+				if !runTransaction(ctx, waitCtx, client, cfg.ChainID, lg, txStats, txCfg) {
+					select {
+					case resync <- senderIdx:
+					default:
+					}
 				}
-				return runTransaction(ctx, client, cfg.ChainID, lg, txCfg)
-			})
+			}()
+		case idx := <-resync:
+			if nonce, err := cfg.Clients[0].PendingNonceAt(ctx, cfg.Accounts[idx].Address); err == nil {
+				nonces[idx] = nonce
+			}
 		case <-ctx.Done():
-			return group.Wait()
+			txStats.stopped(time.Now())
+			if cfg.DrainTimeout > 0 {
+				time.AfterFunc(cfg.DrainTimeout, cancelWait)
+			} else {
+				cancelWait()
+			}
+			group.Wait()
+			return txStats.report(), nil
 		}
 	}
 }
 
-func runTransaction(ctx context.Context, client *ethclient.Client, chainID *big.Int, lg *LoadGenerator, txCfg txConfig) error {
+// runTransaction sends a transaction and waits for it to be mined, recording the outcome.
+// Failures don't stop the load bot, they are reported instead. It returns false if the
+// transaction couldn't be sent.
+func runTransaction(ctx, waitCtx context.Context, client *ethclient.Client, chainID *big.Int, lg *LoadGenerator, txStats *stats, txCfg txConfig) bool {
 	defer func() {
 		lg.PendingMu.Lock()
 		if lg.MaxPending != 0 {
@@ -127,47 +184,38 @@ func runTransaction(ctx context.Context, client *ethclient.Client, chainID *big.
 		lg.PendingMu.Unlock()
 	}()
 
-	abi := contract.AbiFor("StableToken")
-	stableToken := bind.NewBoundContract(env.MustProxyAddressFor("StableToken"), *abi, client)
-
-	transactor, _ := bind.NewKeyedTransactorWithChainID(txCfg.Acc.PrivateKey, chainID)
-	transactor.Context = ctx
-	transactor.ChainID = chainID
-	transactor.Nonce = new(big.Int).SetUint64(txCfg.Nonce)
-
-	stableTokenAddress := env.MustProxyAddressFor("StableToken")
-
-	if n := rand.Intn(2); txCfg.MixFeeCurrency && n == 0 {
-		transactor.FeeCurrency = nil
-
-	} else {
-		transactor.FeeCurrency = &stableTokenAddress
-	}
-	if txCfg.SkipGasEstimation {
-		transactor.GasLimit = GasForTransferWithComment
-	}
-
-	tx, err := stableToken.TxObj(transactor, "transferWithComment", txCfg.Recipient, txCfg.Value, "need to proivde some long comment to make it similar to an encrypted comment").Send()
+	submitted := time.Now()
+	tx, err := sendWorkload(ctx, client, chainID, txCfg)
 	if err != nil {
-		if err != context.Canceled {
+		// Transactions cut short by the end of the run are not failures
+		if ctx.Err() == nil {
 			fmt.Printf("Error sending transaction: %v\n", err)
+			txStats.failed(txCfg.Workload, failureReasonOf(err))
 		}
-		return fmt.Errorf("Error sending transaction: %w", err)
+		return false
 	}
+	txStats.submitted(txCfg.Workload)
 	if txCfg.Verbose {
-		fmt.Printf("cusd transfer generated: from: %s to: %s amount: %s\ttxhash: %s\n", txCfg.Acc.Address.Hex(), txCfg.Recipient.Hex(), txCfg.Value.String(), tx.Transaction.Hash().Hex())
+		fmt.Printf("%s tx generated: from: %s to: %s amount: %s\ttxhash: %s\n", txCfg.Workload, txCfg.Acc.Address.Hex(), txCfg.Recipient.Hex(), txCfg.Value.String(), tx.Hash().Hex())
 		printJSON(tx)
 	}
 
-	_, err = tx.WaitMined(ctx)
-
+	receipt, err := bind.WaitMined(waitCtx, client, tx)
 	if err != nil {
-		if err != context.Canceled {
+		if waitCtx.Err() != nil {
+			txStats.unconfirmed(txCfg.Workload)
+		} else {
 			fmt.Printf("Error waiting for tx: %v\n", err)
+			txStats.failed(txCfg.Workload, failureReasonOf(err))
 		}
-		return fmt.Errorf("Error waiting for tx: %w", err)
+		return true
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		txStats.failed(txCfg.Workload, FailureReverted)
+		return true
 	}
-	return err
+	txStats.included(txCfg.Workload, time.Since(submitted))
+	return true
 }
 
 func printJSON(obj interface{}) {
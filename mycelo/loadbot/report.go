@@ -0,0 +1,252 @@
+package loadbot
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stats collects the outcome of every transaction sent by the load bot
+type stats struct {
+	mu        sync.Mutex
+	start     time.Time
+	end       time.Time
+	workloads map[WorkloadKind]*workloadStats
+}
+
+type workloadStats struct {
+	submitted   int
+	unconfirmed int
+	latencies   []time.Duration
+	failures    map[FailureReason]int
+}
+
+// FailureReason is the category of the failure of a transaction in the report
+type FailureReason string
+
+const (
+	// FailureNonce is a transaction rejected for its nonce, or already known
+	FailureNonce FailureReason = "nonce"
+	// FailureUnderpriced is a transaction rejected for its gas price or fee cap
+	FailureUnderpriced FailureReason = "underpriced"
+	// FailureFunds is a transaction rejected for the balance of its sender
+	FailureFunds FailureReason = "funds"
+	// FailureTimeout is a request to the node which timed out
+	FailureTimeout FailureReason = "timeout"
+	// FailureReverted is a transaction mined with a failed status
+	FailureReverted FailureReason = "reverted"
+	// FailureOther is any other error
+	FailureOther FailureReason = "other"
+)
+
+// failureReasonOf categorizes an error sending or waiting for a transaction. The
+// errors of the node come as text through RPC, so they're matched by message.
+func failureReasonOf(err error) FailureReason {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return FailureTimeout
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "nonce too"), strings.Contains(msg, "already known"):
+		return FailureNonce
+	case strings.Contains(msg, "underpriced"), strings.Contains(msg, "gas price minimum"), strings.Contains(msg, "less than block base fee"):
+		return FailureUnderpriced
+	case strings.Contains(msg, "insufficient funds"):
+		return FailureFunds
+	case strings.Contains(msg, "timeout"), strings.Contains(msg, "deadline exceeded"):
+		return FailureTimeout
+	}
+	return FailureOther
+}
+
+func newStats(workloads []Workload) *stats {
+	s := &stats{workloads: make(map[WorkloadKind]*workloadStats)}
+	for _, w := range workloads {
+		s.workloads[w.Kind] = &workloadStats{failures: make(map[FailureReason]int)}
+	}
+	return s
+}
+
+func (s *stats) started(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start = now
+}
+
+func (s *stats) stopped(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.end = now
+}
+
+func (s *stats) submitted(kind WorkloadKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workloads[kind].submitted++
+}
+
+// included records a transaction mined successfully, with its submission-to-inclusion latency
+func (s *stats) included(kind WorkloadKind, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workloads[kind].latencies = append(s.workloads[kind].latencies, latency)
+}
+
+func (s *stats) failed(kind WorkloadKind, reason FailureReason) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workloads[kind].failures[reason]++
+}
+
+// unconfirmed records a transaction still pending when the load bot stopped
+func (s *stats) unconfirmed(kind WorkloadKind) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workloads[kind].unconfirmed++
+}
+
+// Report summarizes a load bot run
+type Report struct {
+	Start     time.Time        `json:"start"`
+	End       time.Time        `json:"end"`
+	Duration  float64          `json:"durationSeconds"`
+	Workloads []WorkloadReport `json:"workloads"`
+}
+
+// WorkloadReport summarizes the transactions of a workload. Latencies go from the
+// submission of the transaction until its receipt was seen, in milliseconds.
+type WorkloadReport struct {
+	Workload     WorkloadKind          `json:"workload"`
+	Submitted    int                   `json:"submitted"`
+	Included     int                   `json:"included"`
+	Failed       int                   `json:"failed"`
+	Unconfirmed  int                   `json:"unconfirmed"`
+	SubmittedTPS float64               `json:"submittedTps"`
+	AchievedTPS  float64               `json:"achievedTps"`
+	LatencyP50   float64               `json:"latencyP50Ms"`
+	LatencyP90   float64               `json:"latencyP90Ms"`
+	LatencyP99   float64               `json:"latencyP99Ms"`
+	LatencyMax   float64               `json:"latencyMaxMs"`
+	Failures     map[FailureReason]int `json:"failures"`
+}
+
+// report builds the report of the transactions recorded so far
+func (s *stats) report() *Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := &Report{Start: s.start, End: s.end, Duration: s.end.Sub(s.start).Seconds()}
+	for kind, ws := range s.workloads {
+		latencies := make([]time.Duration, len(ws.latencies))
+		copy(latencies, ws.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		wr := WorkloadReport{
+			Workload:    kind,
+			Submitted:   ws.submitted,
+			Included:    len(latencies),
+			Unconfirmed: ws.unconfirmed,
+			LatencyP50:  milliseconds(percentile(latencies, 50)),
+			LatencyP90:  milliseconds(percentile(latencies, 90)),
+			LatencyP99:  milliseconds(percentile(latencies, 99)),
+			LatencyMax:  milliseconds(percentile(latencies, 100)),
+			Failures:    make(map[FailureReason]int),
+		}
+		for reason, count := range ws.failures {
+			wr.Failed += count
+			wr.Failures[reason] = count
+		}
+		if r.Duration > 0 {
+			wr.SubmittedTPS = float64(wr.Submitted) / r.Duration
+			wr.AchievedTPS = float64(wr.Included) / r.Duration
+		}
+		r.Workloads = append(r.Workloads, wr)
+	}
+	sort.Slice(r.Workloads, func(i, j int) bool { return r.Workloads[i].Workload < r.Workloads[j].Workload })
+	return r
+}
+
+// percentile returns the nearest-rank percentile p of the sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// WriteJSON writes the report to the given file as JSON
+func (r *Report) WriteJSON(path string) error {
+	raw, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, raw, 0644)
+}
+
+// WriteCSV writes the report to the given file as CSV, one row per workload.
+// Failure reasons are joined in a single column as "reason=count" pairs.
+func (r *Report) WriteCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	header := []string{"workload", "submitted", "included", "failed", "unconfirmed", "submitted_tps", "achieved_tps",
+		"latency_p50_ms", "latency_p90_ms", "latency_p99_ms", "latency_max_ms", "failures"}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, wr := range r.Workloads {
+		reasons := make([]string, 0, len(wr.Failures))
+		for reason, count := range wr.Failures {
+			reasons = append(reasons, fmt.Sprintf("%s=%d", reason, count))
+		}
+		sort.Strings(reasons)
+		record := []string{
+			string(wr.Workload),
+			strconv.Itoa(wr.Submitted),
+			strconv.Itoa(wr.Included),
+			strconv.Itoa(wr.Failed),
+			strconv.Itoa(wr.Unconfirmed),
+			formatFloat(wr.SubmittedTPS),
+			formatFloat(wr.AchievedTPS),
+			formatFloat(wr.LatencyP50),
+			formatFloat(wr.LatencyP90),
+			formatFloat(wr.LatencyP99),
+			formatFloat(wr.LatencyMax),
+			strings.Join(reasons, ";"),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 3, 64)
+}
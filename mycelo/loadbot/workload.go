@@ -0,0 +1,264 @@
+package loadbot
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/celo-org/celo-blockchain/accounts/abi"
+	bind "github.com/celo-org/celo-blockchain/accounts/abi/bind_v2"
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core/types"
+	"github.com/celo-org/celo-blockchain/ethclient"
+	"github.com/celo-org/celo-blockchain/mycelo/contract"
+	"github.com/celo-org/celo-blockchain/mycelo/env"
+	"github.com/celo-org/celo-blockchain/params"
+)
+
+// WorkloadKind identifies a type of transaction generated by the load bot
+type WorkloadKind string
+
+const (
+	// NativeTransfer is a plain CELO value transfer
+	NativeTransfer WorkloadKind = "native"
+	// CELOTransfer is a GoldToken transferWithComment, paying fees in CELO
+	CELOTransfer WorkloadKind = "transfer-celo"
+	// CUSDTransfer is a StableToken transferWithComment, paying fees in cUSD
+	CUSDTransfer WorkloadKind = "transfer-cusd"
+	// CEURTransfer is a StableTokenEUR transferWithComment, paying fees in cEUR
+	CEURTransfer WorkloadKind = "transfer-ceur"
+	// ContractDeploy deploys a small contract
+	ContractDeploy WorkloadKind = "deploy"
+	// StorageCall calls a contract that writes StorageSlotsPerCall new storage slots
+	StorageCall WorkloadKind = "storage"
+	// GatewayFeeTransfer is a cUSD transfer paying a gateway fee to the recipient
+	GatewayFeeTransfer WorkloadKind = "gatewayfee"
+	// DynamicFeeTransfer is a native transfer sent as a CeloDynamicFeeTx (requires the Espresso hardfork)
+	DynamicFeeTransfer WorkloadKind = "dynamicfee"
+)
+
+// AllWorkloadKinds lists every supported workload kind
+var AllWorkloadKinds = []WorkloadKind{
+	NativeTransfer, CELOTransfer, CUSDTransfer, CEURTransfer,
+	ContractDeploy, StorageCall, GatewayFeeTransfer, DynamicFeeTransfer,
+}
+
+// tokenTransfers maps the token transfer workloads to the token they transfer and pay fees with
+var tokenTransfers = map[WorkloadKind]string{
+	CELOTransfer:       "GoldToken",
+	CUSDTransfer:       "StableToken",
+	CEURTransfer:       "StableTokenEUR",
+	GatewayFeeTransfer: "StableToken",
+}
+
+const (
+	// StorageSlotsPerCall is the number of new storage slots written by each StorageCall
+	StorageSlotsPerCall = 10
+	// GasForStorageCall is the hardcoded gas used for a StorageCall when skipping gas estimation
+	GasForStorageCall = 50000 + StorageSlotsPerCall*25000
+	// GasForContractDeploy is the hardcoded gas used for a ContractDeploy when skipping gas estimation
+	GasForContractDeploy = 100000
+)
+
+// GatewayFee is the fee paid, in wei of the fee currency, by each GatewayFeeTransfer
+var GatewayFee = big.NewInt(10000)
+
+// storageContractCode is the init code of a contract whose runtime code, when called with
+// a uint256 n, writes n new storage slots after the last one written (counter kept at slot 0).
+//
+//	  SLOAD(0) CALLDATALOAD(0)
+//	loop:
+//	  if n == 0 goto end
+//	  c = c + 1; SSTORE(c, c); n = n - 1
+//	  goto loop
+//	end:
+//	  SSTORE(0, c) STOP
+var storageContractCode = common.FromHex("0x602180600b6000396000f3" + // codecopy & return the runtime code
+	"600054600035" + "5b8015601b57" + "9060010180805590600190036006565b5060005500")
+
+// Workload is a kind of transaction with the relative weight it has in the load
+type Workload struct {
+	Kind   WorkloadKind
+	Weight int
+}
+
+// ParseWorkloads parses a workload mix such as "transfer-cusd=3,native=1,deploy=1".
+// A kind with no weight has weight 1.
+func ParseWorkloads(spec string) ([]Workload, error) {
+	var workloads []Workload
+	seen := make(map[WorkloadKind]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		kind := WorkloadKind(strings.TrimSpace(parts[0]))
+		if !kind.valid() {
+			return nil, fmt.Errorf("unknown workload %q, valid workloads are %s", kind, workloadKindNames())
+		}
+		if seen[kind] {
+			return nil, fmt.Errorf("duplicated workload %q", kind)
+		}
+		seen[kind] = true
+		weight := 1
+		if len(parts) == 2 {
+			var err error
+			if weight, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight for workload %q: %q", kind, parts[1])
+			}
+		}
+		workloads = append(workloads, Workload{Kind: kind, Weight: weight})
+	}
+	if totalWeight(workloads) == 0 {
+		return nil, fmt.Errorf("workload mix %q has no workload with a positive weight", spec)
+	}
+	return workloads, nil
+}
+
+func (k WorkloadKind) valid() bool {
+	for _, kind := range AllWorkloadKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func workloadKindNames() string {
+	names := make([]string, len(AllWorkloadKinds))
+	for i, kind := range AllWorkloadKinds {
+		names[i] = string(kind)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func totalWeight(workloads []Workload) int {
+	total := 0
+	for _, w := range workloads {
+		total += w.Weight
+	}
+	return total
+}
+
+// pickWorkload selects a workload at random, proportionally to the weights. It
+// defaults to the first workload if none has a positive weight.
+func pickWorkload(workloads []Workload) WorkloadKind {
+	if total := totalWeight(workloads); total > 0 {
+		n := rand.Intn(total)
+		for _, w := range workloads {
+			if n < w.Weight {
+				return w.Kind
+			}
+			n -= w.Weight
+		}
+	}
+	return workloads[0].Kind
+}
+
+func hasWorkload(workloads []Workload, kind WorkloadKind) bool {
+	for _, w := range workloads {
+		if w.Kind == kind && w.Weight > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// deployStorageContract deploys the contract used by the StorageCall workload and waits for it
+func deployStorageContract(ctx context.Context, client *ethclient.Client, chainID *big.Int, acc env.Account) (common.Address, error) {
+	transactor, _ := bind.NewKeyedTransactorWithChainID(acc.PrivateKey, chainID)
+	transactor.Context = ctx
+	_, tx, _, err := bind.DeployContract(transactor, abi.ABI{}, storageContractCode, client)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return bind.WaitDeployed(ctx, client, tx)
+}
+
+// sendWorkload signs and sends the transaction of the configured workload
+func sendWorkload(ctx context.Context, client *ethclient.Client, chainID *big.Int, txCfg txConfig) (*types.Transaction, error) {
+	if txCfg.Workload == DynamicFeeTransfer {
+		return sendDynamicFeeTransfer(ctx, client, chainID, txCfg)
+	}
+
+	transactor, _ := bind.NewKeyedTransactorWithChainID(txCfg.Acc.PrivateKey, chainID)
+	transactor.Context = ctx
+	transactor.ChainID = chainID
+	transactor.Nonce = new(big.Int).SetUint64(txCfg.Nonce)
+
+	switch txCfg.Workload {
+	case NativeTransfer:
+		transactor.Value = txCfg.Value
+		transactor.GasLimit = params.TxGas
+		return bind.NewBoundContract(txCfg.Recipient, abi.ABI{}, client).Transfer(transactor)
+
+	case CELOTransfer, CUSDTransfer, CEURTransfer, GatewayFeeTransfer:
+		tokenAddress := env.MustProxyAddressFor(tokenTransfers[txCfg.Workload])
+		if txCfg.Workload != CELOTransfer && !(txCfg.MixFeeCurrency && rand.Intn(2) == 0) {
+			transactor.FeeCurrency = &tokenAddress
+			gasPrice, err := client.SuggestGasPriceInCurrency(ctx, &tokenAddress)
+			if err != nil {
+				return nil, err
+			}
+			transactor.GasPrice = gasPrice
+		}
+		if txCfg.Workload == GatewayFeeTransfer {
+			transactor.GatewayFeeRecipient = &txCfg.Recipient
+			transactor.GatewayFee = GatewayFee
+		}
+		if txCfg.SkipGasEstimation {
+			transactor.GasLimit = GasForTransferWithComment
+		}
+		token := bind.NewBoundContract(tokenAddress, *contract.AbiFor(tokenTransfers[txCfg.Workload]), client)
+		return token.Transact(transactor, "transferWithComment", txCfg.Recipient, txCfg.Value, "need to proivde some long comment to make it similar to an encrypted comment")
+
+	case ContractDeploy:
+		if txCfg.SkipGasEstimation {
+			transactor.GasLimit = GasForContractDeploy
+		}
+		_, tx, _, err := bind.DeployContract(transactor, abi.ABI{}, storageContractCode, client)
+		return tx, err
+
+	case StorageCall:
+		if txCfg.SkipGasEstimation {
+			transactor.GasLimit = GasForStorageCall
+		}
+		slots := common.BigToHash(big.NewInt(StorageSlotsPerCall))
+		return bind.NewBoundContract(txCfg.StorageContract, abi.ABI{}, client).RawTransact(transactor, slots.Bytes())
+	}
+	return nil, fmt.Errorf("unknown workload %q", txCfg.Workload)
+}
+
+// sendDynamicFeeTransfer sends a native transfer as a CeloDynamicFeeTx. The bound
+// contracts only build legacy transactions, so it's assembled and signed here.
+func sendDynamicFeeTransfer(ctx context.Context, client *ethclient.Client, chainID *big.Int, txCfg txConfig) (*types.Transaction, error) {
+	gasPrice, err := client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasTipCap, err := client.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Leave room for the gas price minimum to double before the tx becomes unminable
+	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(gasPrice, big.NewInt(2)))
+	tx, err := types.SignTx(types.NewTx(&types.CeloDynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     txCfg.Nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       params.TxGas,
+		To:        &txCfg.Recipient,
+		Value:     txCfg.Value,
+	}), types.LatestSignerForChainID(chainID), txCfg.Acc.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return tx, client.SendTransaction(ctx, tx)
+}
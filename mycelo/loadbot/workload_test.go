@@ -0,0 +1,121 @@
+package loadbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/common"
+	"github.com/celo-org/celo-blockchain/core/rawdb"
+	"github.com/celo-org/celo-blockchain/core/state"
+	"github.com/celo-org/celo-blockchain/core/vm/runtime"
+	. "github.com/onsi/gomega"
+)
+
+func TestParseWorkloads(t *testing.T) {
+	RegisterTestingT(t)
+
+	workloads, err := ParseWorkloads("transfer-cusd=3, native ,dynamicfee=0")
+	Ω(err).ShouldNot(HaveOccurred())
+	Ω(workloads).Should(Equal([]Workload{
+		{Kind: CUSDTransfer, Weight: 3},
+		{Kind: NativeTransfer, Weight: 1},
+		{Kind: DynamicFeeTransfer, Weight: 0},
+	}))
+	Ω(hasWorkload(workloads, DynamicFeeTransfer)).Should(BeFalse())
+	for i := 0; i < 100; i++ {
+		Ω(pickWorkload(workloads)).ShouldNot(Equal(DynamicFeeTransfer))
+	}
+
+	for _, spec := range []string{"unknown=1", "native=-1", "native=x", "native,native", "deploy=0", ""} {
+		_, err := ParseWorkloads(spec)
+		Ω(err).Should(HaveOccurred(), spec)
+	}
+}
+
+func TestStorageContract(t *testing.T) {
+	RegisterTestingT(t)
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	cfg := &runtime.Config{State: statedb}
+	_, address, _, err := runtime.Create(storageContractCode, cfg)
+	Ω(err).ShouldNot(HaveOccurred())
+
+	slots := common.BigToHash(big.NewInt(StorageSlotsPerCall)).Bytes()
+	for i := 0; i < 2; i++ {
+		_, _, err = runtime.Call(address, slots, cfg)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	// The counter at slot 0 and every slot written so far
+	Ω(statedb.GetState(address, common.Hash{})).Should(Equal(common.BigToHash(big.NewInt(2 * StorageSlotsPerCall))))
+	for i := int64(1); i <= 2*StorageSlotsPerCall; i++ {
+		Ω(statedb.GetState(address, common.BigToHash(big.NewInt(i)))).Should(Equal(common.BigToHash(big.NewInt(i))))
+	}
+	Ω(statedb.GetState(address, common.BigToHash(big.NewInt(2*StorageSlotsPerCall+1)))).Should(Equal(common.Hash{}))
+}
+
+func TestReport(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := newStats([]Workload{{Kind: NativeTransfer, Weight: 1}, {Kind: ContractDeploy, Weight: 1}})
+	start := time.Now()
+	s.started(start)
+	for i := 1; i <= 100; i++ {
+		s.submitted(NativeTransfer)
+		s.included(NativeTransfer, time.Duration(i)*time.Millisecond)
+	}
+	s.submitted(ContractDeploy)
+	s.failed(ContractDeploy, FailureReverted)
+	s.failed(ContractDeploy, failureReasonOf(errors.New("nonce too low: address 0x0000000000000000000000000000000000000001, tx: 2 state: 3")))
+	s.failed(ContractDeploy, failureReasonOf(errors.New("nonce too high")))
+	s.unconfirmed(ContractDeploy)
+	s.stopped(start.Add(10 * time.Second))
+
+	report := s.report()
+	Ω(report.Duration).Should(Equal(10.0))
+	Ω(report.Workloads).Should(HaveLen(2))
+
+	deploy := report.Workloads[0]
+	Ω(deploy.Workload).Should(Equal(ContractDeploy))
+	Ω(deploy.Failed).Should(Equal(3))
+	Ω(deploy.Unconfirmed).Should(Equal(1))
+	Ω(deploy.Failures).Should(Equal(map[FailureReason]int{FailureReverted: 1, FailureNonce: 2}))
+	Ω(deploy.LatencyP50).Should(Equal(0.0))
+
+	native := report.Workloads[1]
+	Ω(native.Included).Should(Equal(100))
+	Ω(native.AchievedTPS).Should(Equal(10.0))
+	Ω(native.LatencyP50).Should(Equal(50.0))
+	Ω(native.LatencyP90).Should(Equal(90.0))
+	Ω(native.LatencyP99).Should(Equal(99.0))
+	Ω(native.LatencyMax).Should(Equal(100.0))
+}
+
+func TestFailureReasonOf(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := map[string]FailureReason{
+		"nonce too low":                                 FailureNonce,
+		"already known":                                 FailureNonce,
+		"replacement transaction underpriced":           FailureUnderpriced,
+		"gasprice is less than gas price minimum floor": FailureUnderpriced,
+		"max fee per gas less than block base fee":      FailureUnderpriced,
+		"insufficient funds for gas * price + value":    FailureFunds,
+		"execution reverted":                            FailureOther,
+		"Post \"http://localhost:8545\": i/o timeout":   FailureTimeout,
+	}
+	for msg, reason := range tests {
+		Ω(failureReasonOf(errors.New(msg))).Should(Equal(reason), msg)
+	}
+	Ω(failureReasonOf(fmt.Errorf("waiting: %w", context.DeadlineExceeded))).Should(Equal(FailureTimeout))
+}
+
+func TestPickWorkloadWithoutWeights(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(pickWorkload([]Workload{{Kind: NativeTransfer, Weight: 0}, {Kind: ContractDeploy, Weight: 0}})).Should(Equal(NativeTransfer))
+}
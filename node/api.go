@@ -323,6 +323,16 @@ func (api *publicAdminAPI) Peers() ([]*p2p.PeerInfo, error) {
 	return server.PeersInfo(), nil
 }
 
+// PeerPurposes retrieves the purposes of each connected peer, with the time each
+// purpose was added or removed.
+func (api *publicAdminAPI) PeerPurposes() ([]*p2p.PeerPurposesInfo, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.PeerPurposes(), nil
+}
+
 // NodeInfo retrieves all the information we know about the host node at the
 // protocol granularity.
 func (api *publicAdminAPI) NodeInfo() (*p2p.NodeInfo, error) {
@@ -148,6 +148,10 @@ type Config struct {
 	// HTTPPathPrefix specifies a path prefix on which http-rpc is to be served.
 	HTTPPathPrefix string `toml:",omitempty"`
 
+	// Health configures the checks run by the /health and /ready endpoints of
+	// the HTTP RPC server.
+	Health HealthConfig `toml:",omitempty"`
+
 	// WSHost is the host interface on which to start the websocket RPC server. If
 	// this field is empty, no websocket API endpoint will be started.
 	WSHost string
@@ -202,6 +206,31 @@ type Config struct {
 	// AllowUnprotectedTxs bool `toml:",omitempty"`
 }
 
+// HealthConfig configures the checks of the /health and /ready endpoints. The
+// zero value disables every check, so both endpoints always report healthy.
+type HealthConfig struct {
+	// MinPeers is the minimum number of peers needed to be healthy, by peer
+	// purpose: "all", "static", "trusted", "validator" or "proxy".
+	MinPeers map[string]int `toml:",omitempty"`
+
+	// MaxSyncLag is the number of blocks the node may be behind its best peer
+	// and still be ready to serve requests. Zero disables the check.
+	MaxSyncLag uint64 `toml:",omitempty"`
+
+	// SealWindow is the number of recent blocks checked for the signature of
+	// this validator in their ParentAggregatedSeal. The validator is unhealthy
+	// if it was elected for some of them but signed none. Zero disables the check.
+	SealWindow uint64 `toml:",omitempty"`
+
+	// ProxyLink enables checking that a proxy is connected to its proxied
+	// validator, or that a proxied validator is connected to its proxies.
+	ProxyLink bool `toml:",omitempty"`
+
+	// ReplicaState enables checking that a validator is validating if and only
+	// if it is the primary.
+	ReplicaState bool `toml:",omitempty"`
+}
+
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -51,12 +52,14 @@ type Node struct {
 	state         int        // Tracks state of node lifecycle
 
 	lock          sync.Mutex
-	lifecycles    []Lifecycle // All registered backends, services, and auxiliary services that have a lifecycle
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	lifecycles    []Lifecycle    // All registered backends, services, and auxiliary services that have a lifecycle
+	rpcAPIs       []rpc.API      // List of APIs currently provided by the node
+	http          *httpServer    //
+	ws            *httpServer    //
+	ipc           *ipcServer     // Stores information about the ipc http server
+	inprocHandler *rpc.Server    // In-process RPC request handler to process the API requests
+	health        *healthHandler // Liveness checks served on /health
+	ready         *healthHandler // Readiness checks served on /ready
 
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
@@ -165,6 +168,17 @@ func New(conf *Config) (*Node, error) {
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
 
+	// Configure the health endpoints.
+	node.health = newHealthHandler()
+	node.ready = newHealthHandler()
+	node.http.mux.Handle("/health", node.health)
+	node.http.mux.Handle("/ready", node.ready)
+	node.http.handlerNames["/health"] = "Health checks"
+	node.http.handlerNames["/ready"] = "Health checks"
+	if err := node.registerPeerHealthChecks(); err != nil {
+		return nil, err
+	}
+
 	return node, nil
 }
 
@@ -506,6 +520,87 @@ func (n *Node) RegisterHandler(name, path string, handler http.Handler) {
 	n.http.handlerNames[path] = name
 }
 
+// RegisterHealthCheck adds a check to both the /health and /ready endpoints of
+// the HTTP server. Checks should be cheap, they run on every request.
+func (n *Node) RegisterHealthCheck(name string, check HealthCheck) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't register health check on running/stopped node")
+	}
+	n.health.register(name, check)
+	n.ready.register(name, check)
+}
+
+// RegisterReadinessCheck adds a check to the /ready endpoint of the HTTP server
+// only. Use it for conditions in which the node is alive but shouldn't serve
+// requests yet, like while syncing.
+func (n *Node) RegisterReadinessCheck(name string, check HealthCheck) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.state != initializingState {
+		panic("can't register readiness check on running/stopped node")
+	}
+	n.ready.register(name, check)
+}
+
+// peerCountDetails is the JSON detail of the peers health check.
+type peerCountDetails struct {
+	Peers map[string]int `json:"peers"`
+	Min   map[string]int `json:"min"`
+}
+
+// registerPeerHealthChecks registers the check of the minimum peer counts
+// configured in HealthConfig.MinPeers, if any.
+func (n *Node) registerPeerHealthChecks() error {
+	min := n.config.Health.MinPeers
+	if len(min) == 0 {
+		return nil
+	}
+	// Peers are counted by purpose, or all of them for "all"
+	purposes := make(map[string]p2p.PurposeFlag, len(min))
+	names := make([]string, 0, len(min))
+	for name := range min {
+		if name != "all" {
+			purpose, err := p2p.ParsePurpose(name)
+			if err != nil {
+				return fmt.Errorf("invalid health config: %v", err)
+			}
+			purposes[name] = purpose
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	check := func() *HealthResult {
+		details := &peerCountDetails{Peers: make(map[string]int), Min: min}
+		for _, name := range names {
+			details.Peers[name] = 0
+		}
+		for _, peer := range n.server.Peers() {
+			for _, name := range names {
+				if purpose := purposes[name]; purpose == p2p.NoPurpose || peer.HasPurpose(purpose) {
+					details.Peers[name]++
+				}
+			}
+		}
+		result := &HealthResult{Healthy: true, Details: details}
+		for _, name := range names {
+			if details.Peers[name] < min[name] {
+				result.Healthy = false
+				result.Message = fmt.Sprintf("%d %s peers, need %d", details.Peers[name], name, min[name])
+				break
+			}
+		}
+		return result
+	}
+	n.health.register("peers", check)
+	n.ready.register("peers", check)
+	return nil
+}
+
 // Attach creates an RPC client attached to an in-process API handler.
 func (n *Node) Attach() (*rpc.Client, error) {
 	return rpc.DialInProc(n.inprocHandler), nil
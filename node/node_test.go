@@ -17,6 +17,7 @@
 package node
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -436,6 +437,54 @@ func TestRegisterHandler_Unsuccessful(t *testing.T) {
 	node.RegisterHandler("test", "/test", handler)
 }
 
+// Tests that the health checks are served on /health and the readiness checks
+// on /ready only.
+func TestHealthEndpoints(t *testing.T) {
+	node := createNode(t, 0, 0)
+	defer node.Close()
+
+	node.RegisterHealthCheck("live", func() *HealthResult {
+		return &HealthResult{Healthy: true, Message: "fine"}
+	})
+	node.RegisterReadinessCheck("synced", func() *HealthResult {
+		return &HealthResult{Healthy: false, Message: "syncing"}
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+
+	check := func(path string, wantStatus int, want healthResponse) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, node.HTTPEndpoint()+path, nil)
+		if err != nil {
+			t.Fatal("could not issue new http request ", err)
+		}
+		resp := doHTTPRequest(t, req)
+		defer resp.Body.Close()
+		assert.Equal(t, wantStatus, resp.StatusCode)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		var have healthResponse
+		if err := json.NewDecoder(resp.Body).Decode(&have); err != nil {
+			t.Fatalf("could not decode %s response: %v", path, err)
+		}
+		assert.Equal(t, want, have)
+	}
+	live := &HealthResult{Name: "live", Healthy: true, Message: "fine"}
+	synced := &HealthResult{Name: "synced", Healthy: false, Message: "syncing"}
+	check("/health", http.StatusOK, healthResponse{Healthy: true, Checks: []*HealthResult{live}})
+	check("/ready", http.StatusServiceUnavailable, healthResponse{Healthy: false, Checks: []*HealthResult{live, synced}})
+}
+
+// Tests that unknown peer purposes in the health config are rejected.
+func TestHealthConfigInvalidPurpose(t *testing.T) {
+	conf := testNodeConfig()
+	conf.Health.MinPeers = map[string]int{"all": 1, "miner": 1}
+	if _, err := New(conf); err == nil {
+		t.Fatal("node created with invalid peer purpose")
+	}
+}
+
 // Tests whether websocket requests can be handled on the same port as a regular http server.
 func TestWebsocketHTTPOnSamePort_WebsocketRequest(t *testing.T) {
 	node := startHTTP(t, 0, 0)
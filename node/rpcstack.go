@@ -19,6 +19,7 @@ package node
 import (
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -470,6 +471,86 @@ func newGzipHandler(next http.Handler) http.Handler {
 	})
 }
 
+// HealthResult is the outcome of a HealthCheck.
+type HealthResult struct {
+	Name    string      `json:"name"`
+	Healthy bool        `json:"healthy"`
+	Message string      `json:"message,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// HealthCheck reports whether one aspect of the node is healthy. The name of the
+// result is filled in by the health handler.
+type HealthCheck func() *HealthResult
+
+type namedHealthCheck struct {
+	name  string
+	check HealthCheck
+}
+
+// healthResponse is the JSON body served by the health endpoints.
+type healthResponse struct {
+	Healthy bool            `json:"healthy"`
+	Checks  []*HealthResult `json:"checks"`
+}
+
+// healthHandler serves the results of a list of health checks. It responds with
+// 200 if every check passes and with 503 otherwise.
+type healthHandler struct {
+	mu     sync.RWMutex
+	checks []namedHealthCheck
+}
+
+func newHealthHandler() *healthHandler {
+	return &healthHandler{}
+}
+
+// register adds a check to the handler.
+func (h *healthHandler) register(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedHealthCheck{name, check})
+}
+
+// run runs every check and collects their results.
+func (h *healthHandler) run() *healthResponse {
+	h.mu.RLock()
+	checks := make([]namedHealthCheck, len(h.checks))
+	copy(checks, h.checks)
+	h.mu.RUnlock()
+
+	response := &healthResponse{Healthy: true, Checks: make([]*HealthResult, 0, len(checks))}
+	for _, c := range checks {
+		result := c.check()
+		if result == nil {
+			result = &HealthResult{Healthy: true}
+		}
+		result.Name = c.name
+		response.Healthy = response.Healthy && result.Healthy
+		response.Checks = append(response.Checks, result)
+	}
+	return response
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	response := h.run()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	if response.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if r.Method == http.MethodGet {
+		json.NewEncoder(w).Encode(response)
+	}
+}
+
 type ipcServer struct {
 	log      log.Logger
 	endpoint string
@@ -142,6 +142,9 @@ type dialConfig struct {
 
 	// The time waited before redialling a certain node
 	dialHistoryExpiration time.Duration
+
+	// dialFailed is called when a connection to a node can't be established.
+	dialFailed func(n *enode.Node, err error)
 }
 
 func (cfg dialConfig) withDefaults() dialConfig {
@@ -547,6 +550,9 @@ func (t *dialTask) dial(d *dialScheduler, dest *enode.Node) error {
 	fd, err := d.dialer.Dial(d.ctx, t.dest)
 	if err != nil {
 		d.log.Trace("Dial error", "id", t.dest.ID(), "addr", nodeAddr(t.dest), "conn", t.flags, "err", cleanupDialErr(err))
+		if d.dialFailed != nil {
+			d.dialFailed(t.dest, err)
+		}
 		return &dialError{err}
 	}
 	mfd := newMeteredConn(fd, false, &net.TCPAddr{IP: dest.IP(), Port: dest.TCP()})
@@ -117,8 +117,9 @@ type Peer struct {
 	// events receives message send / receive events if set
 	events *event.Feed
 
-	purposesMu sync.Mutex
-	purposes   PurposeFlag
+	purposesMu   sync.Mutex
+	purposes     PurposeFlag
+	purposeTimes map[PurposeFlag]*purposeTimes // When each purpose was last added and removed
 
 	Server *Server
 
@@ -147,6 +148,7 @@ func (p *Peer) AddPurpose(purpose PurposeFlag) {
 		activeProxiesPeerGauge.Inc(1)
 	}
 
+	p.updatePurposeTimes(purpose, true)
 	p.purposes = p.purposes.Add(purpose)
 }
 
@@ -162,6 +164,7 @@ func (p *Peer) RemovePurpose(purpose PurposeFlag) {
 		activeProxiesPeerGauge.Dec(1)
 	}
 
+	p.updatePurposeTimes(purpose, false)
 	p.purposes = p.purposes.Remove(purpose)
 }
 
@@ -268,9 +271,12 @@ func newPeer(log log.Logger, conn *conn, protocols []Protocol, purpose PurposeFl
 		protoErr: make(chan error, len(protomap)+1), // protocols + pingLoop
 		closed:   make(chan struct{}),
 		log:      log.New("id", conn.node.ID(), "conn", conn.flags),
-		purposes: purpose,
 		Server:   server,
+
+		purposeTimes: make(map[PurposeFlag]*purposeTimes),
 	}
+	p.updatePurposeTimes(purpose, true)
+	p.purposes = purpose
 
 	// Increase connection metrics for proxies & validators
 	if p.purposes.IsSet(ValidatorPurpose) {
@@ -0,0 +1,226 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/celo-org/celo-blockchain/metrics"
+	"github.com/celo-org/celo-blockchain/p2p/enode"
+)
+
+// validatorConnFailuresMeterName is the prefix of the meters counting the
+// connections to validators that failed to establish, by reason.
+const validatorConnFailuresMeterName = "p2p/validators/connfailures"
+
+var validatorConnFailuresMeter = metrics.NewRegisteredMeter(validatorConnFailuresMeterName, nil)
+
+// purposeNames are the names of the single purposes, as used in configs and APIs.
+var purposeNames = []struct {
+	purpose PurposeFlag
+	name    string
+}{
+	{ExplicitStaticPurpose, "static"},
+	{ExplicitTrustedPurpose, "trusted"},
+	{ValidatorPurpose, "validator"},
+	{ProxyPurpose, "proxy"},
+}
+
+// ParsePurpose returns the purpose with the given name: "static", "trusted",
+// "validator" or "proxy".
+func ParsePurpose(name string) (PurposeFlag, error) {
+	for _, p := range purposeNames {
+		if p.name == name {
+			return p.purpose, nil
+		}
+	}
+	return NoPurpose, fmt.Errorf("unknown peer purpose %q", name)
+}
+
+// parseReservedPeers converts the purpose names of Config.ReservedPeers to flags.
+func parseReservedPeers(reserved map[string]int) (map[PurposeFlag]int, error) {
+	flags := make(map[PurposeFlag]int, len(reserved))
+	for name, slots := range reserved {
+		purpose, err := ParsePurpose(name)
+		if err != nil {
+			return nil, err
+		}
+		if slots < 0 {
+			return nil, fmt.Errorf("negative reserved peer slots for purpose %q", name)
+		}
+		flags[purpose] = slots
+	}
+	return flags, nil
+}
+
+// freeReservedSlots returns the number of reserved peer slots not taken by
+// peers with the purposes they are reserved for.
+func (srv *Server) freeReservedSlots() int {
+	if len(srv.reserved) == 0 {
+		return 0
+	}
+	var free int
+	srv.doPeerOp(func(peers map[enode.ID]*Peer) {
+		for purpose, slots := range srv.reserved {
+			for _, p := range peers {
+				if slots == 0 {
+					break
+				}
+				if p.HasPurpose(purpose) {
+					slots--
+				}
+			}
+			free += slots
+		}
+	})
+	return free
+}
+
+// setNodePurposes records the static and trusted purposes of a node, so that
+// they can be looked up outside of the run loop.
+func (srv *Server) setNodePurposes(id enode.ID, purposes PurposeFlag) {
+	srv.nodePurposesMu.Lock()
+	defer srv.nodePurposesMu.Unlock()
+
+	if purposes.HasNoPurpose() {
+		delete(srv.nodePurposes, id)
+	} else {
+		srv.nodePurposes[id] = purposes
+	}
+}
+
+// isValidatorNode returns whether the node was added with the validator purpose.
+func (srv *Server) isValidatorNode(id enode.ID) bool {
+	srv.nodePurposesMu.RLock()
+	defer srv.nodePurposesMu.RUnlock()
+
+	return srv.nodePurposes[id].IsSet(ValidatorPurpose)
+}
+
+// validatorConnFailed counts a connection to or from a validator that failed to
+// establish, by reason.
+func (srv *Server) validatorConnFailed(id enode.ID, reason string) {
+	if !metrics.Enabled || !srv.isValidatorNode(id) {
+		return
+	}
+	validatorConnFailuresMeter.Mark(1)
+	metrics.GetOrRegisterMeter(validatorConnFailuresMeterName+"/"+reason, nil).Mark(1)
+}
+
+// connFailureReason classifies the error of a connection setup. DiscReasons,
+// sent by either side, are reported as such, other errors by the handshake
+// that failed.
+func connFailureReason(c *conn, err error) string {
+	if reason, ok := err.(DiscReason); ok {
+		return strings.ReplaceAll(reason.String(), " ", "_")
+	}
+	if c.node == nil {
+		return "enc_handshake"
+	}
+	return "proto_handshake"
+}
+
+// dialFailureReason classifies the error of a failed dial.
+func dialFailureReason(err error) string {
+	if err, ok := err.(interface{ Timeout() bool }); ok && err.Timeout() {
+		return "dial_timeout"
+	}
+	return "dial"
+}
+
+// purposeTimes holds when a purpose was last added to and removed from a peer.
+type purposeTimes struct {
+	added, removed time.Time
+}
+
+// PeerPurposeInfo describes a purpose a peer has, or had.
+type PeerPurposeInfo struct {
+	Purpose string     `json:"purpose"`
+	Active  bool       `json:"active"`
+	Added   time.Time  `json:"added"`
+	Removed *time.Time `json:"removed,omitempty"` // Set if the purpose was removed
+}
+
+// PeerPurposesInfo lists the purposes of a connected peer.
+type PeerPurposesInfo struct {
+	ID       string             `json:"id"`
+	Name     string             `json:"name"`
+	Enode    string             `json:"enode"`
+	Purposes []*PeerPurposeInfo `json:"purposes"`
+}
+
+// updatePurposeTimes records the time the purposes were added or removed.
+// It must be called with purposesMu held, before the purposes are updated.
+func (p *Peer) updatePurposeTimes(purpose PurposeFlag, added bool) {
+	now := time.Now()
+	for _, name := range purposeNames {
+		if !purpose.IsSet(name.purpose) {
+			continue
+		}
+		times := p.purposeTimes[name.purpose]
+		switch {
+		case added && !p.purposes.IsSet(name.purpose):
+			p.purposeTimes[name.purpose] = &purposeTimes{added: now}
+		case !added && p.purposes.IsSet(name.purpose) && times != nil:
+			times.removed = now
+		}
+	}
+}
+
+// PurposesInfo returns the purposes the peer has had since it connected.
+func (p *Peer) PurposesInfo() *PeerPurposesInfo {
+	p.purposesMu.Lock()
+	defer p.purposesMu.Unlock()
+
+	info := &PeerPurposesInfo{
+		ID:       p.ID().String(),
+		Name:     p.Fullname(),
+		Enode:    p.Node().URLv4(),
+		Purposes: make([]*PeerPurposeInfo, 0, len(p.purposeTimes)),
+	}
+	for _, name := range purposeNames {
+		times, ok := p.purposeTimes[name.purpose]
+		if !ok {
+			continue
+		}
+		purpose := &PeerPurposeInfo{
+			Purpose: name.name,
+			Active:  p.purposes.IsSet(name.purpose),
+			Added:   times.added,
+		}
+		if !purpose.Active {
+			removed := times.removed
+			purpose.Removed = &removed
+		}
+		info.Purposes = append(info.Purposes, purpose)
+	}
+	return info
+}
+
+// PeerPurposes returns the purposes of all connected peers, sorted by node identifier.
+func (srv *Server) PeerPurposes() []*PeerPurposesInfo {
+	peers := srv.Peers()
+	infos := make([]*PeerPurposesInfo, 0, len(peers))
+	for _, p := range peers {
+		infos = append(infos, p.PurposesInfo())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
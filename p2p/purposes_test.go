@@ -0,0 +1,118 @@
+// Copyright 2021 The Celo Authors
+// This file is part of the celo library.
+//
+// The celo library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The celo library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the celo library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/celo-org/celo-blockchain/internal/testlog"
+	"github.com/celo-org/celo-blockchain/log"
+	"github.com/celo-org/celo-blockchain/p2p/enode"
+	"github.com/celo-org/celo-blockchain/p2p/enr"
+)
+
+func TestParsePurpose(t *testing.T) {
+	for _, p := range purposeNames {
+		if purpose, err := ParsePurpose(p.name); err != nil || purpose != p.purpose {
+			t.Errorf("purpose %q: have %v (err %v), want %v", p.name, purpose, err, p.purpose)
+		}
+	}
+	if _, err := ParsePurpose("miner"); err == nil {
+		t.Error("unknown purpose parsed")
+	}
+	if _, err := parseReservedPeers(map[string]int{"validator": -1}); err == nil {
+		t.Error("negative reserved slots accepted")
+	}
+}
+
+func TestPeerPurposesInfo(t *testing.T) {
+	p := NewPeer(randomID(), "test", nil)
+	p.AddPurpose(ValidatorPurpose | ProxyPurpose)
+	p.RemovePurpose(ProxyPurpose)
+
+	info := p.PurposesInfo()
+	if len(info.Purposes) != 2 {
+		t.Fatalf("purpose count mismatch: have %d, want 2", len(info.Purposes))
+	}
+	validator, proxy := info.Purposes[0], info.Purposes[1]
+	if validator.Purpose != "validator" || !validator.Active || validator.Removed != nil || validator.Added.IsZero() {
+		t.Errorf("validator purpose mismatch: %+v", validator)
+	}
+	if proxy.Purpose != "proxy" || proxy.Active || proxy.Removed == nil || proxy.Removed.Before(proxy.Added) {
+		t.Errorf("proxy purpose mismatch: %+v", proxy)
+	}
+
+	// Adding a purpose again resets its times
+	p.AddPurpose(ProxyPurpose)
+	if proxy = p.PurposesInfo().Purposes[1]; !proxy.Active || proxy.Removed != nil {
+		t.Errorf("re-added proxy purpose mismatch: %+v", proxy)
+	}
+}
+
+func TestServerReservedPeers(t *testing.T) {
+	srv := &Server{
+		Config: Config{
+			PrivateKey:    newkey(),
+			MaxPeers:      4,
+			NoDial:        true,
+			NoDiscovery:   true,
+			ReservedPeers: map[string]int{"validator": 2},
+			Logger:        testlog.Logger(t, log.LvlTrace),
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv.Stop()
+
+	addPeer := func() *Peer {
+		fd, _ := net.Pipe()
+		node := enode.SignNull(new(enr.Record), randomID())
+		c := &conn{fd: fd, transport: newTestTransport(&newkey().PublicKey, fd, nil), flags: inboundConn, node: node, cont: make(chan error)}
+		if err := srv.checkpoint(c, srv.checkpointAddPeer); err != nil {
+			t.Fatalf("could not add conn: %v", err)
+		}
+		for _, p := range srv.Peers() {
+			if p.ID() == node.ID() {
+				return p
+			}
+		}
+		t.Fatal("peer not added")
+		return nil
+	}
+
+	// Plain peers can only take the slots that aren't reserved
+	first := addPeer()
+	second := addPeer()
+	if err := srv.CheckPeerCounts(second); err != nil {
+		t.Errorf("unexpected error for unreserved slot: %v", err)
+	}
+	third := addPeer()
+	if err := srv.CheckPeerCounts(third); err != DiscTooManyPeers {
+		t.Errorf("wrong error for reserved slot: %v", err)
+	}
+
+	// A validator taking a reserved slot leaves only one slot reserved
+	srv.AddPeer(first.Node(), ValidatorPurpose)
+	if free := srv.freeReservedSlots(); free != 1 {
+		t.Errorf("free reserved slots mismatch: have %d, want 1", free)
+	}
+	if err := srv.CheckPeerCounts(third); err != nil {
+		t.Errorf("unexpected error once a validator took a reserved slot: %v", err)
+	}
+}
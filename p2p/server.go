@@ -118,6 +118,12 @@ type Config struct {
 	// allowed to connect, even above the peer limit.
 	TrustedNodes []*enode.Node
 
+	// ReservedPeers is the number of peer slots reserved for peers with each
+	// purpose, by purpose name (see ParsePurpose). Peers without a purpose
+	// can't take the free reserved slots, so they can't crowd out the
+	// connections to validators or proxies.
+	ReservedPeers map[string]int `toml:",omitempty"`
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
@@ -224,6 +230,10 @@ type Server struct {
 
 	// State of run loop and listenLoop.
 	inboundHistory expHeap
+
+	reserved       map[PurposeFlag]int      // ReservedPeers by purpose
+	nodePurposesMu sync.RWMutex             // protects nodePurposes
+	nodePurposes   map[enode.ID]PurposeFlag // Static and trusted purposes of each node, mirrored from the run loop
 }
 
 type peerOpFunc func(peers map[enode.ID]*Peer)
@@ -559,6 +569,10 @@ func (srv *Server) Start() (err error) {
 	if srv.listenFunc == nil {
 		srv.listenFunc = net.Listen
 	}
+	if srv.reserved, err = parseReservedPeers(srv.ReservedPeers); err != nil {
+		return err
+	}
+	srv.nodePurposes = make(map[enode.ID]PurposeFlag)
 	srv.quit = make(chan struct{})
 	srv.delpeer = make(chan peerDrop)
 	srv.checkpointPostHandshake = make(chan *conn)
@@ -728,6 +742,9 @@ func (srv *Server) setupDialScheduler() {
 		dialer:                srv.Dialer,
 		clock:                 srv.clock,
 		dialHistoryExpiration: srv.DialHistoryExpiration,
+		dialFailed: func(n *enode.Node, err error) {
+			srv.validatorConnFailed(n.ID(), dialFailureReason(err))
+		},
 	}
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
@@ -821,9 +838,21 @@ func (srv *Server) run() {
 		static[n.ID()] = ExplicitStaticPurpose
 	}
 
+	// Mirror the purposes of the nodes for lookups outside of the run loop.
+	syncPurposes := func(id enode.ID) {
+		srv.setNodePurposes(id, static[id].Add(trusted[id]))
+	}
+	for id := range trusted {
+		syncPurposes(id)
+	}
+	for id := range static {
+		syncPurposes(id)
+	}
+
 	addStatic := func(n *enode.Node, purpose PurposeFlag) {
 		newPurpose := static[n.ID()].Add(purpose)
 		static[n.ID()] = newPurpose
+		syncPurposes(n.ID())
 
 		// If already connected, set the peer's static node purpose set
 		if p, ok := peers[n.ID()]; ok {
@@ -862,6 +891,7 @@ func (srv *Server) run() {
 				p.RemovePurpose(purpose)
 			}
 		}
+		syncPurposes(n.ID())
 		if !disconnecting {
 			// We aren't disconnecting the peer, so no need to wait for anything further
 			close(done)
@@ -870,6 +900,7 @@ func (srv *Server) run() {
 
 	addTrusted := func(n *enode.Node, purpose PurposeFlag) {
 		trusted[n.ID()] = trusted[n.ID()].Add(purpose)
+		syncPurposes(n.ID())
 
 		// Mark any already-connected peer as trusted
 		if p, ok := peers[n.ID()]; ok {
@@ -895,6 +926,7 @@ func (srv *Server) run() {
 				p.RemovePurpose(purpose)
 			}
 		}
+		syncPurposes(n.ID())
 	}
 
 running:
@@ -1025,7 +1057,8 @@ func (srv *Server) CheckPeerCounts(peer *Peer) error {
 	case peer.Info().Network.Trusted || peer.Info().Network.Static:
 		return nil
 	// KJUE - Remove the peerOp not nil check after restoring peer check in server.go
-	case srv.peerOp != nil && (srv.PeerCount() > srv.MaxPeers):
+	// Peers without a purpose can't take the slots reserved for other purposes.
+	case srv.peerOp != nil && (srv.PeerCount() > srv.MaxPeers-srv.freeReservedSlots()):
 		return DiscTooManyPeers
 	case srv.inboundCount() > srv.maxInboundConns():
 		return DiscTooManyInboundPeers
@@ -1137,6 +1170,13 @@ func (srv *Server) SetupConn(fd net.Conn, flags connFlag, dialDest *enode.Node)
 
 	err := srv.setupConn(c, flags, dialDest)
 	if err != nil {
+		if err != errServerStopped {
+			if dialDest != nil {
+				srv.validatorConnFailed(dialDest.ID(), connFailureReason(c, err))
+			} else if c.node != nil {
+				srv.validatorConnFailed(c.node.ID(), connFailureReason(c, err))
+			}
+		}
 		c.close(err)
 	}
 	return err
@@ -53,6 +53,10 @@ func cmp(x uint64, y uint64) int {
 	return 0
 }
 
+func (v *VersionInfo) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
 func (v *VersionInfo) Cmp(version *VersionInfo) int {
 	if v.Major == version.Major {
 		if v.Minor == version.Minor {
@@ -22,19 +22,26 @@
 package fourbyte
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+
+	ethereum "github.com/celo-org/celo-blockchain"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
 )
 
 // Database is a 4byte database with the possibility of maintaining an immutable
 // set (embedded) into the process and a mutable set (loaded and written to file).
+//
+// Calls to the celo core contracts are identified from their bundled ABIs first.
 type Database struct {
 	embedded   map[string]string
 	custom     map[string]string
 	customPath string
+	celo       *abis.Decoder
 }
 
 // newEmpty exists for testing purposes.
@@ -42,6 +49,7 @@ func newEmpty() *Database {
 	return &Database{
 		embedded: make(map[string]string),
 		custom:   make(map[string]string),
+		celo:     abis.NewDecoder(),
 	}
 }
 
@@ -74,7 +82,7 @@ func NewFromFile(path string) (*Database, error) {
 // file) as well as a custom database. The latter will be used to write new
 // values into if they are submitted via the API.
 func NewWithFile(path string) (*Database, error) {
-	db := &Database{make(map[string]string), make(map[string]string), path}
+	db := &Database{make(map[string]string), make(map[string]string), path, abis.NewDecoder()}
 	db.customPath = path
 
 	blob, err := Asset("4byte.json")
@@ -96,6 +104,13 @@ func NewWithFile(path string) (*Database, error) {
 	return db, nil
 }
 
+// ResolveCeloRegistry resolves the addresses of the celo core contracts through
+// the registry at the latest block, so that calls to them are decoded with the ABI
+// of the called contract.
+func (db *Database) ResolveCeloRegistry(ctx context.Context, caller ethereum.ContractCaller) error {
+	return db.celo.ResolveRegistryAt(ctx, caller, nil)
+}
+
 // Size returns the number of 4byte entries in the embedded and custom datasets.
 func (db *Database) Size() (int, int) {
 	return len(db.embedded), len(db.custom)
@@ -77,6 +77,10 @@ func (db *Database) ValidateTransaction(selector *string, tx *apitypes.SendTxArg
 	if bytes.Equal(tx.To.Address().Bytes(), common.Address{}.Bytes()) {
 		messages.Crit("Transaction recipient is the zero address")
 	}
+	to := tx.To.Address()
+	if name, ok := db.celo.ContractName(to); ok {
+		messages.Info(fmt.Sprintf("Transaction recipient is the celo core contract %s", name))
+	}
 	switch {
 	case tx.GasPrice == nil && tx.MaxFeePerGas == nil:
 		messages.Crit("Neither 'gasPrice' nor 'maxFeePerGas' specified.")
@@ -88,13 +92,14 @@ func (db *Database) ValidateTransaction(selector *string, tx *apitypes.SendTxArg
 		messages.Crit("Both 'gasPrice' and 'maxPriorityFeePerGas' specified.")
 	}
 	// Semantic fields validated, try to make heads or tails of the call data
-	db.ValidateCallData(selector, data, messages)
+	db.ValidateCallData(&to, selector, data, messages)
 	return messages, nil
 }
 
 // ValidateCallData checks if the ABI call-data + method selector (if given) can
-// be parsed and seems to match.
-func (db *Database) ValidateCallData(selector *string, data []byte, messages *apitypes.ValidationMessages) {
+// be parsed and seems to match. The recipient of the call may be nil if it is
+// not known.
+func (db *Database) ValidateCallData(to *common.Address, selector *string, data []byte, messages *apitypes.ValidationMessages) {
 	// If the data is empty, we have a plain value transfer, nothing more to do
 	if len(data) == 0 {
 		return
@@ -117,9 +122,21 @@ func (db *Database) ValidateCallData(selector *string, data []byte, messages *ap
 		}
 		return
 	}
-	// No method selector was provided, check the database for embedded ones
+	// No method selector was provided, check the celo core contracts first. Calls
+	// to addresses not resolved as core contracts are only decoded if the database
+	// doesn't know the selector, without naming a contract.
+	celoCall, err := db.celo.Decode(to, data)
+	if err == nil && celoCall.Contract != "" {
+		messages.Info(fmt.Sprintf("Transaction invokes the following method: %q", celoCall.String()))
+		return
+	}
+	// Check the database for embedded ones
 	embedded, err := db.Selector(data[:4])
 	if err != nil {
+		if celoCall != nil {
+			messages.Info(fmt.Sprintf("Transaction invokes the following method: %q", celoCall.String()))
+			return
+		}
 		messages.Warn(fmt.Sprintf("Transaction contains data, but the ABI signature could not be found: %v", err))
 		return
 	}
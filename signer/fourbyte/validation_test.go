@@ -18,10 +18,13 @@ package fourbyte
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/celo-org/celo-blockchain/common"
 	"github.com/celo-org/celo-blockchain/common/hexutil"
+	"github.com/celo-org/celo-blockchain/contracts/abis"
+	"github.com/celo-org/celo-blockchain/params"
 	"github.com/celo-org/celo-blockchain/signer/core/apitypes"
 )
 
@@ -135,3 +138,74 @@ func TestTransactionValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestCeloCallValidation(t *testing.T) {
+	db := newEmpty()
+	stableToken := common.HexToAddress("0x765DE816845861e75A25fCA122bb6898B8B1282a")
+	db.celo.ResolveRegistry(func(registryId common.Hash) (common.Address, error) {
+		if registryId == params.StableTokenRegistryId {
+			return stableToken, nil
+		}
+		return common.Address{}, nil
+	})
+	data, err := abis.StableToken.Pack("transferWithComment", common.HexToAddress("0xdead"), big.NewInt(1000), "rent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := db.ValidateTransaction(nil, dummyTxArgs(txtestcase{
+		from: "000000000000000000000000000000000000dead", to: stableToken.Hex(),
+		n: "0x01", g: "0x20", gp: "0x40", value: "0x00", d: hexutil.Encode(data),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Transaction recipient is the celo core contract StableToken",
+		`Transaction invokes the following method: "StableToken.transferWithComment(to: 0x000000000000000000000000000000000000dEaD, value: 1000, comment: \"rent\")"`,
+	}
+	if len(msgs.Messages) != len(want) {
+		t.Fatalf("expected %d messages, got %d: %v", len(want), len(msgs.Messages), msgs.Messages)
+	}
+	for i, msg := range msgs.Messages {
+		if msg.Typ != "Info" || msg.Message != want[i] {
+			t.Errorf("message %d: have %s: %s, want Info: %s", i, msg.Typ, msg.Message, want[i])
+		}
+	}
+}
+
+func TestCeloCallValidationUnresolvedAddress(t *testing.T) {
+	db := newEmpty()
+	data, err := abis.StableToken.Pack("transferWithComment", common.HexToAddress("0xdead"), big.NewInt(1000), "rent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A core contract method called on an arbitrary address is not attributed to
+	// the core contract
+	msgs, err := db.ValidateTransaction(nil, dummyTxArgs(txtestcase{
+		from: "000000000000000000000000000000000000dead", to: common.HexToAddress("0xbeef").Hex(),
+		n: "0x01", g: "0x20", gp: "0x40", value: "0x00", d: hexutil.Encode(data),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Transaction invokes the following method: "transferWithComment(to: 0x000000000000000000000000000000000000dEaD, value: 1000, comment: \"rent\")"`
+	if len(msgs.Messages) != 1 || msgs.Messages[0].Typ != "Info" || msgs.Messages[0].Message != want {
+		t.Fatalf("have %v, want Info: %s", msgs.Messages, want)
+	}
+	// Selectors known to the database take precedence
+	if err := db.AddSelector("transferWithComment(address,uint256,string)", data[:4]); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = db.ValidateTransaction(nil, dummyTxArgs(txtestcase{
+		from: "000000000000000000000000000000000000dead", to: common.HexToAddress("0xbeef").Hex(),
+		n: "0x01", g: "0x20", gp: "0x40", value: "0x00", d: hexutil.Encode(data),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, msg := range msgs.Messages {
+		if strings.Contains(msg.Message, "StableToken") {
+			t.Errorf("call to unresolved address attributed to StableToken: %s", msg.Message)
+		}
+	}
+}